@@ -0,0 +1,49 @@
+// Command bootstrap-admin makes a registered user the first admin, who can
+// then promote others through the API. It refuses once any admin exists, so it
+// only works on a fresh system. Register the account yourself first, since
+// emails are not verified.
+//
+//	go run ./cmd/bootstrap-admin -email admin@example.com
+//
+// It reads the same database settings as the server, and is built into its
+// image, so with docker compose run it as
+//
+//	docker compose exec backend ./bootstrap-admin -email admin@example.com
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/platform/db"
+	"geraldaddo.com/live-voting-system/platform/log"
+	"github.com/lpernett/godotenv"
+)
+
+func main() {
+	email := flag.String("email", "", "email of the registered user to make the first admin")
+	flag.Parse()
+	if *email == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	_ = godotenv.Load("../.env.development")
+
+	logger, cleanup := log.InitLog()
+	defer cleanup()
+	DB := db.InitDB(logger, db.GetDBUrl(logger), 1, 1)
+	defer DB.Close()
+
+	service := user.NewUserService(user.NewUserRepository(DB), logger)
+	ctx := context.WithValue(context.Background(), "requestId", "bootstrap-admin")
+	err := service.BootstrapAdmin(ctx, *email)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Could not make the first admin:", err.Error())
+		cleanup()
+		os.Exit(1)
+	}
+	fmt.Println("Made " + *email + " the first admin")
+}
@@ -0,0 +1,71 @@
+// Command verify-certificate checks a results certificate offline against the
+// public key published at /certificates/public-key.
+//
+//	go run ./cmd/verify-certificate -certificate certificate.json -key public-key.json
+//
+// The key may also be given as the base64 encoding of the raw Ed25519 key.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"geraldaddo.com/live-voting-system/domain/result"
+	"geraldaddo.com/live-voting-system/platform/signing"
+)
+
+func main() {
+	certificatePath := flag.String("certificate", "", "path to the certificate from /elections/:id/certificate")
+	keyPath := flag.String("key", "", "path to the public key from /certificates/public-key")
+	flag.Parse()
+	if *certificatePath == "" || *keyPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	err := verify(*certificatePath, *keyPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Certificate is NOT valid:", err.Error())
+		os.Exit(1)
+	}
+}
+
+func verify(certificatePath string, keyPath string) error {
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return err
+	}
+	key, err := signing.ParsePublicKey(data)
+	if err != nil {
+		return err
+	}
+	data, err = os.ReadFile(certificatePath)
+	if err != nil {
+		return err
+	}
+	var certificate signing.Certificate
+	err = json.Unmarshal(data, &certificate)
+	if err != nil {
+		return fmt.Errorf("could not parse certificate: %w", err)
+	}
+	err = signing.Verify(&certificate, key)
+	if err != nil {
+		return err
+	}
+	var document result.CertificateDocument
+	err = json.Unmarshal([]byte(certificate.Document), &document)
+	if err != nil {
+		return fmt.Errorf("could not parse certified results: %w", err)
+	}
+	fmt.Println("Certificate is valid")
+	fmt.Printf("Election:     %s (%s)\n", document.Title, document.ElectionId)
+	fmt.Printf("Method:       %s\n", document.Method)
+	fmt.Printf("Certified:    %s by %s\n", document.CertifiedAt.Format("2006-01-02 15:04:05 MST"), document.CertifiedBy)
+	fmt.Printf("Ballots:      %d (%d blank)\n", document.Results.TotalBallots, document.Results.BlankBallots)
+	fmt.Printf("Ballot hash:  %s\n", document.BallotHash)
+	fmt.Printf("Winners:      %v\n", document.Results.Winners)
+	fmt.Printf("Signing key:  %s\n", certificate.KeyId)
+	return nil
+}
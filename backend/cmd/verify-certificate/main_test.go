@@ -0,0 +1,102 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/result"
+	"geraldaddo.com/live-voting-system/domain/tally"
+	"geraldaddo.com/live-voting-system/platform/signing"
+)
+
+// writeCertificate signs a document the way the server does and writes the
+// certificate and the published public key to files, as a user would save
+// them.
+func writeCertificate(t *testing.T, tamper func(certificate *signing.Certificate)) (string, string) {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal("Could not generate key", err.Error())
+	}
+	signer := signing.NewSigner(key)
+	document := result.CertificateDocument{
+		ElectionId: "test-election-id",
+		Title: "Test election",
+		CertifiedBy: "test-admin-id",
+		CertifiedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Results: result.Result{Result: tally.Result{Winners: []string{"candidate-1"}}},
+	}
+	certificate, err := signer.Sign(document)
+	if err != nil {
+		t.Fatal("Could not sign certificate", err.Error())
+	}
+	tamper(certificate)
+
+	dir := t.TempDir()
+	certificatePath := filepath.Join(dir, "certificate.json")
+	keyPath := filepath.Join(dir, "public-key.json")
+	writeJSON(t, certificatePath, certificate)
+	writeJSON(t, keyPath, signer.PublicKey())
+	return certificatePath, keyPath
+}
+
+func writeJSON(t *testing.T, path string, value any) {
+	t.Helper()
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		t.Fatal("Could not encode " + path, err.Error())
+	}
+	err = os.WriteFile(path, data, 0600)
+	if err != nil {
+		t.Fatal("Could not write " + path, err.Error())
+	}
+}
+
+func TestVerify(t *testing.T) {
+	certificatePath, keyPath := writeCertificate(t, func(certificate *signing.Certificate) {})
+
+	err := verify(certificatePath, keyPath)
+
+	if err != nil {
+		t.Error("Could not verify certificate", err.Error())
+	}
+}
+
+func TestVerifyShouldRejectTamperedCertificate(t *testing.T) {
+	tests := []struct {
+		name string
+		tamper func(certificate *signing.Certificate)
+	}{
+		{
+			"Changed winner",
+			func(certificate *signing.Certificate) {
+				certificate.Document = strings.Replace(certificate.Document, "candidate-1", "candidate-2", 1)
+			},
+		},
+		{
+			"Changed signature",
+			func(certificate *signing.Certificate) {
+				certificate.Signature[0] ^= 0xff
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			certificatePath, keyPath := writeCertificate(t, test.tamper)
+
+			err := verify(certificatePath, keyPath)
+
+			if !errors.Is(err, signing.ErrInvalidSignature) {
+				t.Errorf("Expected error: %v but got %v", signing.ErrInvalidSignature, err)
+			}
+		})
+	}
+}
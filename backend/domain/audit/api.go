@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AuditAPI struct {
+	service *AuditService
+	log *zap.Logger
+}
+
+func NewAuditAPI(service *AuditService, logger *zap.Logger) *AuditAPI {
+	return &AuditAPI{service: service, log: logger}
+}
+
+func (api *AuditAPI) RegisterRoutes(server *gin.Engine) {
+	server.GET("/audit", user.Require(user.ReadAudit), api.getEvents)
+}
+
+// Audit is a middleware that records every request of a signed in user that
+// a route's declared permission turned away. Anonymous requests are not
+// recorded, so that they cannot fill the audit log, and neither are other
+// 403s, which services use for refusals that are not about permissions, such
+// as voters missing from the electoral roll.
+func (api *AuditAPI) Audit(ctx *gin.Context) {
+	ctx.Next()
+	value, denied := ctx.Get(user.DeniedKey)
+	permission, _ := value.(user.Permission)
+	u, signedIn := user.FromContext(ctx)
+	if !denied || !signedIn {
+		return
+	}
+	event := &Event{
+		UserId: u.ID,
+		Role: string(u.Role),
+		Method: ctx.Request.Method,
+		Path: ctx.Request.URL.Path,
+		Permission: string(permission),
+		Status: ctx.Writer.Status(),
+		RequestId: ctx.GetString("requestId"),
+		IPAddress: ctx.ClientIP(),
+	}
+	api.service.Record(context.WithoutCancel(ctx), event)
+}
+
+func (api *AuditAPI) getEvents(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		api.log.Error("could not parse page number", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse page number"})
+		return
+	}
+	pageSize, err := strconv.Atoi(ctx.DefaultQuery("size", "10"))
+	if err != nil || pageSize < 1 {
+		api.log.Error("could not parse page size", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse page size"})
+		return
+	}
+	queryParams := EventQueryParams{
+		UserId: ctx.Query("userId"),
+		Limit: pageSize,
+		Offset: (page - 1) * pageSize,
+	}
+	events, err := api.service.GetEvents(ctx, queryParams)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, events)
+}
@@ -0,0 +1,73 @@
+package audit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/audit"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/mocks"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestAuditShouldRecordPermissionDenials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	baseUser := &user.User{ID: "test-user-id", Role: user.Base}
+	tests := []struct {
+		name string
+		user *user.User
+		path string
+		expected *audit.Event
+	}{
+		{"Missing permission", baseUser, "/admin", &audit.Event{UserId: "test-user-id", Role: "base", Method: "GET", Path: "/admin", Permission: string(user.ManageElections), Status: 403, RequestId: "test-request-id"}},
+		{"Not signed in", nil, "/admin", nil},
+		{"Refused by a service", baseUser, "/refused", nil},
+		{"Allowed request", baseUser, "/allowed", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockAuditRepository := mocks.NewMockAuditRepository(ctrl)
+			if test.expected != nil {
+				mockAuditRepository.
+					EXPECT().
+					Save(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, event *audit.Event) error {
+						event.IPAddress = ""
+						if *event != *test.expected {
+							t.Errorf("Expected event: %+v but got %+v", test.expected, event)
+						}
+						return nil
+					}).
+					Times(1)
+			}
+			api := audit.NewAuditAPI(audit.NewAuditService(mockAuditRepository, zap.NewNop()), zap.NewNop())
+			server := gin.New()
+			server.Use(api.Audit)
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("requestId", "test-request-id")
+				if test.user != nil {
+					ctx.Set(user.ContextKey, test.user)
+				}
+			})
+			server.GET("/admin", user.Require(user.ManageElections), func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, gin.H{"message": "ok"})
+			})
+			server.GET("/refused", user.Require(user.ReadElections), func(ctx *gin.Context) {
+				ctx.JSON(http.StatusForbidden, gin.H{"message": "refused"})
+			})
+			server.GET("/allowed", user.Require(user.ReadElections), func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, gin.H{"message": "ok"})
+			})
+			recorder := httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", test.path, nil)
+			server.ServeHTTP(recorder, request)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package audit
+
+import "time"
+
+// Event records a request of a signed in user that was refused for lack of
+// permission.
+type Event struct {
+	ID string
+	UserId string
+	Role string
+	Method string
+	Path string
+	// Permission is the permission the route declares.
+	Permission string
+	Status int
+	RequestId string
+	IPAddress string
+	CreatedAt time.Time
+}
+
+type EventQueryParams struct {
+	UserId string
+	Limit int
+	Offset int
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+)
+
+//go:generate mockgen -destination=../../mocks/mock_audit_repo.go -package=mocks . AuditRepository
+type AuditRepository interface {
+	Save(ctx context.Context, event *Event) error
+	GetAllWithFilters(ctx context.Context, params EventQueryParams) ([]Event, error)
+}
+
+type AuditRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepositoryImpl {
+	return &AuditRepositoryImpl{db: db}
+}
+
+func (repo *AuditRepositoryImpl) Save(ctx context.Context, event *Event) error {
+	insertStatement := `
+	INSERT INTO audit_events(user_id, role, method, path, permission, status, request_id, ip_address)
+	VALUES (NULLIF($1, '')::UUID, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING id, created_at`
+	return repo.db.QueryRow(
+		insertStatement,
+		event.UserId,
+		event.Role,
+		event.Method,
+		event.Path,
+		event.Permission,
+		event.Status,
+		event.RequestId,
+		event.IPAddress,
+	).Scan(&event.ID, &event.CreatedAt)
+}
+
+func (repo *AuditRepositoryImpl) GetAllWithFilters(ctx context.Context, params EventQueryParams) ([]Event, error) {
+	query := `
+	SELECT id, COALESCE(user_id::TEXT, ''), role, method, path, permission, status, request_id, ip_address, created_at
+	FROM audit_events
+	WHERE $1 = '' OR user_id::TEXT = $1
+	ORDER BY created_at DESC
+	LIMIT $2 OFFSET $3
+	`
+	rows, err := repo.db.Query(query, params.UserId, params.Limit, params.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var events []Event
+	for rows.Next() {
+		var event Event
+		err := rows.Scan(
+			&event.ID,
+			&event.UserId,
+			&event.Role,
+			&event.Method,
+			&event.Path,
+			&event.Permission,
+			&event.Status,
+			&event.RequestId,
+			&event.IPAddress,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
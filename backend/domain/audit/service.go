@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+type AuditService struct {
+	repo AuditRepository
+	log *zap.Logger
+}
+
+func NewAuditService(repo AuditRepository, logger *zap.Logger) *AuditService {
+	return &AuditService{repo: repo, log: logger}
+}
+
+// Record stores a refused request. The request has already been answered, so
+// failures are only logged, together with the event so it is not lost.
+func (service *AuditService) Record(ctx context.Context, event *Event) {
+	service.log.Warn(
+		"Refused request",
+		zap.String("request_id", event.RequestId),
+		zap.String("user_id", event.UserId),
+		zap.String("permission", event.Permission),
+		zap.String("path", event.Method + " " + event.Path),
+		zap.Int("status", event.Status),
+	)
+	err := service.repo.Save(ctx, event)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not audit refused request", zap.String("request_id", event.RequestId))
+	}
+}
+
+func (service *AuditService) GetEvents(ctx context.Context, params EventQueryParams) ([]Event, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	events, err := service.repo.GetAllWithFilters(ctx, params)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Failed to get audit events", zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get audit events")
+	}
+	service.log.Info(fmt.Sprintf("Got audit events of length: %d", len(events)), zap.String("request_id", requestId))
+	return events, nil
+}
@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AuthAPI struct {
+	service *AuthService
+	log *zap.Logger
+}
+
+func NewAuthAPI(service *AuthService, logger *zap.Logger) *AuthAPI {
+	return &AuthAPI{service: service, log: logger}
+}
+
+func (api *AuthAPI) RegisterRoutes(server *gin.Engine) {
+	server.POST("/auth/register", api.register)
+	server.POST("/auth/login", api.login)
+	server.POST("/auth/refresh", api.refresh)
+	server.GET("/.well-known/jwks.json", api.getJWKS)
+	server.POST("/auth/logout", RequireUser, api.logout)
+	server.POST("/auth/password", RequireUser, api.changePassword)
+	server.GET("/auth/sessions", RequireUser, api.getSessions)
+	server.DELETE("/auth/sessions", RequireUser, api.revokeSessions)
+	server.DELETE("/auth/sessions/:id", RequireUser, api.revokeSession)
+	server.PUT("/users/:id/password", user.Require(user.ManageUsers), api.setPassword)
+}
+
+// Authenticate is a middleware that signs in requests with a bearer access
+// token, putting the user and session into the request context. Requests
+// without a token continue anonymously; requests with a bad one are rejected.
+func (api *AuthAPI) Authenticate(ctx *gin.Context) {
+	header := ctx.GetHeader("Authorization")
+	if header == "" {
+		ctx.Next()
+		return
+	}
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "authorization header must be a bearer token"})
+		return
+	}
+	u, session, err := api.service.Authenticate(ctx, token)
+	if err != nil {
+		status := http.StatusUnauthorized
+		if !errors.Is(err, ErrUnauthenticated) && !errors.Is(err, ErrUserInactive) {
+			status = http.StatusInternalServerError
+		}
+		ctx.AbortWithStatusJSON(status, gin.H{"message": err.Error()})
+		return
+	}
+	ctx.Set(user.ContextKey, u)
+	ctx.Set(SessionKey, session)
+	ctx.Next()
+}
+
+// RequireUser rejects requests that are not signed in.
+func RequireUser(ctx *gin.Context) {
+	if _, ok := user.FromContext(ctx); !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": ErrUnauthenticated.Error()})
+		return
+	}
+	ctx.Next()
+}
+
+func (api *AuthAPI) register(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var registration Registration
+	err := ctx.ShouldBindJSON(&registration)
+	if err != nil {
+		api.log.Warn(err.Error())
+		api.log.Warn("could not parse registration", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse registration"})
+		return
+	}
+	u, err := api.service.Register(ctx, &registration)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "registered user", "id": u.ID})
+}
+
+func (api *AuthAPI) login(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var login Login
+	err := ctx.ShouldBindJSON(&login)
+	if err != nil {
+		api.log.Warn(err.Error())
+		api.log.Warn("could not parse login", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse login"})
+		return
+	}
+	token, err := api.service.Login(ctx, &login, ctx.Request.UserAgent(), ctx.ClientIP())
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, token)
+}
+
+func (api *AuthAPI) refresh(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var refresh Refresh
+	err := ctx.ShouldBindJSON(&refresh)
+	if err != nil {
+		api.log.Warn(err.Error())
+		api.log.Warn("could not parse refresh token", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse refresh token"})
+		return
+	}
+	token, err := api.service.Refresh(ctx, refresh.RefreshToken)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, token)
+}
+
+// getJWKS publishes the keys access tokens are verified with. New keys are
+// published for the key overlap before they sign tokens, so clients may cache
+// the set for a few minutes.
+func (api *AuthAPI) getJWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, api.service.JWKS())
+}
+
+func (api *AuthAPI) logout(ctx *gin.Context) {
+	err := api.service.Logout(ctx)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "signed out"})
+}
+
+func (api *AuthAPI) changePassword(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var change PasswordChange
+	err := ctx.ShouldBindJSON(&change)
+	if err != nil {
+		api.log.Warn(err.Error())
+		api.log.Warn("could not parse password change", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse password change"})
+		return
+	}
+	err = api.service.ChangePassword(ctx, &change)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "changed password"})
+}
+
+func (api *AuthAPI) setPassword(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var reset PasswordReset
+	err := ctx.ShouldBindJSON(&reset)
+	if err != nil {
+		api.log.Warn(err.Error())
+		api.log.Warn("could not parse password", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse password"})
+		return
+	}
+	err = api.service.SetPassword(ctx, ctx.Param("id"), &reset)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "set password"})
+}
+
+func (api *AuthAPI) getSessions(ctx *gin.Context) {
+	sessions, err := api.service.GetSessions(ctx)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, sessions)
+}
+
+func (api *AuthAPI) revokeSession(ctx *gin.Context) {
+	err := api.service.RevokeSession(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "revoked session"})
+}
+
+func (api *AuthAPI) revokeSessions(ctx *gin.Context) {
+	count, err := api.service.RevokeSessions(ctx)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "revoked sessions", "count": count})
+}
+
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidCredentials), errors.Is(err, ErrUnauthenticated):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrUserInactive), errors.Is(err, ErrIncorrectPassword), errors.Is(err, user.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrSessionNotFound), errors.Is(err, user.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, user.ErrEmailTaken):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,309 @@
+package auth_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/auth"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/mocks"
+	"geraldaddo.com/live-voting-system/platform/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+type repositories struct {
+	credentials *mocks.MockCredentialRepository
+	sessions *mocks.MockSessionRepository
+	users *mocks.MockUserRepository
+}
+
+// SetupServer signs in requests with the Authenticate middleware, as the
+// server does, rather than putting a user into the context.
+func SetupServer(ctrl *gomock.Controller, keys *jwt.Keyring) (*gin.Engine, repositories) {
+	repos := repositories{
+		credentials: mocks.NewMockCredentialRepository(ctrl),
+		sessions: mocks.NewMockSessionRepository(ctrl),
+		users: mocks.NewMockUserRepository(ctrl),
+	}
+	service := auth.NewAuthService(repos.credentials, repos.sessions, repos.users, keys, 15 * time.Minute, time.Hour, zap.NewNop())
+	api := auth.NewAuthAPI(service, zap.NewNop())
+	server := gin.Default()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("requestId", uuid.New().String())
+	})
+	server.Use(api.Authenticate)
+	api.RegisterRoutes(server)
+	return server, repos
+}
+
+// bearer signs an access token for the session of the user.
+func bearer(t *testing.T, keys *jwt.Keyring, userId string, expiresAt time.Time) string {
+	t.Helper()
+	token, err := keys.Sign(jwt.Claims{Issuer: auth.Issuer, Subject: userId, SessionId: "session-of-" + userId, ExpiresAt: expiresAt.Unix()}, time.Now())
+	if err != nil {
+		t.Fatalf("Could not sign token: %v", err)
+	}
+	return "Bearer " + token
+}
+
+// expectSignedIn lets the access token of the user through Authenticate.
+func expectSignedIn(repos repositories, u *user.User) {
+	repos.sessions.
+		EXPECT().
+		GetById(gomock.Any(), "session-of-" + u.ID).
+		Return(&auth.Session{ID: "session-of-" + u.ID, UserId: u.ID}, nil).
+		Times(1)
+	repos.users.
+		EXPECT().
+		GetById(gomock.Any(), u.ID).
+		Return(u, nil).
+		Times(1)
+}
+
+func TestAuthenticateAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keys := keyring(t)
+	valid := bearer(t, keys, "test-user-id", time.Now().Add(time.Minute))
+	base := &user.User{ID: "test-user-id", Role: user.Base, Active: true}
+	tests := []struct {
+		name string
+		header string
+		setup func(repos repositories)
+		statusCode int
+	}{
+		{"No token", "", func(repos repositories) {}, 401},
+		{"Not a bearer token", "Basic dXNlcjpwYXNzd29yZA==", func(repos repositories) {}, 401},
+		{"Empty bearer token", "Bearer ", func(repos repositories) {}, 401},
+		{"Invalid token", "Bearer not-a-token", func(repos repositories) {}, 401},
+		{"Expired token", bearer(t, keys, "test-user-id", time.Now().Add(-time.Minute)), func(repos repositories) {}, 401},
+		{
+			"Revoked session",
+			valid,
+			func(repos repositories) {
+				repos.sessions.EXPECT().GetById(gomock.Any(), "session-of-test-user-id").Return(nil, sql.ErrNoRows).Times(1)
+			},
+			401,
+		},
+		{
+			"Deactivated user",
+			valid,
+			func(repos repositories) {
+				expectSignedIn(repos, &user.User{ID: "test-user-id", Role: user.Base, Active: false})
+			},
+			401,
+		},
+		{
+			"Valid token",
+			valid,
+			func(repos repositories) {
+				expectSignedIn(repos, base)
+				repos.sessions.EXPECT().GetAllByUser(gomock.Any(), "test-user-id").Return([]auth.Session{}, nil).Times(1)
+			},
+			200,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, repos := SetupServer(ctrl, keys)
+			test.setup(repos)
+
+			recorder := httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", "/auth/sessions", nil)
+			if test.header != "" {
+				request.Header.Set("Authorization", test.header)
+			}
+			server.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.statusCode {
+				t.Errorf("Expected status code: %d but got %d", test.statusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestRequirePermissionAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keys := keyring(t)
+	tests := []struct {
+		name string
+		user *user.User
+		statusCode int
+	}{
+		{"Anonymous", nil, 401},
+		{"Base user", &user.User{ID: "test-user-id", Role: user.Base, Active: true}, 403},
+		{"Admin", &user.User{ID: "test-admin-id", Role: user.Admin, Active: true}, 200},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, repos := SetupServer(ctrl, keys)
+			if test.user != nil {
+				expectSignedIn(repos, test.user)
+			}
+			if test.statusCode == 200 {
+				repos.users.EXPECT().GetById(gomock.Any(), "test-created-id").Return(&user.User{ID: "test-created-id"}, nil).Times(1)
+				repos.credentials.EXPECT().SetPassword(gomock.Any(), "test-created-id", gomock.Any()).Return(nil).Times(1)
+				repos.sessions.EXPECT().RevokeAll(gomock.Any(), "test-created-id", "").Return(0, nil).Times(1)
+			}
+
+			recorder := httptest.NewRecorder()
+			request, _ := http.NewRequest("PUT", "/users/test-created-id/password", strings.NewReader(`{"NewPassword": "correct horse battery"}`))
+			if test.user != nil {
+				request.Header.Set("Authorization", bearer(t, keys, test.user.ID, time.Now().Add(time.Minute)))
+			}
+			server.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.statusCode {
+				t.Errorf("Expected status code: %d but got %d", test.statusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestAuthHandlersAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keys := keyring(t)
+	stored := hash(t, "correct horse battery")
+	base := &user.User{ID: "test-user-id", Role: user.Base, Active: true}
+	registration := `{"FirstName": "Ada", "LastName": "Lovelace", "Email": "ada@example.com", "Password": "correct horse battery"}`
+	tests := []struct {
+		name string
+		method string
+		path string
+		signedIn bool
+		body string
+		setup func(repos repositories)
+		statusCode int
+	}{
+		{
+			"Register",
+			"POST", "/auth/register", false, registration,
+			func(repos repositories) {
+				repos.credentials.
+					EXPECT().
+					Register(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, u *user.User, passwordHash string) error {
+						u.ID = "test-user-id"
+						return nil
+					}).
+					Times(1)
+			},
+			200,
+		},
+		{
+			"Register with short password",
+			"POST", "/auth/register", false, strings.Replace(registration, "correct horse battery", "short", 1),
+			func(repos repositories) {},
+			400,
+		},
+		{
+			"Register taken email",
+			"POST", "/auth/register", false, registration,
+			func(repos repositories) {
+				repos.credentials.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any()).Return(user.ErrEmailTaken).Times(1)
+			},
+			409,
+		},
+		{
+			"Login",
+			"POST", "/auth/login", false, `{"Email": "ada@example.com", "Password": "correct horse battery"}`,
+			func(repos repositories) {
+				repos.credentials.EXPECT().GetByEmail(gomock.Any(), "ada@example.com").Return(&auth.Credentials{UserId: "test-user-id", PasswordHash: stored}, nil).Times(1)
+				repos.users.EXPECT().GetById(gomock.Any(), "test-user-id").Return(base, nil).Times(1)
+				repos.sessions.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			200,
+		},
+		{
+			"Login with wrong password",
+			"POST", "/auth/login", false, `{"Email": "ada@example.com", "Password": "wrong horse battery"}`,
+			func(repos repositories) {
+				repos.credentials.EXPECT().GetByEmail(gomock.Any(), "ada@example.com").Return(&auth.Credentials{UserId: "test-user-id", PasswordHash: stored}, nil).Times(1)
+			},
+			401,
+		},
+		{
+			"Login as deactivated user",
+			"POST", "/auth/login", false, `{"Email": "ada@example.com", "Password": "correct horse battery"}`,
+			func(repos repositories) {
+				repos.credentials.EXPECT().GetByEmail(gomock.Any(), "ada@example.com").Return(&auth.Credentials{UserId: "test-user-id", PasswordHash: stored}, nil).Times(1)
+				repos.users.EXPECT().GetById(gomock.Any(), "test-user-id").Return(&user.User{ID: "test-user-id", Active: false}, nil).Times(1)
+			},
+			403,
+		},
+		{
+			"Refresh with used token",
+			"POST", "/auth/refresh", false, `{"RefreshToken": "test-refresh-token"}`,
+			func(repos repositories) {
+				repos.sessions.EXPECT().GetByToken(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows).Times(1)
+			},
+			401,
+		},
+		{
+			"Logout",
+			"POST", "/auth/logout", true, "",
+			func(repos repositories) {
+				repos.sessions.EXPECT().Revoke(gomock.Any(), "test-user-id", "session-of-test-user-id").Return(true, nil).Times(1)
+			},
+			200,
+		},
+		{
+			"Logout anonymously",
+			"POST", "/auth/logout", false, "",
+			func(repos repositories) {},
+			401,
+		},
+		{
+			"Change password with wrong current password",
+			"POST", "/auth/password", true, `{"CurrentPassword": "wrong horse battery", "NewPassword": "battery staple horse"}`,
+			func(repos repositories) {
+				repos.credentials.EXPECT().GetByUser(gomock.Any(), "test-user-id").Return(&auth.Credentials{UserId: "test-user-id", PasswordHash: stored}, nil).Times(1)
+			},
+			403,
+		},
+		{
+			"Revoke session of another user",
+			"DELETE", "/auth/sessions/other-session-id", true, "",
+			func(repos repositories) {
+				repos.sessions.EXPECT().Revoke(gomock.Any(), "test-user-id", "other-session-id").Return(false, nil).Times(1)
+			},
+			404,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, repos := SetupServer(ctrl, keys)
+			if test.signedIn {
+				expectSignedIn(repos, base)
+			}
+			test.setup(repos)
+
+			recorder := httptest.NewRecorder()
+			request, _ := http.NewRequest(test.method, test.path, strings.NewReader(test.body))
+			if test.signedIn {
+				request.Header.Set("Authorization", bearer(t, keys, base.ID, time.Now().Add(time.Minute)))
+			}
+			server.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.statusCode {
+				t.Errorf("Expected status code: %d but got %d", test.statusCode, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+)
+
+// SessionKey is the key the session of a signed in request is stored under in
+// the request context.
+const SessionKey = "session"
+
+type Registration struct {
+	FirstName string `binding:"required,max=20"`
+	LastName string `binding:"required,max=20"`
+	MiddleName string `binding:"max=20"`
+	Email string `binding:"required,email,max=50"`
+	Password string `binding:"required,min=12,max=128"`
+}
+
+type Login struct {
+	Email string `binding:"required"`
+	Password string `binding:"required,max=128"`
+}
+
+type PasswordChange struct {
+	CurrentPassword string `binding:"required,max=128"`
+	NewPassword string `binding:"required,min=12,max=128"`
+}
+
+// PasswordReset sets the password of another user, such as one an admin
+// created without one.
+type PasswordReset struct {
+	NewPassword string `binding:"required,min=12,max=128"`
+}
+
+type Credentials struct {
+	UserId string
+	PasswordHash string
+}
+
+// Session is a signed in device, holding its current refresh token. Only a
+// hash of the token is stored, so a leaked sessions table cannot be used to
+// sign in.
+type Session struct {
+	ID string
+	UserId string
+	TokenHash string `json:"-"`
+	UserAgent string
+	IPAddress string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	// Current marks the session the request was made with.
+	Current bool
+}
+
+// Token is returned at login and refresh. Clients send the access token as a
+// bearer token and exchange the refresh token for new tokens before it
+// expires. Each refresh token can only be used once.
+type Token struct {
+	AccessToken string
+	AccessTokenExpiresAt time.Time
+	RefreshToken string
+	RefreshTokenExpiresAt time.Time
+	User *user.User
+}
+
+type Refresh struct {
+	RefreshToken string `binding:"required"`
+}
@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+)
+
+//go:generate mockgen -destination=../../mocks/mock_credential_repo.go -package=mocks . CredentialRepository
+type CredentialRepository interface {
+	// Register creates an active user together with their password hash.
+	Register(ctx context.Context, u *user.User, passwordHash string) error
+	GetByEmail(ctx context.Context, email string) (*Credentials, error)
+	GetByUser(ctx context.Context, userId string) (*Credentials, error)
+	SetPassword(ctx context.Context, userId string, passwordHash string) error
+}
+
+//go:generate mockgen -destination=../../mocks/mock_session_repo.go -package=mocks . SessionRepository
+type SessionRepository interface {
+	Save(ctx context.Context, session *Session) error
+	// GetByToken returns the session with the token hash if it is neither
+	// expired nor revoked.
+	GetByToken(ctx context.Context, tokenHash string) (*Session, error)
+	// GetById returns the session if it is neither expired nor revoked.
+	GetById(ctx context.Context, id string) (*Session, error)
+	GetAllByUser(ctx context.Context, userId string) ([]Session, error)
+	// Rotate replaces the refresh token of a session and reports whether the
+	// session still had the old token, so each token is only used once.
+	Rotate(ctx context.Context, id string, tokenHash string, newTokenHash string) (bool, error)
+	// Revoke ends one session of a user and reports whether it was active.
+	Revoke(ctx context.Context, userId string, id string) (bool, error)
+	// RevokeAll ends every session of a user except the one given, which may
+	// be empty, and returns how many were ended.
+	RevokeAll(ctx context.Context, userId string, except string) (int, error)
+}
+
+type CredentialRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewCredentialRepository(db *sql.DB) *CredentialRepositoryImpl {
+	return &CredentialRepositoryImpl{db: db}
+}
+
+func (repo *CredentialRepositoryImpl) Register(ctx context.Context, u *user.User, passwordHash string) error {
+	insertStatement := `
+	INSERT INTO users(first_name, last_name, middle_name, email, role, active, password_hash)
+	VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7)
+	RETURNING id, created_at, updated_at`
+	err := repo.db.QueryRow(
+		insertStatement,
+		u.FirstName,
+		u.LastName,
+		u.MiddleName,
+		u.Email,
+		u.Role,
+		u.Active,
+		passwordHash,
+	).Scan(&u.ID, &u.CreatedAt, &u.UpdatedAt)
+	return user.EmailTaken(err)
+}
+
+func (repo *CredentialRepositoryImpl) GetByEmail(ctx context.Context, email string) (*Credentials, error) {
+	query := `SELECT id, COALESCE(password_hash, '') FROM users WHERE email = $1`
+	var credentials Credentials
+	err := repo.db.QueryRow(query, email).Scan(&credentials.UserId, &credentials.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+	return &credentials, nil
+}
+
+func (repo *CredentialRepositoryImpl) GetByUser(ctx context.Context, userId string) (*Credentials, error) {
+	query := `SELECT id, COALESCE(password_hash, '') FROM users WHERE id = $1`
+	var credentials Credentials
+	err := repo.db.QueryRow(query, userId).Scan(&credentials.UserId, &credentials.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+	return &credentials, nil
+}
+
+func (repo *CredentialRepositoryImpl) SetPassword(ctx context.Context, userId string, passwordHash string) error {
+	_, err := repo.db.Exec(`UPDATE users SET password_hash = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`, passwordHash, userId)
+	return err
+}
+
+type SessionRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewSessionRepository(db *sql.DB) *SessionRepositoryImpl {
+	return &SessionRepositoryImpl{db: db}
+}
+
+const sessionColumns = `id, user_id, token_hash, user_agent, ip_address, created_at, expires_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSession(row scanner) (*Session, error) {
+	var s Session
+	err := row.Scan(&s.ID, &s.UserId, &s.TokenHash, &s.UserAgent, &s.IPAddress, &s.CreatedAt, &s.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (repo *SessionRepositoryImpl) Save(ctx context.Context, session *Session) error {
+	insertStatement := `
+	INSERT INTO sessions(user_id, token_hash, user_agent, ip_address, expires_at)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, created_at`
+	return repo.db.QueryRow(
+		insertStatement,
+		session.UserId,
+		session.TokenHash,
+		session.UserAgent,
+		session.IPAddress,
+		session.ExpiresAt,
+	).Scan(&session.ID, &session.CreatedAt)
+}
+
+func (repo *SessionRepositoryImpl) GetByToken(ctx context.Context, tokenHash string) (*Session, error) {
+	query := `
+	SELECT ` + sessionColumns + `
+	FROM sessions
+	WHERE token_hash = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP`
+	return scanSession(repo.db.QueryRow(query, tokenHash))
+}
+
+func (repo *SessionRepositoryImpl) GetById(ctx context.Context, id string) (*Session, error) {
+	query := `
+	SELECT ` + sessionColumns + `
+	FROM sessions
+	WHERE id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP`
+	return scanSession(repo.db.QueryRow(query, id))
+}
+
+func (repo *SessionRepositoryImpl) GetAllByUser(ctx context.Context, userId string) ([]Session, error) {
+	query := `
+	SELECT ` + sessionColumns + `
+	FROM sessions
+	WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+	ORDER BY created_at DESC`
+	rows, err := repo.db.Query(query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var sessions []Session
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *s)
+	}
+	return sessions, nil
+}
+
+func (repo *SessionRepositoryImpl) Rotate(ctx context.Context, id string, tokenHash string, newTokenHash string) (bool, error) {
+	updateStatement := `
+	UPDATE sessions
+	SET token_hash = $1
+	WHERE id = $2 AND token_hash = $3 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP`
+	result, err := repo.db.Exec(updateStatement, newTokenHash, id, tokenHash)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows == 1, err
+}
+
+func (repo *SessionRepositoryImpl) Revoke(ctx context.Context, userId string, id string) (bool, error) {
+	updateStatement := `
+	UPDATE sessions
+	SET revoked_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP`
+	result, err := repo.db.Exec(updateStatement, id, userId)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows == 1, err
+}
+
+func (repo *SessionRepositoryImpl) RevokeAll(ctx context.Context, userId string, except string) (int, error) {
+	updateStatement := `
+	UPDATE sessions
+	SET revoked_at = CURRENT_TIMESTAMP
+	WHERE user_id = $1 AND id::TEXT <> $2 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP`
+	result, err := repo.db.Exec(updateStatement, userId, except)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	return int(rows), err
+}
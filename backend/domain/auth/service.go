@@ -0,0 +1,444 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/platform/jwt"
+	"geraldaddo.com/live-voting-system/platform/password"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("Email or password is incorrect")
+	ErrUserInactive = errors.New("User has been deactivated")
+	ErrUnauthenticated = errors.New("Sign in required")
+	ErrIncorrectPassword = errors.New("Current password is incorrect")
+	ErrSessionNotFound = errors.New("Session does not exist")
+)
+
+// Issuer names this server in the tokens it issues.
+const Issuer = "live-voting-system"
+
+const (
+	// DefaultSessionTTL is how long a session lasts when SESSION_TTL is not
+	// set. Refreshing does not extend it.
+	DefaultSessionTTL = 7 * 24 * time.Hour
+	// DefaultAccessTokenTTL is how long an access token lasts when
+	// ACCESS_TOKEN_TTL is not set.
+	DefaultAccessTokenTTL = 15 * time.Minute
+)
+
+type AuthService struct {
+	credentials CredentialRepository
+	sessions SessionRepository
+	users user.UserRepository
+	keys *jwt.Keyring
+	accessTokenTTL time.Duration
+	sessionTTL time.Duration
+	log *zap.Logger
+	dummyHash func() string
+}
+
+func NewAuthService(
+	credentials CredentialRepository,
+	sessions SessionRepository,
+	users user.UserRepository,
+	keys *jwt.Keyring,
+	accessTokenTTL time.Duration,
+	sessionTTL time.Duration,
+	logger *zap.Logger,
+) *AuthService {
+	return &AuthService{
+		credentials: credentials,
+		sessions: sessions,
+		users: users,
+		keys: keys,
+		accessTokenTTL: accessTokenTTL,
+		sessionTTL: sessionTTL,
+		log: logger,
+		dummyHash: sync.OnceValue(func() string {
+			hash, _ := password.Hash("not a real password")
+			return hash
+		}),
+	}
+}
+
+// Register creates an active base user who can sign in with the password.
+// The email address is taken on trust, which eligibility rules on
+// email_domain have to allow for.
+func (service *AuthService) Register(ctx context.Context, registration *Registration) (*user.User, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	hash, err := password.Hash(registration.Password)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not hash password", zap.String("request_id", requestId))
+		return nil, errors.New("Could not register user")
+	}
+	u := &user.User{
+		FirstName: registration.FirstName,
+		LastName: registration.LastName,
+		MiddleName: registration.MiddleName,
+		Email: registration.Email,
+		Role: user.Base,
+		Active: true,
+	}
+	err = service.credentials.Register(ctx, u, hash)
+	if errors.Is(err, user.ErrEmailTaken) {
+		service.log.Warn("Email is already taken", zap.String("request_id", requestId))
+		return nil, user.ErrEmailTaken
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not register user", zap.String("request_id", requestId))
+		return nil, errors.New("Could not register user")
+	}
+	service.log.Info("Registered user: " + u.ID, zap.String("request_id", requestId))
+	return u, nil
+}
+
+// Login checks a user's password and starts a session for them. Unknown
+// emails are checked against a dummy hash so they take as long to reject as
+// wrong passwords.
+func (service *AuthService) Login(ctx context.Context, login *Login, userAgent string, ipAddress string) (*Token, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	credentials, err := service.credentials.GetByEmail(ctx, login.Email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get credentials", zap.String("request_id", requestId))
+		return nil, errors.New("Could not sign in")
+	}
+	hash := service.dummyHash()
+	if credentials != nil && credentials.PasswordHash != "" {
+		hash = credentials.PasswordHash
+	}
+	ok, err := password.Verify(login.Password, hash)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not verify password", zap.String("request_id", requestId))
+		return nil, errors.New("Could not sign in")
+	}
+	if !ok || credentials == nil || credentials.PasswordHash == "" {
+		service.log.Warn("Failed sign in", zap.String("request_id", requestId))
+		return nil, ErrInvalidCredentials
+	}
+	u, err := service.activeUser(ctx, credentials.UserId)
+	if err != nil {
+		return nil, err
+	}
+	token, err := newToken()
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not generate session token", zap.String("request_id", requestId))
+		return nil, errors.New("Could not sign in")
+	}
+	session := &Session{
+		UserId: u.ID,
+		TokenHash: hashToken(token),
+		UserAgent: userAgent,
+		IPAddress: ipAddress,
+		ExpiresAt: time.Now().Add(service.sessionTTL),
+	}
+	err = service.sessions.Save(ctx, session)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not save session", zap.String("request_id", requestId))
+		return nil, errors.New("Could not sign in")
+	}
+	service.log.Info("Signed in user: " + u.ID, zap.String("request_id", requestId))
+	return service.issue(ctx, u, session, token)
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh
+// token. The old refresh token stops working.
+func (service *AuthService) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	session, err := service.sessions.GetByToken(ctx, hashToken(refreshToken))
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("Unknown, used, expired or revoked refresh token", zap.String("request_id", requestId))
+		return nil, ErrUnauthenticated
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get session", zap.String("request_id", requestId))
+		return nil, errors.New("Could not refresh tokens")
+	}
+	u, err := service.activeUser(ctx, session.UserId)
+	if err != nil {
+		return nil, err
+	}
+	token, err := newToken()
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not generate refresh token", zap.String("request_id", requestId))
+		return nil, errors.New("Could not refresh tokens")
+	}
+	rotated, err := service.sessions.Rotate(ctx, session.ID, session.TokenHash, hashToken(token))
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not rotate refresh token of session: " + session.ID, zap.String("request_id", requestId))
+		return nil, errors.New("Could not refresh tokens")
+	}
+	if !rotated {
+		service.log.Warn("Refresh token of session: " + session.ID + " was used concurrently", zap.String("request_id", requestId))
+		return nil, ErrUnauthenticated
+	}
+	return service.issue(ctx, u, session, token)
+}
+
+// issue signs an access token for the session and returns it with the
+// session's refresh token.
+func (service *AuthService) issue(ctx context.Context, u *user.User, session *Session, refreshToken string) (*Token, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	now := time.Now()
+	expiresAt := now.Add(service.accessTokenTTL)
+	claims := jwt.Claims{
+		Issuer: Issuer,
+		Subject: u.ID,
+		ExpiresAt: expiresAt.Unix(),
+		SessionId: session.ID,
+	}
+	accessToken, err := service.keys.Sign(claims, now)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not sign access token", zap.String("request_id", requestId))
+		return nil, errors.New("Could not issue tokens")
+	}
+	return &Token{
+		AccessToken: accessToken,
+		AccessTokenExpiresAt: time.Unix(expiresAt.Unix(), 0),
+		RefreshToken: refreshToken,
+		RefreshTokenExpiresAt: session.ExpiresAt,
+		User: u,
+	}, nil
+}
+
+// Authenticate returns the user and session an access token was issued to.
+// Tokens of sessions that have since been revoked or expired and of users
+// deactivated since the token was issued are rejected.
+func (service *AuthService) Authenticate(ctx context.Context, accessToken string) (*user.User, *Session, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	claims, err := service.keys.Verify(accessToken, Issuer, time.Now())
+	if err != nil {
+		service.log.Warn(err.Error(), zap.String("request_id", requestId))
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnauthenticated, err.Error())
+	}
+	session, err := service.sessions.GetById(ctx, claims.SessionId)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && session.UserId != claims.Subject) {
+		service.log.Warn("Session: " + claims.SessionId + " has ended", zap.String("request_id", requestId))
+		return nil, nil, fmt.Errorf("%w: session has ended", ErrUnauthenticated)
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get session: " + claims.SessionId, zap.String("request_id", requestId))
+		return nil, nil, errors.New("Could not authenticate user")
+	}
+	u, err := service.activeUser(ctx, claims.Subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	return u, session, nil
+}
+
+// activeUser gets a user who is signing in or refreshing their tokens,
+// rejecting them if they have been deactivated or deleted.
+func (service *AuthService) activeUser(ctx context.Context, id string) (*user.User, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	u, err := service.users.GetById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("User: " + id + " no longer exists", zap.String("request_id", requestId))
+		return nil, ErrUnauthenticated
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get user: " + id, zap.String("request_id", requestId))
+		return nil, errors.New("Could not authenticate user")
+	}
+	if !u.Active {
+		service.log.Warn("Deactivated user: " + u.ID + " tried to authenticate", zap.String("request_id", requestId))
+		return nil, ErrUserInactive
+	}
+	return u, nil
+}
+
+// JWKS returns the public keys access tokens are verified with.
+func (service *AuthService) JWKS() jwt.JWKSet {
+	return service.keys.JWKS()
+}
+
+// Logout ends the session the request was made with.
+func (service *AuthService) Logout(ctx context.Context) error {
+	session, ok := ctx.Value(SessionKey).(*Session)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	return service.RevokeSession(ctx, session.ID)
+}
+
+// GetSessions lists the active sessions of the signed in user.
+func (service *AuthService) GetSessions(ctx context.Context) ([]Session, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	u, ok := user.FromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	sessions, err := service.sessions.GetAllByUser(ctx, u.ID)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get sessions of user: " + u.ID, zap.String("request_id", requestId))
+		return nil, errors.New("Could not get sessions")
+	}
+	current, _ := ctx.Value(SessionKey).(*Session)
+	for i := range sessions {
+		sessions[i].Current = current != nil && sessions[i].ID == current.ID
+	}
+	return sessions, nil
+}
+
+// RevokeSession ends one of the signed in user's sessions.
+func (service *AuthService) RevokeSession(ctx context.Context, id string) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	u, ok := user.FromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	revoked, err := service.sessions.Revoke(ctx, u.ID, id)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not revoke session: " + id, zap.String("request_id", requestId))
+		return errors.New("Could not revoke session")
+	}
+	if !revoked {
+		service.log.Warn("Session: " + id + " is not an active session of user: " + u.ID, zap.String("request_id", requestId))
+		return ErrSessionNotFound
+	}
+	service.log.Info("Revoked session: " + id, zap.String("request_id", requestId))
+	return nil
+}
+
+// RevokeSessions ends every session of the signed in user, signing them out
+// everywhere.
+func (service *AuthService) RevokeSessions(ctx context.Context) (int, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	u, ok := user.FromContext(ctx)
+	if !ok {
+		return 0, ErrUnauthenticated
+	}
+	count, err := service.sessions.RevokeAll(ctx, u.ID, "")
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not revoke sessions of user: " + u.ID, zap.String("request_id", requestId))
+		return 0, errors.New("Could not revoke sessions")
+	}
+	service.log.Info(fmt.Sprintf("Revoked %d sessions of user: %s", count, u.ID), zap.String("request_id", requestId))
+	return count, nil
+}
+
+// ChangePassword replaces the signed in user's password and ends their other
+// sessions.
+func (service *AuthService) ChangePassword(ctx context.Context, change *PasswordChange) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	u, ok := user.FromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	credentials, err := service.credentials.GetByUser(ctx, u.ID)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get credentials of user: " + u.ID, zap.String("request_id", requestId))
+		return errors.New("Could not change password")
+	}
+	ok, err = password.Verify(change.CurrentPassword, credentials.PasswordHash)
+	if err != nil || !ok {
+		service.log.Warn("Incorrect current password for user: " + u.ID, zap.String("request_id", requestId))
+		return ErrIncorrectPassword
+	}
+	hash, err := password.Hash(change.NewPassword)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not hash password", zap.String("request_id", requestId))
+		return errors.New("Could not change password")
+	}
+	err = service.credentials.SetPassword(ctx, u.ID, hash)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not set password of user: " + u.ID, zap.String("request_id", requestId))
+		return errors.New("Could not change password")
+	}
+	var current string
+	if session, ok := ctx.Value(SessionKey).(*Session); ok {
+		current = session.ID
+	}
+	_, err = service.sessions.RevokeAll(ctx, u.ID, current)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not revoke other sessions of user: " + u.ID, zap.String("request_id", requestId))
+		return errors.New("Could not revoke other sessions")
+	}
+	service.log.Info("Changed password of user: " + u.ID, zap.String("request_id", requestId))
+	return nil
+}
+
+// SetPassword lets an admin set the password of a user, so users created
+// through the users API can sign in. It ends every session of the user.
+func (service *AuthService) SetPassword(ctx context.Context, userId string, reset *PasswordReset) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	if !user.Can(ctx, user.ManageUsers) {
+		service.log.Warn("Password of user: " + userId + " set without permission", zap.String("request_id", requestId))
+		return user.ErrForbidden
+	}
+	_, err := service.users.GetById(ctx, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("User: " + userId + " does not exist", zap.String("request_id", requestId))
+		return user.ErrUserNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get user: " + userId, zap.String("request_id", requestId))
+		return errors.New("Could not set password")
+	}
+	hash, err := password.Hash(reset.NewPassword)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not hash password", zap.String("request_id", requestId))
+		return errors.New("Could not set password")
+	}
+	err = service.credentials.SetPassword(ctx, userId, hash)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not set password of user: " + userId, zap.String("request_id", requestId))
+		return errors.New("Could not set password")
+	}
+	_, err = service.sessions.RevokeAll(ctx, userId, "")
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not revoke sessions of user: " + userId, zap.String("request_id", requestId))
+		return errors.New("Could not revoke sessions")
+	}
+	service.log.Info("Set password of user: " + userId, zap.String("request_id", requestId))
+	return nil
+}
+
+// newToken returns a random refresh token. Only its hash is stored.
+func newToken() (string, error) {
+	token := make([]byte, 32)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
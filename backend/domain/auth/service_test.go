@@ -0,0 +1,425 @@
+package auth_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/auth"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/mocks"
+	"geraldaddo.com/live-voting-system/platform/jwt"
+	"geraldaddo.com/live-voting-system/platform/password"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func hash(t *testing.T, plaintext string) string {
+	t.Helper()
+	hashed, err := password.HashWithParams(plaintext, password.Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	if err != nil {
+		t.Fatalf("Could not hash password: %v", err)
+	}
+	return hashed
+}
+
+func keyring(t *testing.T) *jwt.Keyring {
+	t.Helper()
+	keys, err := jwt.OpenKeyring(t.TempDir(), 24 * time.Hour, time.Hour)
+	if err != nil {
+		t.Fatalf("Could not open keyring: %v", err)
+	}
+	return keys
+}
+
+func signedIn(u *user.User, session *auth.Session) context.Context {
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	ctx = context.WithValue(ctx, user.ContextKey, u)
+	return context.WithValue(ctx, auth.SessionKey, session)
+}
+
+func TestRegisterShouldCreateActiveBaseUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		registerErr error
+		expected error
+	}{
+		{"New email", nil, nil},
+		{"Email taken", user.ErrEmailTaken, user.ErrEmailTaken},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCredentialRepository := mocks.NewMockCredentialRepository(ctrl)
+			mockCredentialRepository.
+				EXPECT().
+				Register(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, u *user.User, passwordHash string) error {
+					if u.Role != user.Base || !u.Active {
+						t.Errorf("Expected an active base user but got role: %s active: %t", u.Role, u.Active)
+					}
+					ok, err := password.Verify("correct horse battery", passwordHash)
+					if err != nil || !ok {
+						t.Errorf("Expected the stored hash to match the password")
+					}
+					return test.registerErr
+				}).
+				Times(1)
+			service := auth.NewAuthService(mockCredentialRepository, mocks.NewMockSessionRepository(ctrl), mocks.NewMockUserRepository(ctrl), keyring(t), 15 * time.Minute, time.Hour, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			_, err := service.Register(ctx, &auth.Registration{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com", Password: "correct horse battery"})
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stored := hash(t, "correct horse battery")
+	tests := []struct {
+		name string
+		password string
+		credentials *auth.Credentials
+		credentialsErr error
+		active bool
+		expected error
+	}{
+		{"Correct password", "correct horse battery", &auth.Credentials{UserId: "test-user-id", PasswordHash: stored}, nil, true, nil},
+		{"Wrong password", "wrong horse battery", &auth.Credentials{UserId: "test-user-id", PasswordHash: stored}, nil, true, auth.ErrInvalidCredentials},
+		{"Unknown email", "correct horse battery", nil, sql.ErrNoRows, true, auth.ErrInvalidCredentials},
+		{"No password set", "", &auth.Credentials{UserId: "test-user-id"}, nil, true, auth.ErrInvalidCredentials},
+		{"Deactivated user", "correct horse battery", &auth.Credentials{UserId: "test-user-id", PasswordHash: stored}, nil, false, auth.ErrUserInactive},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCredentialRepository := mocks.NewMockCredentialRepository(ctrl)
+			mockSessionRepository := mocks.NewMockSessionRepository(ctrl)
+			mockUserRepository := mocks.NewMockUserRepository(ctrl)
+			mockCredentialRepository.
+				EXPECT().
+				GetByEmail(gomock.Any(), "ada@example.com").
+				Return(test.credentials, test.credentialsErr).
+				Times(1)
+			if !errors.Is(test.expected, auth.ErrInvalidCredentials) {
+				mockUserRepository.
+					EXPECT().
+					GetById(gomock.Any(), "test-user-id").
+					Return(&user.User{ID: "test-user-id", Active: test.active}, nil).
+					Times(1)
+			}
+			if test.expected == nil {
+				mockSessionRepository.
+					EXPECT().
+					Save(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, session *auth.Session) error {
+						if session.UserId != "test-user-id" || session.TokenHash == "" {
+							t.Errorf("Expected a session for the user with a token hash but got %+v", session)
+						}
+						session.ID = "test-session-id"
+						return nil
+					}).
+					Times(1)
+			}
+			service := auth.NewAuthService(mockCredentialRepository, mockSessionRepository, mockUserRepository, keyring(t), 15 * time.Minute, time.Hour, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			token, err := service.Login(ctx, &auth.Login{Email: "ada@example.com", Password: test.password}, "test-agent", "127.0.0.1")
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+			if test.expected == nil && (token == nil || token.AccessToken == "" || token.RefreshToken == "") {
+				t.Errorf("Expected an access and refresh token")
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keys := keyring(t)
+	now := time.Now()
+	sign := func(issuer string, expiresAt time.Time) string {
+		token, err := keys.Sign(jwt.Claims{Issuer: issuer, Subject: "test-user-id", SessionId: "test-session-id", ExpiresAt: expiresAt.Unix()}, now)
+		if err != nil {
+			t.Fatalf("Could not sign token: %v", err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name string
+		token string
+		// ended means the session was revoked or expired since the token
+		// was issued; signed tokens of ended sessions are still refused.
+		ended bool
+		active bool
+		expected error
+	}{
+		{"Valid token", sign(auth.Issuer, now.Add(time.Minute)), false, true, nil},
+		{"Expired token", sign(auth.Issuer, now.Add(-time.Minute)), false, true, auth.ErrUnauthenticated},
+		{"Token of another issuer", sign("other-issuer", now.Add(time.Minute)), false, true, auth.ErrUnauthenticated},
+		{"Session revoked since issued", sign(auth.Issuer, now.Add(time.Minute)), true, true, auth.ErrUnauthenticated},
+		{"Deactivated since issued", sign(auth.Issuer, now.Add(time.Minute)), false, false, auth.ErrUserInactive},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockSessionRepository := mocks.NewMockSessionRepository(ctrl)
+			mockUserRepository := mocks.NewMockUserRepository(ctrl)
+			if test.ended {
+				mockSessionRepository.
+					EXPECT().
+					GetById(gomock.Any(), "test-session-id").
+					Return(nil, sql.ErrNoRows).
+					Times(1)
+			}
+			if !errors.Is(test.expected, auth.ErrUnauthenticated) {
+				mockSessionRepository.
+					EXPECT().
+					GetById(gomock.Any(), "test-session-id").
+					Return(&auth.Session{ID: "test-session-id", UserId: "test-user-id"}, nil).
+					Times(1)
+				mockUserRepository.
+					EXPECT().
+					GetById(gomock.Any(), "test-user-id").
+					Return(&user.User{ID: "test-user-id", Active: test.active}, nil).
+					Times(1)
+			}
+			service := auth.NewAuthService(mocks.NewMockCredentialRepository(ctrl), mockSessionRepository, mockUserRepository, keys, 15 * time.Minute, time.Hour, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			u, session, err := service.Authenticate(ctx, test.token)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+			if test.expected == nil && (u.ID != "test-user-id" || session.ID != "test-session-id") {
+				t.Errorf("Expected user: test-user-id and session: test-session-id but got %v %v", u, session)
+			}
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		sessionErr error
+		rotated bool
+		expected error
+	}{
+		{"Active session", nil, true, nil},
+		{"Used, expired or revoked token", sql.ErrNoRows, false, auth.ErrUnauthenticated},
+		{"Token used concurrently", nil, false, auth.ErrUnauthenticated},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockSessionRepository := mocks.NewMockSessionRepository(ctrl)
+			mockUserRepository := mocks.NewMockUserRepository(ctrl)
+			var session *auth.Session
+			if test.sessionErr == nil {
+				session = &auth.Session{ID: "test-session-id", UserId: "test-user-id", TokenHash: "old-hash", ExpiresAt: time.Now().Add(time.Hour)}
+				mockUserRepository.
+					EXPECT().
+					GetById(gomock.Any(), "test-user-id").
+					Return(&user.User{ID: "test-user-id", Active: true}, nil).
+					Times(1)
+				mockSessionRepository.
+					EXPECT().
+					Rotate(gomock.Any(), "test-session-id", "old-hash", gomock.Not("old-hash")).
+					Return(test.rotated, nil).
+					Times(1)
+			}
+			mockSessionRepository.
+				EXPECT().
+				GetByToken(gomock.Any(), gomock.Not("test-refresh-token")).
+				Return(session, test.sessionErr).
+				Times(1)
+			service := auth.NewAuthService(mocks.NewMockCredentialRepository(ctrl), mockSessionRepository, mockUserRepository, keyring(t), 15 * time.Minute, time.Hour, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			token, err := service.Refresh(ctx, "test-refresh-token")
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+			if test.expected == nil && (token.RefreshToken == "test-refresh-token" || token.AccessToken == "") {
+				t.Errorf("Expected a new access and refresh token but got %+v", token)
+			}
+		})
+	}
+}
+
+func TestRevokeSessionShouldOnlyRevokeOwnSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionRepository := mocks.NewMockSessionRepository(ctrl)
+	mockSessionRepository.
+		EXPECT().
+		Revoke(gomock.Any(), "test-user-id", "other-session-id").
+		Return(false, nil).
+		Times(1)
+	service := auth.NewAuthService(mocks.NewMockCredentialRepository(ctrl), mockSessionRepository, mocks.NewMockUserRepository(ctrl), keyring(t), 15 * time.Minute, time.Hour, zap.NewNop())
+	ctx := signedIn(&user.User{ID: "test-user-id"}, &auth.Session{ID: "test-session-id"})
+	err := service.RevokeSession(ctx, "other-session-id")
+	if !errors.Is(err, auth.ErrSessionNotFound) {
+		t.Errorf("Expected error: %v but got %v", auth.ErrSessionNotFound, err)
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		currentPassword string
+		expected error
+	}{
+		{"Correct current password", "correct horse battery", nil},
+		{"Incorrect current password", "wrong horse battery", auth.ErrIncorrectPassword},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCredentialRepository := mocks.NewMockCredentialRepository(ctrl)
+			mockSessionRepository := mocks.NewMockSessionRepository(ctrl)
+			mockCredentialRepository.
+				EXPECT().
+				GetByUser(gomock.Any(), "test-user-id").
+				Return(&auth.Credentials{UserId: "test-user-id", PasswordHash: hash(t, "correct horse battery")}, nil).
+				Times(1)
+			if test.expected == nil {
+				mockCredentialRepository.
+					EXPECT().
+					SetPassword(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(nil).
+					Times(1)
+				mockSessionRepository.
+					EXPECT().
+					RevokeAll(gomock.Any(), "test-user-id", "test-session-id").
+					Return(2, nil).
+					Times(1)
+			}
+			service := auth.NewAuthService(mockCredentialRepository, mockSessionRepository, mocks.NewMockUserRepository(ctrl), keyring(t), 15 * time.Minute, time.Hour, zap.NewNop())
+			ctx := signedIn(&user.User{ID: "test-user-id"}, &auth.Session{ID: "test-session-id"})
+			err := service.ChangePassword(ctx, &auth.PasswordChange{CurrentPassword: test.currentPassword, NewPassword: "battery staple horse"})
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestSetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	admin := &user.User{ID: "test-admin-id", Role: user.Admin}
+	tests := []struct {
+		name string
+		user *user.User
+		userErr error
+		expected error
+	}{
+		{"Admin sets password", admin, nil, nil},
+		{"Base user", &user.User{ID: "test-user-id", Role: user.Base}, nil, user.ErrForbidden},
+		{"Missing user", admin, sql.ErrNoRows, user.ErrUserNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCredentialRepository := mocks.NewMockCredentialRepository(ctrl)
+			mockSessionRepository := mocks.NewMockSessionRepository(ctrl)
+			mockUserRepository := mocks.NewMockUserRepository(ctrl)
+			if !errors.Is(test.expected, user.ErrForbidden) {
+				mockUserRepository.
+					EXPECT().
+					GetById(gomock.Any(), "test-created-id").
+					Return(&user.User{ID: "test-created-id", Active: true}, test.userErr).
+					Times(1)
+			}
+			if test.expected == nil {
+				mockCredentialRepository.
+					EXPECT().
+					SetPassword(gomock.Any(), "test-created-id", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userId string, passwordHash string) error {
+						ok, err := password.Verify("correct horse battery", passwordHash)
+						if err != nil || !ok {
+							t.Errorf("Expected the stored hash to match the password")
+						}
+						return nil
+					}).
+					Times(1)
+				mockSessionRepository.
+					EXPECT().
+					RevokeAll(gomock.Any(), "test-created-id", "").
+					Return(0, nil).
+					Times(1)
+			}
+			service := auth.NewAuthService(mockCredentialRepository, mockSessionRepository, mockUserRepository, keyring(t), 15 * time.Minute, time.Hour, zap.NewNop())
+			err := service.SetPassword(signedIn(test.user, nil), "test-created-id", &auth.PasswordReset{NewPassword: "correct horse battery"})
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestSetPasswordShouldLetCreatedUserSignIn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCredentialRepository := mocks.NewMockCredentialRepository(ctrl)
+	mockSessionRepository := mocks.NewMockSessionRepository(ctrl)
+	mockUserRepository := mocks.NewMockUserRepository(ctrl)
+	created := &user.User{ID: "test-created-id", Email: "ada@example.com", Role: user.Base, Active: true}
+	var stored string
+	mockUserRepository.
+		EXPECT().
+		GetById(gomock.Any(), "test-created-id").
+		Return(created, nil).
+		Times(2)
+	mockCredentialRepository.
+		EXPECT().
+		SetPassword(gomock.Any(), "test-created-id", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userId string, passwordHash string) error {
+			stored = passwordHash
+			return nil
+		}).
+		Times(1)
+	mockCredentialRepository.
+		EXPECT().
+		GetByEmail(gomock.Any(), "ada@example.com").
+		DoAndReturn(func(ctx context.Context, email string) (*auth.Credentials, error) {
+			return &auth.Credentials{UserId: "test-created-id", PasswordHash: stored}, nil
+		}).
+		Times(1)
+	mockSessionRepository.EXPECT().RevokeAll(gomock.Any(), "test-created-id", "").Return(0, nil).Times(1)
+	mockSessionRepository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	service := auth.NewAuthService(mockCredentialRepository, mockSessionRepository, mockUserRepository, keyring(t), 15 * time.Minute, time.Hour, zap.NewNop())
+	err := service.SetPassword(signedIn(&user.User{ID: "test-admin-id", Role: user.Admin}, nil), "test-created-id", &auth.PasswordReset{NewPassword: "correct horse battery"})
+	if err != nil {
+		t.Fatal("Could not set password", err.Error())
+	}
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	token, err := service.Login(ctx, &auth.Login{Email: "ada@example.com", Password: "correct horse battery"}, "test-agent", "127.0.0.1")
+
+	if err != nil {
+		t.Fatal("Could not sign in with the password set", err.Error())
+	}
+	if token.User.ID != created.ID {
+		t.Errorf("Expected to sign in as: %s but got %s", created.ID, token.User.ID)
+	}
+}
@@ -0,0 +1,102 @@
+package candidate
+
+import (
+	"errors"
+	"net/http"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type CandidateAPI struct {
+	service *CandidateService
+	log *zap.Logger
+}
+
+func NewCandidateAPI(service *CandidateService, logger *zap.Logger) *CandidateAPI {
+	return &CandidateAPI{service: service, log: logger}
+}
+
+func (api *CandidateAPI) RegisterRoutes(server *gin.Engine) {
+	server.GET("/elections/:id/candidates", user.Require(user.ReadElections), api.getCandidates)
+	server.GET("/elections/:id/candidates/:candidateId", user.Require(user.ReadElections), api.getCandidate)
+	server.POST("/elections/:id/candidates", user.Require(user.ManageElections), api.createCandidate)
+	server.PATCH("/elections/:id/candidates/:candidateId", user.Require(user.ManageElections), api.updateCandidate)
+	server.DELETE("/elections/:id/candidates/:candidateId", user.Require(user.ManageElections), api.deleteCandidate)
+}
+
+func (api *CandidateAPI) createCandidate(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var candidate Candidate
+	err := ctx.ShouldBindJSON(&candidate)
+	if err != nil {
+		api.log.Error(err.Error())
+		api.log.Error("could not parse candidate", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse candidate"})
+		return
+	}
+	err = api.service.CreateCandidate(ctx, ctx.Param("id"), &candidate)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "created candidate"})
+}
+
+func (api *CandidateAPI) getCandidates(ctx *gin.Context) {
+	candidates, err := api.service.GetCandidates(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, candidates)
+}
+
+func (api *CandidateAPI) getCandidate(ctx *gin.Context) {
+	candidate, err := api.service.GetCandidate(ctx, ctx.Param("id"), ctx.Param("candidateId"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, candidate)
+}
+
+func (api *CandidateAPI) updateCandidate(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var updatedCandidate Candidate
+	err := ctx.ShouldBindJSON(&updatedCandidate)
+	if err != nil {
+		api.log.Error(err.Error())
+		api.log.Error("could not parse update information", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse update information"})
+		return
+	}
+	err = api.service.UpdateCandidate(ctx, ctx.Param("id"), ctx.Param("candidateId"), &updatedCandidate)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "updated candidate"})
+}
+
+func (api *CandidateAPI) deleteCandidate(ctx *gin.Context) {
+	err := api.service.DeleteCandidate(ctx, ctx.Param("id"), ctx.Param("candidateId"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "deleted candidate"})
+}
+
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrElectionNotFound), errors.Is(err, ErrCandidateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidList), errors.Is(err, ErrInvalidQuestion):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrElectionNotDraft):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,126 @@
+package candidate_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/candidate"
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func SetupServer() *gin.Engine {
+	server := gin.Default()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("requestId", uuid.New().String())
+		ctx.Set(user.ContextKey, &user.User{ID: "test-admin", Role: user.Admin, Active: true})
+	})
+	return server
+}
+
+func SetupTestAPI(ctrl *gomock.Controller) (*candidate.CandidateAPI, *mocks.MockCandidateRepository, *mocks.MockElectionRepository) {
+	candidateRepository := mocks.NewMockCandidateRepository(ctrl)
+	electionRepository := mocks.NewMockElectionRepository(ctrl)
+	service := candidate.NewCandidateService(candidateRepository, electionRepository, mocks.NewMockPartyListRepository(ctrl), mocks.NewMockQuestionRepository(ctrl), zap.NewNop())
+	return candidate.NewCandidateAPI(service, zap.NewNop()), candidateRepository, electionRepository
+}
+
+func TestCreateCandidateAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := SetupServer()
+	api, mockCandidateRepo, mockElectionRepo := SetupTestAPI(ctrl)
+	api.RegisterRoutes(server)
+
+	tests := []struct {
+		name string
+		body string
+		status election.ElectionStatus
+		statusCode int
+		output string
+	}{
+		{"Fail to parse candidate", `{}`, "", 400, "could not parse candidate"},
+		{"Election is not a draft", `{"Name": "test"}`, election.Active, 409, candidate.ErrElectionNotDraft.Error()},
+		{"Successfully create candidate", `{"Name": "test"}`, election.Draft, 200, "created candidate"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.status != "" {
+				mockElectionRepo.
+					EXPECT().
+					GetById(gomock.Any(), "test-election-id").
+					Return(&election.Election{Status: test.status}, nil).
+					Times(1)
+			}
+			if test.status == election.Draft {
+				mockCandidateRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			}
+			recorder := httptest.NewRecorder()
+			request, _ := http.NewRequest("POST", "/elections/test-election-id/candidates", strings.NewReader(test.body))
+			server.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.statusCode {
+				t.Errorf("Expected status code: %d but got %d", test.statusCode, recorder.Code)
+			}
+			var response map[string]string
+			err := json.Unmarshal(recorder.Body.Bytes(), &response)
+			if err != nil {
+				t.Fatal("Request did not return valid JSON")
+			}
+			message, exists := response["message"]
+			if !exists {
+				t.Fatal("JSON does not contain message key")
+			}
+			if message != test.output {
+				t.Errorf("Expected message: %s but got %s", test.output, message)
+			}
+		})
+	}
+}
+
+func TestGetCandidatesAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := SetupServer()
+	api, mockCandidateRepo, mockElectionRepo := SetupTestAPI(ctrl)
+	api.RegisterRoutes(server)
+
+	expectedCandidates := []candidate.Candidate{
+		{Name: "test-candidate-1"},
+		{Name: "test-candidate-2"},
+	}
+	expectedBytes, _ := json.Marshal(expectedCandidates)
+
+	mockElectionRepo.
+		EXPECT().
+		GetById(gomock.Any(), "test-election-id").
+		Return(&election.Election{Status: election.Draft}, nil).
+		Times(1)
+	mockCandidateRepo.
+		EXPECT().
+		GetAllByElection(gomock.Any(), "test-election-id").
+		Return(expectedCandidates, nil).
+		Times(1)
+
+	recorder := httptest.NewRecorder()
+	request, _ := http.NewRequest("GET", "/elections/test-election-id/candidates", nil)
+	server.ServeHTTP(recorder, request)
+
+	if recorder.Code != 200 {
+		t.Errorf("Expected status code: %d but got %d", 200, recorder.Code)
+	}
+	if recorder.Body.String() != string(expectedBytes) {
+		t.Errorf("JSON response: %s did not match expected: %s", recorder.Body.String(), string(expectedBytes))
+	}
+}
@@ -0,0 +1,60 @@
+package candidate
+
+import (
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/partylist"
+	"geraldaddo.com/live-voting-system/domain/tally"
+)
+
+type Candidate struct {
+	ID string
+	ElectionId string
+	// ListId is the party list the candidate stands for, if any.
+	ListId string
+	// QuestionId is the question the candidate is an option of. Candidates
+	// without one stand in the election itself.
+	QuestionId string
+	Name string `binding:"required"`
+	Description string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ForQuestion keeps the candidates that are options of the question, or of
+// the election itself when questionId is empty.
+func ForQuestion(candidates []Candidate, questionId string) []Candidate {
+	var options []Candidate
+	for _, c := range candidates {
+		if c.QuestionId == questionId {
+			options = append(options, c)
+		}
+	}
+	return options
+}
+
+// Ids returns the IDs of the candidates in ballot order.
+func Ids(candidates []Candidate) []string {
+	ids := make([]string, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+// GroupByList gives each party list its candidates in ballot order, as the
+// tally expects them.
+func GroupByList(lists []partylist.PartyList, candidates []Candidate) []tally.List {
+	grouped := make([]tally.List, len(lists))
+	index := make(map[string]int, len(lists))
+	for i, list := range lists {
+		grouped[i] = tally.List{ID: list.ID, Candidates: []string{}}
+		index[list.ID] = i
+	}
+	for _, c := range candidates {
+		if i, ok := index[c.ListId]; ok {
+			grouped[i].Candidates = append(grouped[i].Candidates, c.ID)
+		}
+	}
+	return grouped
+}
@@ -0,0 +1,94 @@
+package candidate
+
+import (
+	"context"
+	"database/sql"
+
+	"geraldaddo.com/live-voting-system/platform/models"
+)
+
+//go:generate mockgen -destination=../../mocks/mock_candidate_repo.go -package=mocks . CandidateRepository
+type CandidateRepository interface {
+	models.Repository[Candidate]
+	GetAllByElection(ctx context.Context, electionId string) ([]Candidate, error)
+	DeleteOne(ctx context.Context, id string) error
+}
+type CandidateRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewCandidateRepository(db *sql.DB) *CandidateRepositoryImpl {
+	return &CandidateRepositoryImpl{db: db}
+}
+
+func (repo *CandidateRepositoryImpl) Save(ctx context.Context, candidate *Candidate) error {
+	insertStatement := `
+	INSERT INTO candidates(election_id, list_id, question_id, name, description)
+	VALUES ($1, NULLIF($2, '')::UUID, NULLIF($3, '')::UUID, $4, $5)`
+	_, err := repo.db.Exec(
+		insertStatement,
+		candidate.ElectionId,
+		candidate.ListId,
+		candidate.QuestionId,
+		candidate.Name,
+		candidate.Description,
+	)
+	return err
+}
+
+func (repo *CandidateRepositoryImpl) GetById(ctx context.Context, id string) (*Candidate, error) {
+	query := `
+	SELECT id, election_id, COALESCE(list_id::TEXT, ''), COALESCE(question_id::TEXT, ''), name, description, created_at, updated_at
+	FROM candidates
+	WHERE id = $1
+	`
+	row := repo.db.QueryRow(query, id)
+
+	var c Candidate
+	err := row.Scan(&c.ID, &c.ElectionId, &c.ListId, &c.QuestionId, &c.Name, &c.Description, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (repo *CandidateRepositoryImpl) GetAllByElection(ctx context.Context, electionId string) ([]Candidate, error) {
+	query := `
+	SELECT id, election_id, COALESCE(list_id::TEXT, ''), COALESCE(question_id::TEXT, ''), name, description, created_at, updated_at
+	FROM candidates
+	WHERE election_id = $1
+	ORDER BY created_at ASC
+	`
+	rows, err := repo.db.Query(query, electionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []Candidate
+	for rows.Next() {
+		var c Candidate
+		err := rows.Scan(&c.ID, &c.ElectionId, &c.ListId, &c.QuestionId, &c.Name, &c.Description, &c.CreatedAt, &c.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, nil
+}
+
+func (repo *CandidateRepositoryImpl) UpdateOne(ctx context.Context, id string, c *Candidate) error {
+	updateStatement := `
+	UPDATE candidates
+	SET list_id = NULLIF($1, '')::UUID, question_id = NULLIF($2, '')::UUID, name = $3, description = $4,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $5
+	`
+	_, err := repo.db.Exec(updateStatement, c.ListId, c.QuestionId, c.Name, c.Description, id)
+	return err
+}
+
+func (repo *CandidateRepositoryImpl) DeleteOne(ctx context.Context, id string) error {
+	_, err := repo.db.Exec(`DELETE FROM candidates WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,216 @@
+package candidate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/partylist"
+	"geraldaddo.com/live-voting-system/domain/question"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrElectionNotFound = errors.New("Election does not exist")
+	ErrCandidateNotFound = errors.New("Candidate does not exist")
+	ErrElectionNotDraft = errors.New("Candidates can only be changed while the election is a draft")
+	ErrInvalidList = errors.New("Party list is not part of this election")
+	ErrInvalidQuestion = errors.New("Question is not part of this election")
+)
+
+type CandidateService struct {
+	repo CandidateRepository
+	elections election.ElectionRepository
+	lists partylist.PartyListRepository
+	questions question.QuestionRepository
+	log *zap.Logger
+}
+
+func NewCandidateService(
+	repo CandidateRepository,
+	elections election.ElectionRepository,
+	lists partylist.PartyListRepository,
+	questions question.QuestionRepository,
+	logger *zap.Logger,
+) *CandidateService {
+	return &CandidateService{repo: repo, elections: elections, lists: lists, questions: questions, log: logger}
+}
+
+func (service *CandidateService) CreateCandidate(ctx context.Context, electionId string, candidate *Candidate) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	err := service.requireDraft(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	err = service.requireList(ctx, electionId, candidate.ListId)
+	if err != nil {
+		return err
+	}
+	err = service.requireQuestion(ctx, electionId, candidate)
+	if err != nil {
+		return err
+	}
+	candidate.ElectionId = electionId
+	err = service.repo.Save(ctx, candidate)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not create candidate for election: " + electionId, zap.String("request_id", requestId))
+		return errors.New("Could not create candidate")
+	}
+	service.log.Info("Created candidate for election: " + electionId, zap.String("request_id", requestId))
+	return nil
+}
+
+func (service *CandidateService) GetCandidates(ctx context.Context, electionId string) ([]Candidate, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	_, err := service.getElection(ctx, electionId)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := service.repo.GetAllByElection(ctx, electionId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Failed to get candidates for election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get candidates")
+	}
+	service.log.Info(fmt.Sprintf("Got candidates of length: %d", len(candidates)), zap.String("request_id", requestId))
+	return candidates, nil
+}
+
+func (service *CandidateService) GetCandidate(ctx context.Context, electionId string, id string) (*Candidate, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	candidate, err := service.repo.GetById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && candidate.ElectionId != electionId) {
+		service.log.Warn("Could not find candidate with id: " + id, zap.String("request_id", requestId))
+		return nil, ErrCandidateNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get candidate with id: " + id, zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get candidate with ID: " + id)
+	}
+	service.log.Info("Found candidate with ID: " + id, zap.String("request_id", requestId))
+	return candidate, nil
+}
+
+func (service *CandidateService) UpdateCandidate(ctx context.Context, electionId string, id string, updatedCandidate *Candidate) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	err := service.requireDraft(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	_, err = service.GetCandidate(ctx, electionId, id)
+	if err != nil {
+		return err
+	}
+	err = service.requireList(ctx, electionId, updatedCandidate.ListId)
+	if err != nil {
+		return err
+	}
+	err = service.requireQuestion(ctx, electionId, updatedCandidate)
+	if err != nil {
+		return err
+	}
+	err = service.repo.UpdateOne(ctx, id, updatedCandidate)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not update candidate: " + id, zap.String("request_id", requestId))
+		return errors.New("Could not update candidate: " + id)
+	}
+	service.log.Info("Updated candidate: " + id, zap.String("request_id", requestId))
+	return nil
+}
+
+func (service *CandidateService) DeleteCandidate(ctx context.Context, electionId string, id string) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	err := service.requireDraft(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	_, err = service.GetCandidate(ctx, electionId, id)
+	if err != nil {
+		return err
+	}
+	err = service.repo.DeleteOne(ctx, id)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not delete candidate: " + id, zap.String("request_id", requestId))
+		return errors.New("Could not delete candidate: " + id)
+	}
+	service.log.Info("Deleted candidate: " + id, zap.String("request_id", requestId))
+	return nil
+}
+
+func (service *CandidateService) getElection(ctx context.Context, electionId string) (*election.Election, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.elections.GetById(ctx, electionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("Election with id: " + electionId + " does not exist", zap.String("request_id", requestId))
+		return nil, ErrElectionNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get election with ID: " + electionId)
+	}
+	return e, nil
+}
+
+func (service *CandidateService) requireDraft(ctx context.Context, electionId string) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.getElection(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	if e.Status != election.Draft {
+		service.log.Warn("Cannot change candidates of election: " + electionId, zap.String("request_id", requestId))
+		return ErrElectionNotDraft
+	}
+	return nil
+}
+
+// requireList checks that the party list, if one is given, belongs to the
+// election.
+func (service *CandidateService) requireList(ctx context.Context, electionId string, listId string) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	if listId == "" {
+		return nil
+	}
+	list, err := service.lists.GetById(ctx, listId)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && list.ElectionId != electionId) {
+		service.log.Warn("Party list: " + listId + " is not part of election: " + electionId, zap.String("request_id", requestId))
+		return ErrInvalidList
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get party list: " + listId, zap.String("request_id", requestId))
+		return errors.New("Failed to get party list with ID: " + listId)
+	}
+	return nil
+}
+
+// requireQuestion checks that the question, if one is given, belongs to the
+// election. Options of a question cannot stand for a party list.
+func (service *CandidateService) requireQuestion(ctx context.Context, electionId string, candidate *Candidate) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	if candidate.QuestionId == "" {
+		return nil
+	}
+	if candidate.ListId != "" {
+		service.log.Warn("Option of question: " + candidate.QuestionId + " cannot stand for a party list", zap.String("request_id", requestId))
+		return ErrInvalidList
+	}
+	q, err := service.questions.GetById(ctx, candidate.QuestionId)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && q.ElectionId != electionId) {
+		service.log.Warn("Question: " + candidate.QuestionId + " is not part of election: " + electionId, zap.String("request_id", requestId))
+		return ErrInvalidQuestion
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get question: " + candidate.QuestionId, zap.String("request_id", requestId))
+		return errors.New("Failed to get question with ID: " + candidate.QuestionId)
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package candidate_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/candidate"
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/partylist"
+	"geraldaddo.com/live-voting-system/domain/question"
+	"geraldaddo.com/live-voting-system/mocks"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestCreateCandidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+
+	electionId := "test-election-id"
+	input := &candidate.Candidate{Name: "test candidate"}
+
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), electionId).
+		Return(&election.Election{ID: electionId, Status: election.Draft}, nil).
+		Times(1)
+	mockCandidateRepository.
+		EXPECT().
+		Save(gomock.Any(), input).
+		Return(nil).
+		Times(1)
+
+	service := candidate.NewCandidateService(mockCandidateRepository, mockElectionRepository, mocks.NewMockPartyListRepository(ctrl), mocks.NewMockQuestionRepository(ctrl), zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	err := service.CreateCandidate(ctx, electionId, input)
+
+	if err != nil {
+		t.Error("Create candidate returned an error", err.Error())
+	}
+	if input.ElectionId != electionId {
+		t.Errorf("Expected candidate election id: %s but got %s", electionId, input.ElectionId)
+	}
+}
+
+func TestCandidatesShouldOnlyChangeWhileElectionIsDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	statuses := []election.ElectionStatus{election.Active, election.Closed, election.Archived}
+	for _, status := range statuses {
+		t.Run(string(status), func(t *testing.T) {
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), gomock.Any()).
+				Return(&election.Election{Status: status}, nil).
+				Times(3)
+			service := candidate.NewCandidateService(mockCandidateRepository, mockElectionRepository, mocks.NewMockPartyListRepository(ctrl), mocks.NewMockQuestionRepository(ctrl), zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+
+			err := service.CreateCandidate(ctx, "test-id", &candidate.Candidate{Name: "test"})
+			if !errors.Is(err, candidate.ErrElectionNotDraft) {
+				t.Errorf("Expected create to fail with: %v but got %v", candidate.ErrElectionNotDraft, err)
+			}
+			err = service.UpdateCandidate(ctx, "test-id", "test-candidate-id", &candidate.Candidate{Name: "test"})
+			if !errors.Is(err, candidate.ErrElectionNotDraft) {
+				t.Errorf("Expected update to fail with: %v but got %v", candidate.ErrElectionNotDraft, err)
+			}
+			err = service.DeleteCandidate(ctx, "test-id", "test-candidate-id")
+			if !errors.Is(err, candidate.ErrElectionNotDraft) {
+				t.Errorf("Expected delete to fail with: %v but got %v", candidate.ErrElectionNotDraft, err)
+			}
+		})
+	}
+}
+
+func TestGetCandidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+
+	electionId := "test-election-id"
+	candidates := []candidate.Candidate{
+		{Name: "test-1", ElectionId: electionId},
+		{Name: "test-2", ElectionId: electionId},
+	}
+
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), electionId).
+		Return(&election.Election{ID: electionId, Status: election.Active}, nil).
+		Times(1)
+	mockCandidateRepository.
+		EXPECT().
+		GetAllByElection(gomock.Any(), electionId).
+		Return(candidates, nil).
+		Times(1)
+
+	service := candidate.NewCandidateService(mockCandidateRepository, mockElectionRepository, mocks.NewMockPartyListRepository(ctrl), mocks.NewMockQuestionRepository(ctrl), zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	result, err := service.GetCandidates(ctx, electionId)
+
+	if err != nil {
+		t.Error("Could not get list of candidates", err.Error())
+	}
+	if !slices.Equal(result, candidates) {
+		t.Error("Did not return expected candidates")
+	}
+}
+
+func TestGetCandidateShouldNotReturnCandidateFromAnotherElection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+
+	mockCandidateRepository.
+		EXPECT().
+		GetById(gomock.Any(), "test-candidate-id").
+		Return(&candidate.Candidate{ID: "test-candidate-id", ElectionId: "other-election-id"}, nil).
+		Times(1)
+
+	service := candidate.NewCandidateService(mockCandidateRepository, mockElectionRepository, mocks.NewMockPartyListRepository(ctrl), mocks.NewMockQuestionRepository(ctrl), zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	_, err := service.GetCandidate(ctx, "test-election-id", "test-candidate-id")
+
+	if !errors.Is(err, candidate.ErrCandidateNotFound) {
+		t.Errorf("Expected error: %v but got %v", candidate.ErrCandidateNotFound, err)
+	}
+}
+
+func TestCreateCandidateShouldRequireListFromSameElection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		list *partylist.PartyList
+		lookupErr error
+		expected error
+	}{
+		{"List from this election", &partylist.PartyList{ID: "test-list-id", ElectionId: "test-election-id"}, nil, nil},
+		{"Missing list", nil, sql.ErrNoRows, candidate.ErrInvalidList},
+		{"List from another election", &partylist.PartyList{ID: "test-list-id", ElectionId: "other-election-id"}, nil, candidate.ErrInvalidList},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockPartyListRepository := mocks.NewMockPartyListRepository(ctrl)
+			input := &candidate.Candidate{Name: "test candidate", ListId: "test-list-id"}
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&election.Election{ID: "test-election-id", Status: election.Draft}, nil).
+				Times(1)
+			mockPartyListRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-list-id").
+				Return(test.list, test.lookupErr).
+				Times(1)
+			if test.expected == nil {
+				mockCandidateRepository.
+					EXPECT().
+					Save(gomock.Any(), input).
+					Return(nil).
+					Times(1)
+			}
+			service := candidate.NewCandidateService(mockCandidateRepository, mockElectionRepository, mockPartyListRepository, mocks.NewMockQuestionRepository(ctrl), zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			err := service.CreateCandidate(ctx, "test-election-id", input)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestCreateCandidateShouldRequireQuestionFromSameElection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		question *question.Question
+		lookupErr error
+		expected error
+	}{
+		{"Question from this election", &question.Question{ID: "test-question-id", ElectionId: "test-election-id"}, nil, nil},
+		{"Missing question", nil, sql.ErrNoRows, candidate.ErrInvalidQuestion},
+		{"Question from another election", &question.Question{ID: "test-question-id", ElectionId: "other-election-id"}, nil, candidate.ErrInvalidQuestion},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockQuestionRepository := mocks.NewMockQuestionRepository(ctrl)
+			input := &candidate.Candidate{Name: "In favour", QuestionId: "test-question-id"}
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&election.Election{ID: "test-election-id", Status: election.Draft}, nil).
+				Times(1)
+			mockQuestionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-question-id").
+				Return(test.question, test.lookupErr).
+				Times(1)
+			if test.expected == nil {
+				mockCandidateRepository.
+					EXPECT().
+					Save(gomock.Any(), input).
+					Return(nil).
+					Times(1)
+			}
+			service := candidate.NewCandidateService(mockCandidateRepository, mockElectionRepository, mocks.NewMockPartyListRepository(ctrl), mockQuestionRepository, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			err := service.CreateCandidate(ctx, "test-election-id", input)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package election
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"geraldaddo.com/live-voting-system/domain/user"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -18,10 +20,13 @@ func NewElectionAPI(service *ElectionService, logger *zap.Logger) *ElectionAPI {
 }
 
 func (api *ElectionAPI) RegisterRoutes(server *gin.Engine) {
-	server.GET("/elections", api.getElections)
-	server.GET("/elections/:id", api.getElection)
-	server.POST("/elections", api.createElection)
-	server.PATCH("/elections/:id", api.updateElection)
+	server.GET("/elections", user.Require(user.ReadElections), api.getElections)
+	server.GET("/elections/:id", user.Require(user.ReadElections), api.getElection)
+	server.POST("/elections", user.Require(user.ManageElections), api.createElection)
+	server.PATCH("/elections/:id", user.Require(user.ManageElections), api.updateElection)
+	server.POST("/elections/:id/open", user.Require(user.ManageElections), api.transitionElection(Active, "opened election"))
+	server.POST("/elections/:id/close", user.Require(user.ManageElections), api.transitionElection(Closed, "closed election"))
+	server.POST("/elections/:id/archive", user.Require(user.ManageElections), api.transitionElection(Archived, "archived election"))
 }
 
 func (api *ElectionAPI) createElection(ctx *gin.Context) {
@@ -36,7 +41,7 @@ func (api *ElectionAPI) createElection(ctx *gin.Context) {
 	}
 	err = api.service.CreateElection(ctx, &election)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{"message": "created election"})
@@ -92,18 +97,44 @@ func (api *ElectionAPI) getElection(ctx *gin.Context) {
 func (api *ElectionAPI) updateElection(ctx *gin.Context) {
 	requestId := ctx.GetString("requestId")
 	electionId := ctx.Param("id")
-	var updatedElection Election
-	err := ctx.ShouldBindJSON(&updatedElection)
+	var update ElectionUpdate
+	err := ctx.ShouldBindJSON(&update)
 	if err != nil {
 		api.log.Error(err.Error())
 		api.log.Error("could not parse update information", zap.String("request_id", requestId))
 		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse update information"})
 		return
 	}
-	err = api.service.UpdateElection(ctx, electionId, &updatedElection)
+	err = api.service.UpdateElection(ctx, electionId, &update)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{"message": "updated election"})
-}
\ No newline at end of file
+}
+
+func (api *ElectionAPI) transitionElection(target ElectionStatus, message string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		err := api.service.TransitionElection(ctx, ctx.Param("id"), target)
+		if err != nil {
+			ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": message})
+	}
+}
+
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrElectionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidMethod), errors.Is(err, ErrInvalidSeats), errors.Is(err, ErrInvalidSurplusTransfer),
+		errors.Is(err, ErrInvalidThreshold), errors.Is(err, ErrInvalidCredits), errors.Is(err, ErrInvalidEligibility):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrIllegalTransition), errors.Is(err, ErrStatusChanged):
+		return http.StatusConflict
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
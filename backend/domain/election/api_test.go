@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/user"
 	"geraldaddo.com/live-voting-system/mocks"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -21,12 +22,15 @@ func SetupServer() *gin.Engine {
 	server := gin.Default()
 	server.Use(func(ctx *gin.Context) {
 		ctx.Set("requestId", uuid.New().String())
+		ctx.Set(user.ContextKey, &user.User{ID: "test-admin", Role: user.Admin, Active: true})
 	})
 	return server
 }
 func SetupTestAPI(ctrl *gomock.Controller) (*election.ElectionAPI, *mocks.MockElectionRepository) {
 	repository := mocks.NewMockElectionRepository(ctrl)
-	service := election.NewElectionService(repository, zap.NewNop())
+	publisher := mocks.NewMockStatusPublisher(ctrl)
+	publisher.EXPECT().PublishStatus(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	service := election.NewElectionService(repository, publisher, zap.NewNop())
 	return election.NewElectionAPI(service, zap.NewNop()), repository
 }
 
@@ -254,4 +258,102 @@ func TestUpdateElectionAPI(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestTransitionElectionAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := SetupServer()
+	api, mockRepo := SetupTestAPI(ctrl)
+	api.RegisterRoutes(server)
+
+	tests := []struct {
+		name string
+		path string
+		current election.ElectionStatus
+		target election.ElectionStatus
+		status int
+		result string
+	}{
+		{"Open draft election", "open", election.Draft, election.Active, 200, "opened election"},
+		{"Close active election", "close", election.Active, election.Closed, 200, "closed election"},
+		{"Reopen closed election", "open", election.Closed, election.Active, 200, "opened election"},
+		{"Archive closed election", "archive", election.Closed, election.Archived, 200, "archived election"},
+		{"Archive draft election", "archive", election.Draft, election.Archived, 409, "Cannot move election from draft to archived"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockRepo.
+				EXPECT().
+				GetById(gomock.Any(), "test-id").
+				Return(&election.Election{ID: "test-id", Status: test.current}, nil).
+				Times(1)
+			if test.status == 200 {
+				mockRepo.
+					EXPECT().
+					UpdateStatus(gomock.Any(), "test-id", test.current, test.target).
+					Return(true, nil).
+					Times(1)
+			}
+			recorder := httptest.NewRecorder()
+			request, _ := http.NewRequest("POST", "/elections/test-id/" + test.path, nil)
+			server.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Errorf("Expect status code: %d but got %d", test.status, recorder.Code)
+			}
+			var response map[string]string
+			err := json.Unmarshal(recorder.Body.Bytes(), &response)
+			if err != nil {
+				t.Fatal("Request did not return valid JSON")
+			}
+			if response["message"] != test.result {
+				t.Errorf("Expected message: %s but got %s", test.result, response["message"])
+			}
+		})
+	}
+}
+
+func TestElectionAPIShouldEnforcePermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		user *user.User
+		method string
+		path string
+		statusCode int
+	}{
+		{"Anonymous user cannot list elections", nil, "GET", "/elections", 401},
+		{"Base user can list elections", &user.User{ID: "test-user", Role: user.Base}, "GET", "/elections", 200},
+		{"Base user cannot create elections", &user.User{ID: "test-user", Role: user.Base}, "POST", "/elections", 403},
+		{"Base user cannot update elections", &user.User{ID: "test-user", Role: user.Base}, "PATCH", "/elections/test-election-id", 403},
+		{"Base user cannot open elections", &user.User{ID: "test-user", Role: user.Base}, "POST", "/elections/test-election-id/open", 403},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := gin.New()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("requestId", uuid.New().String())
+				if test.user != nil {
+					ctx.Set(user.ContextKey, test.user)
+				}
+			})
+			api, mockRepo := SetupTestAPI(ctrl)
+			api.RegisterRoutes(server)
+			if test.statusCode == 200 {
+				mockRepo.EXPECT().GetAllWithFilters(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+			}
+			recorder := httptest.NewRecorder()
+			request, _ := http.NewRequest(test.method, test.path, strings.NewReader("{}"))
+			server.ServeHTTP(recorder, request)
+			if recorder.Code != test.statusCode {
+				t.Errorf("Expected status code: %d but got %d", test.statusCode, recorder.Code)
+			}
+		})
+	}
+}
@@ -1,6 +1,12 @@
 package election
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/tally"
+)
 
 type ElectionStatus string
 
@@ -8,24 +14,191 @@ const (
 	Draft ElectionStatus = "draft"
 	Active ElectionStatus = "active"
 	Closed ElectionStatus = "closed"
+	// Certified elections have had their results signed off by an admin.
+	Certified ElectionStatus = "certified"
 	Archived ElectionStatus = "archived"
 )
 
+type VotingMethod string
+
+const (
+	Plurality VotingMethod = "plurality"
+	InstantRunoff VotingMethod = "irv"
+	Approval VotingMethod = "approval"
+	Borda VotingMethod = "borda"
+	Score VotingMethod = "score"
+	Schulze VotingMethod = "schulze"
+	RankedPairs VotingMethod = "ranked_pairs"
+	SingleTransferableVote VotingMethod = "stv"
+	DHondt VotingMethod = "dhondt"
+	SainteLague VotingMethod = "sainte_lague"
+	Hare VotingMethod = "hare"
+	Quadratic VotingMethod = "quadratic"
+	Cumulative VotingMethod = "cumulative"
+)
+
+type SurplusTransfer string
+
+const (
+	GregoryTransfer SurplusTransfer = SurplusTransfer(tally.Gregory)
+	MeekTransfer SurplusTransfer = SurplusTransfer(tally.Meek)
+)
+
 type Election struct {
 	ID string
 	Title string `binding:"required"`
 	Description string `binding:"required"`
 	StartTime time.Time `binding:"required"`
 	EndTime time.Time `binding:"required"`
-	Status ElectionStatus `binding:"required"`
+	Status ElectionStatus
+	Method VotingMethod
+	// Seats is the number of winners. Only multi-winner methods fill more
+	// than one seat.
+	Seats int
+	// SurplusTransfer picks how a single transferable vote passes on the
+	// surplus of elected candidates.
+	SurplusTransfer SurplusTransfer
+	// Threshold is the percentage of votes a party list needs to win seats.
+	Threshold float64
+	// Credits is the budget each voter spends on a quadratic ballot.
+	Credits int
+	// Eligibility is a rule admitting the users who match it, in addition
+	// to those on the electoral roll. See eligibility.Rule.
+	Eligibility string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// ElectionUpdate changes the details of a draft election. Fields missing
+// from the request keep their current value; fields sent empty or zero are
+// cleared, so an eligibility rule can be removed or a threshold reset.
+type ElectionUpdate struct {
+	Title *string `binding:"omitnil,min=1"`
+	Description *string `binding:"omitnil,min=1"`
+	StartTime *time.Time
+	EndTime *time.Time
+	Method *VotingMethod
+	Seats *int
+	SurplusTransfer *SurplusTransfer
+	Threshold *float64
+	Credits *int
+	Eligibility *string
+}
+
+// Apply returns the election with the update made. The options of the
+// voting method are only kept while the method stays the same.
+func (update *ElectionUpdate) Apply(election Election) Election {
+	updated := election
+	if update.Title != nil {
+		updated.Title = *update.Title
+	}
+	if update.Description != nil {
+		updated.Description = *update.Description
+	}
+	if update.StartTime != nil {
+		updated.StartTime = *update.StartTime
+	}
+	if update.EndTime != nil {
+		updated.EndTime = *update.EndTime
+	}
+	if update.Method != nil && *update.Method != election.Method {
+		updated.Method = *update.Method
+		updated.SurplusTransfer = ""
+		updated.Threshold = 0
+		updated.Credits = 0
+	}
+	if update.Seats != nil {
+		updated.Seats = *update.Seats
+	}
+	if update.SurplusTransfer != nil {
+		updated.SurplusTransfer = *update.SurplusTransfer
+	}
+	if update.Threshold != nil {
+		updated.Threshold = *update.Threshold
+	}
+	if update.Credits != nil {
+		updated.Credits = *update.Credits
+	}
+	if update.Eligibility != nil {
+		updated.Eligibility = *update.Eligibility
+	}
+	return updated
+}
+
 func (status ElectionStatus) IsValid() bool {
 	switch status {
-	case Draft, Active, Closed, Archived:
+	case Draft, Active, Closed, Certified, Archived:
 		return true
 	}
 	return false
-}
\ No newline at end of file
+}
+
+func (method VotingMethod) IsValid() bool {
+	switch method {
+	case Plurality, InstantRunoff, Approval, Borda, Score, Schulze, RankedPairs, SingleTransferableVote, DHondt, SainteLague, Hare, Quadratic, Cumulative:
+		return true
+	}
+	return false
+}
+
+func (method VotingMethod) IsMultiWinner() bool {
+	return method == SingleTransferableVote || method == Cumulative || method.IsPartyList()
+}
+
+// IsPartyList reports whether voters choose a party list rather than
+// candidates.
+func (method VotingMethod) IsPartyList() bool {
+	switch method {
+	case DHondt, SainteLague, Hare:
+		return true
+	}
+	return false
+}
+
+// Contest describes the election to the tally method counting it.
+func (election *Election) Contest(candidates []string, lists []tally.List) tally.Contest {
+	return tally.Contest{
+		Candidates: candidates,
+		Lists: lists,
+		Seats: election.Seats,
+		SurplusTransfer: string(election.SurplusTransfer),
+		Threshold: election.Threshold,
+		Credits: election.Credits,
+	}
+}
+
+// transitions lists the statuses an election may move to from each status.
+// Closed elections can be reopened and archived elections restored to closed.
+// Certification is final, so certified elections can only be archived and,
+// once archived, are never restored.
+var transitions = map[ElectionStatus][]ElectionStatus{
+	Draft: {Active},
+	Active: {Closed},
+	Closed: {Active, Certified, Archived},
+	Certified: {Archived},
+	Archived: {Closed},
+}
+
+func (status ElectionStatus) CanTransitionTo(target ElectionStatus) bool {
+	for _, allowed := range transitions[status] {
+		if allowed == target {
+			return true
+		}
+	}
+	return false
+}
+
+var ErrIllegalTransition = errors.New("Illegal election status transition")
+
+type TransitionError struct {
+	From ElectionStatus
+	To ElectionStatus
+}
+
+func (err *TransitionError) Error() string {
+	return fmt.Sprintf("Cannot move election from %s to %s", err.From, err.To)
+}
+
+func (err *TransitionError) Is(target error) bool {
+	return target == ErrIllegalTransition
+}
@@ -3,6 +3,7 @@ package election
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"geraldaddo.com/live-voting-system/platform/models"
 )
@@ -17,7 +18,13 @@ type ElectionQueryParams struct {
 type ElectionRepository interface {
 	models.Repository[Election]
 	GetAllWithFilters(ctx context.Context, params ElectionQueryParams) ([]Election, error)
+	UpdateStatus(ctx context.Context, id string, from ElectionStatus, to ElectionStatus) (bool, error)
+	IsCertified(ctx context.Context, id string) (bool, error)
+	GetDueToOpen(ctx context.Context, now time.Time) ([]Election, error)
+	GetDueToClose(ctx context.Context, now time.Time) ([]Election, error)
 }
+const electionColumns = `id, title, description, start_time, end_time, status, method, seats, surplus_transfer, threshold, credits, eligibility, created_at, updated_at`
+
 type ElectionRepositoryImpl struct {
 	db *sql.DB
 }
@@ -28,32 +35,38 @@ func NewElectionRepository(db *sql.DB) *ElectionRepositoryImpl {
 
 func (repo *ElectionRepositoryImpl) Save(ctx context.Context, election *Election) error {
 	insertStatement := `
-	INSERT INTO elections(title, description, start_time, end_time, status)
-	VALUES ($1, $2, $3, $4, $5)`
+	INSERT INTO elections(title, description, start_time, end_time, status, method, seats, surplus_transfer, threshold,
+		credits, eligibility)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 	_, err := repo.db.Exec(
-		insertStatement, election.Title, election.Description, election.StartTime, election.EndTime, election.Status)
+		insertStatement,
+		election.Title,
+		election.Description,
+		election.StartTime,
+		election.EndTime,
+		election.Status,
+		election.Method,
+		election.Seats,
+		election.SurplusTransfer,
+		election.Threshold,
+		election.Credits,
+		election.Eligibility,
+	)
 	return err
 }
 
 func (repo *ElectionRepositoryImpl) GetById(ctx context.Context, id string) (*Election, error) {
 	query := `
-	SELECT id, title, description, start_time, end_time, status, created_at, updated_at
+	SELECT ` + electionColumns + `
 	FROM elections
 	WHERE id = $1
 	`
-	row := repo.db.QueryRow(query, id)
-	
-	var e Election
-	err := row.Scan(&e.ID, &e.Title, &e.Description, &e.StartTime, &e.EndTime, &e.Status, &e.CreatedAt, &e.UpdatedAt)
-	if err != nil {
-		return nil, err
-	}
-	return &e, nil
+	return scanElection(repo.db.QueryRow(query, id))
 }
 
 func (repo *ElectionRepositoryImpl) GetAllWithFilters(ctx context.Context, params ElectionQueryParams) ([]Election, error) {
 	query := `
-	SELECT id, title, description, start_time, end_time, status, created_at, updated_at
+	SELECT ` + electionColumns + `
 	FROM elections
 	WHERE $1 = '' OR status = $1
 	ORDER BY created_at DESC
@@ -63,26 +76,125 @@ func (repo *ElectionRepositoryImpl) GetAllWithFilters(ctx context.Context, param
 	if err != nil {
 		return nil, err
 	}
+	return scanElections(rows)
+}
+
+func (repo *ElectionRepositoryImpl) GetDueToOpen(ctx context.Context, now time.Time) ([]Election, error) {
+	query := `
+	SELECT ` + electionColumns + `
+	FROM elections
+	WHERE status = $1 AND start_time <= $2
+	ORDER BY start_time ASC
+	`
+	rows, err := repo.db.Query(query, Draft, now)
+	if err != nil {
+		return nil, err
+	}
+	return scanElections(rows)
+}
+
+func (repo *ElectionRepositoryImpl) GetDueToClose(ctx context.Context, now time.Time) ([]Election, error) {
+	query := `
+	SELECT ` + electionColumns + `
+	FROM elections
+	WHERE status = $1 AND end_time <= $2
+	ORDER BY end_time ASC
+	`
+	rows, err := repo.db.Query(query, Active, now)
+	if err != nil {
+		return nil, err
+	}
+	return scanElections(rows)
+}
+
+func scanElections(rows *sql.Rows) ([]Election, error) {
 	defer rows.Close()
 
 	var elections []Election
 	for rows.Next() {
-		var e Election
-		err := rows.Scan(&e.ID, &e.Title, &e.Description, &e.StartTime, &e.EndTime, &e.Status, &e.CreatedAt, &e.UpdatedAt)
+		e, err := scanElection(rows)
 		if err != nil {
 			return nil, err
 		}
-		elections = append(elections, e)
+		elections = append(elections, *e)
 	}
 	return elections, nil
 }
 
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanElection(row scanner) (*Election, error) {
+	var e Election
+	err := row.Scan(
+		&e.ID,
+		&e.Title,
+		&e.Description,
+		&e.StartTime,
+		&e.EndTime,
+		&e.Status,
+		&e.Method,
+		&e.Seats,
+		&e.SurplusTransfer,
+		&e.Threshold,
+		&e.Credits,
+		&e.Eligibility,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
 func (repo *ElectionRepositoryImpl) UpdateOne(ctx context.Context, id string, e *Election) error {
 	updateStatement := `
 	UPDATE elections
-	SET title = $1, description = $2, start_time = $3, end_time = $4, status = $5
-	WHERE id = $6
+	SET title = $1, description = $2, start_time = $3, end_time = $4, method = $5, seats = $6, surplus_transfer = $7,
+		threshold = $8, credits = $9, eligibility = $10, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $11
 	`
-	_, err := repo.db.Exec(updateStatement, &e.Title, &e.Description, &e.StartTime, &e.EndTime, &e.Status, id)
+	_, err := repo.db.Exec(
+		updateStatement,
+		&e.Title,
+		&e.Description,
+		&e.StartTime,
+		&e.EndTime,
+		&e.Method,
+		&e.Seats,
+		&e.SurplusTransfer,
+		&e.Threshold,
+		&e.Credits,
+		&e.Eligibility,
+		id,
+	)
 	return err
-}
\ No newline at end of file
+}
+
+// UpdateStatus only moves the election if it is still in the from status, so
+// two concurrent transitions cannot both succeed. It reports whether the
+// status was changed.
+func (repo *ElectionRepositoryImpl) UpdateStatus(ctx context.Context, id string, from ElectionStatus, to ElectionStatus) (bool, error) {
+	updateStatement := `
+	UPDATE elections
+	SET status = $1, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $2 AND status = $3
+	`
+	result, err := repo.db.Exec(updateStatement, to, id, from)
+	if err != nil {
+		return false, err
+	}
+	updated, err := result.RowsAffected()
+	return updated == 1, err
+}
+
+// IsCertified reports whether the election's results were ever certified, which
+// stays true once a certified election is archived.
+func (repo *ElectionRepositoryImpl) IsCertified(ctx context.Context, id string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM certifications WHERE election_id = $1)`
+	var certified bool
+	err := repo.db.QueryRow(query, id).Scan(&certified)
+	return certified, err
+}
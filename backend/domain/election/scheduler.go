@@ -0,0 +1,106 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SchedulerLockKey identifies the advisory lock that elects the instance
+// running the scheduler.
+const SchedulerLockKey int64 = 4_172_310_001
+
+type Leader interface {
+	TryAcquire(ctx context.Context) (bool, error)
+	Release(ctx context.Context) error
+}
+
+// ElectionScheduler opens draft elections once their start time has passed
+// and closes active elections once their end time has passed. Every instance
+// runs a scheduler but only the current leader acts on a tick. Each tick
+// looks for everything that is overdue rather than what became due since the
+// last tick, so elections missed during downtime are caught up on start.
+type ElectionScheduler struct {
+	service *ElectionService
+	repo ElectionRepository
+	leader Leader
+	interval time.Duration
+	log *zap.Logger
+}
+
+func NewElectionScheduler(
+	service *ElectionService,
+	repo ElectionRepository,
+	leader Leader,
+	interval time.Duration,
+	logger *zap.Logger,
+) *ElectionScheduler {
+	return &ElectionScheduler{service: service, repo: repo, leader: leader, interval: interval, log: logger}
+}
+
+func (scheduler *ElectionScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(scheduler.interval)
+		defer ticker.Stop()
+		for {
+			scheduler.RunOnce(ctx, time.Now())
+			select {
+			case <-ctx.Done():
+				err := scheduler.leader.Release(context.Background())
+				if err != nil {
+					scheduler.log.Error(err.Error())
+					scheduler.log.Error("Could not release scheduler leadership")
+				}
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (scheduler *ElectionScheduler) RunOnce(ctx context.Context, now time.Time) {
+	ctx = context.WithValue(ctx, "requestId", "scheduler")
+	leader, err := scheduler.leader.TryAcquire(ctx)
+	if err != nil {
+		scheduler.log.Error(err.Error())
+		scheduler.log.Error("Could not check scheduler leadership")
+		return
+	}
+	if !leader {
+		return
+	}
+
+	due, err := scheduler.repo.GetDueToOpen(ctx, now)
+	if err != nil {
+		scheduler.log.Error(err.Error())
+		scheduler.log.Error("Could not get elections due to open")
+	}
+	for _, election := range due {
+		scheduler.transition(ctx, election.ID, Active)
+	}
+
+	// Runs after opening so an election that started and ended while the
+	// scheduler was down is closed in the same tick.
+	due, err = scheduler.repo.GetDueToClose(ctx, now)
+	if err != nil {
+		scheduler.log.Error(err.Error())
+		scheduler.log.Error("Could not get elections due to close")
+	}
+	for _, election := range due {
+		scheduler.transition(ctx, election.ID, Closed)
+	}
+}
+
+func (scheduler *ElectionScheduler) transition(ctx context.Context, id string, target ElectionStatus) {
+	err := scheduler.service.moveElection(ctx, id, target)
+	if errors.Is(err, ErrStatusChanged) || errors.Is(err, ErrIllegalTransition) {
+		scheduler.log.Warn("Election: " + id + " was changed before the scheduler could move it to " + string(target))
+		return
+	}
+	if err != nil {
+		scheduler.log.Error(err.Error())
+		scheduler.log.Error("Scheduler could not move election: " + id + " to " + string(target))
+	}
+}
@@ -0,0 +1,98 @@
+package election_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/mocks"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+type fakeLeader struct {
+	leader bool
+}
+
+func (leader *fakeLeader) TryAcquire(ctx context.Context) (bool, error) {
+	return leader.leader, nil
+}
+
+func (leader *fakeLeader) Release(ctx context.Context) error {
+	return nil
+}
+
+func SetupTestScheduler(ctrl *gomock.Controller, leader bool) (*election.ElectionScheduler, *mocks.MockElectionRepository, *mocks.MockStatusPublisher) {
+	repository := mocks.NewMockElectionRepository(ctrl)
+	publisher := mocks.NewMockStatusPublisher(ctrl)
+	service := election.NewElectionService(repository, publisher, zap.NewNop())
+	scheduler := election.NewElectionScheduler(service, repository, &fakeLeader{leader: leader}, time.Minute, zap.NewNop())
+	return scheduler, repository, publisher
+}
+
+func TestSchedulerShouldDoNothingWithoutLeadership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	scheduler, _, _ := SetupTestScheduler(ctrl, false)
+	scheduler.RunOnce(context.Background(), time.Now())
+}
+
+func TestSchedulerShouldOpenAndCloseDueElections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	scheduler, mockRepo, mockPublisher := SetupTestScheduler(ctrl, true)
+	now := time.Now()
+
+	// missed-election started and ended while no instance was running, so it
+	// is opened and then closed in the same tick.
+	dueToOpen := []election.Election{
+		{ID: "starting-election", Status: election.Draft, StartTime: now.Add(-1 * time.Minute), EndTime: now.Add(time.Hour)},
+		{ID: "missed-election", Status: election.Draft, StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-1 * time.Hour)},
+	}
+	dueToClose := []election.Election{
+		{ID: "ending-election", Status: election.Active, StartTime: now.Add(-1 * time.Hour), EndTime: now.Add(-1 * time.Minute)},
+		{ID: "missed-election", Status: election.Active, StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-1 * time.Hour)},
+	}
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetDueToOpen(gomock.Any(), now).Return(dueToOpen, nil),
+		mockRepo.EXPECT().GetById(gomock.Any(), "starting-election").Return(&dueToOpen[0], nil),
+		mockRepo.EXPECT().UpdateStatus(gomock.Any(), "starting-election", election.Draft, election.Active).Return(true, nil),
+		mockRepo.EXPECT().GetById(gomock.Any(), "missed-election").Return(&dueToOpen[1], nil),
+		mockRepo.EXPECT().UpdateStatus(gomock.Any(), "missed-election", election.Draft, election.Active).Return(true, nil),
+		mockRepo.EXPECT().GetDueToClose(gomock.Any(), now).Return(dueToClose, nil),
+		mockRepo.EXPECT().GetById(gomock.Any(), "ending-election").Return(&dueToClose[0], nil),
+		mockRepo.EXPECT().UpdateStatus(gomock.Any(), "ending-election", election.Active, election.Closed).Return(true, nil),
+		mockRepo.EXPECT().GetById(gomock.Any(), "missed-election").Return(&dueToClose[1], nil),
+		mockRepo.EXPECT().UpdateStatus(gomock.Any(), "missed-election", election.Active, election.Closed).Return(true, nil),
+	)
+	mockPublisher.EXPECT().PublishStatus(gomock.Any(), gomock.Any(), election.Active).Times(2)
+	mockPublisher.EXPECT().PublishStatus(gomock.Any(), gomock.Any(), election.Closed).Times(2)
+
+	scheduler.RunOnce(context.Background(), now)
+}
+
+func TestSchedulerShouldContinueWhenAnElectionWasMovedConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	scheduler, mockRepo, mockPublisher := SetupTestScheduler(ctrl, true)
+	now := time.Now()
+	dueToOpen := []election.Election{
+		{ID: "moved-election", Status: election.Draft},
+		{ID: "starting-election", Status: election.Draft},
+	}
+
+	mockRepo.EXPECT().GetDueToOpen(gomock.Any(), now).Return(dueToOpen, nil)
+	mockRepo.EXPECT().GetById(gomock.Any(), "moved-election").Return(&dueToOpen[0], nil)
+	mockRepo.EXPECT().UpdateStatus(gomock.Any(), "moved-election", election.Draft, election.Active).Return(false, nil)
+	mockRepo.EXPECT().GetById(gomock.Any(), "starting-election").Return(&dueToOpen[1], nil)
+	mockRepo.EXPECT().UpdateStatus(gomock.Any(), "starting-election", election.Draft, election.Active).Return(true, nil)
+	mockRepo.EXPECT().GetDueToClose(gomock.Any(), now).Return(nil, nil)
+	mockPublisher.EXPECT().PublishStatus(gomock.Any(), "starting-election", election.Active).Times(1)
+
+	scheduler.RunOnce(context.Background(), now)
+}
@@ -2,30 +2,77 @@ package election
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"time"
 
+	"geraldaddo.com/live-voting-system/domain/eligibility"
+	"geraldaddo.com/live-voting-system/domain/user"
 	"go.uber.org/zap"
 )
 
+var (
+	ErrElectionNotFound = errors.New("Election does not exist")
+	ErrStatusChanged = errors.New("Election status was changed by another request")
+	ErrInvalidMethod = errors.New("Voting method is not supported")
+	ErrInvalidSeats = errors.New("Number of seats is not supported by the voting method")
+	ErrInvalidSurplusTransfer = errors.New("Surplus transfer is not supported by the voting method")
+	ErrInvalidThreshold = errors.New("Threshold is not supported by the voting method")
+	ErrInvalidCredits = errors.New("Credits are not supported by the voting method")
+	ErrInvalidEligibility = eligibility.ErrInvalidRule
+	ErrForbidden = user.ErrForbidden
+)
+
+// StatusPublisher is told whenever an election moves to a new status so live
+// viewers can follow the transition.
+//
+//go:generate mockgen -destination=../../mocks/mock_election_publisher.go -package=mocks . StatusPublisher
+type StatusPublisher interface {
+	PublishStatus(ctx context.Context, electionId string, status ElectionStatus)
+}
+
 type ElectionService struct {
 	repo ElectionRepository
+	publisher StatusPublisher
 	log *zap.Logger
 }
 
-func NewElectionService(repo ElectionRepository, logger *zap.Logger) *ElectionService {
-	return &ElectionService{repo: repo, log: logger}
+func NewElectionService(repo ElectionRepository, publisher StatusPublisher, logger *zap.Logger) *ElectionService {
+	return &ElectionService{repo: repo, publisher: publisher, log: logger}
+}
+
+// authorize refuses to let anyone but admins manage elections, whichever route
+// the request came through.
+func (service *ElectionService) authorize(ctx context.Context) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	if !user.Can(ctx, user.ManageElections) {
+		service.log.Warn("Election management requested by a user without permission", zap.String("request_id", requestId))
+		return ErrForbidden
+	}
+	return nil
 }
 
 func (service *ElectionService) CreateElection(ctx context.Context, election *Election) error {
 	requestId, _ := ctx.Value("requestId").(string)
+	err := service.authorize(ctx)
+	if err != nil {
+		return err
+	}
 	election.Status = Draft
 	if election.StartTime.After(election.EndTime) || election.StartTime.Equal(election.EndTime) {
 		service.log.Warn("Election start time must be before end time")
 		return errors.New("Election start time must be before end time")
 	}
-	err := service.repo.Save(ctx, election)
+	err = ValidateMethod(election)
+	if err == nil {
+		err = validateEligibility(election)
+	}
+	if err != nil {
+		service.log.Warn(err.Error(), zap.String("request_id", requestId))
+		return err
+	}
+	err = service.repo.Save(ctx, election)
 	if err != nil {
 		service.log.Error(err.Error())
 		service.log.Error("Could not create election", zap.String("request_id", requestId))
@@ -35,6 +82,48 @@ func (service *ElectionService) CreateElection(ctx context.Context, election *El
 	return nil
 }
 
+// ValidateMethod fills in the default voting options and checks that the
+// voting method supports them.
+func ValidateMethod(election *Election) error {
+	if election.Method == "" {
+		election.Method = Plurality
+	}
+	if !election.Method.IsValid() {
+		return fmt.Errorf("%w: %s", ErrInvalidMethod, election.Method)
+	}
+	if election.Seats == 0 {
+		election.Seats = 1
+	}
+	if election.Seats < 1 || (election.Seats > 1 && !election.Method.IsMultiWinner()) {
+		return fmt.Errorf("%w: %d seats for %s", ErrInvalidSeats, election.Seats, election.Method)
+	}
+	if election.Method == SingleTransferableVote && election.SurplusTransfer == "" {
+		election.SurplusTransfer = GregoryTransfer
+	}
+	switch {
+	case election.Method == SingleTransferableVote && (election.SurplusTransfer == GregoryTransfer || election.SurplusTransfer == MeekTransfer):
+	case election.Method != SingleTransferableVote && election.SurplusTransfer == "":
+	default:
+		return fmt.Errorf("%w: %s for %s", ErrInvalidSurplusTransfer, election.SurplusTransfer, election.Method)
+	}
+	if election.Threshold < 0 || election.Threshold >= 100 || (election.Threshold > 0 && !election.Method.IsPartyList()) {
+		return fmt.Errorf("%w: %v%% for %s", ErrInvalidThreshold, election.Threshold, election.Method)
+	}
+	if (election.Method == Quadratic) != (election.Credits > 0) || election.Credits < 0 {
+		return fmt.Errorf("%w: %d credits for %s", ErrInvalidCredits, election.Credits, election.Method)
+	}
+	return nil
+}
+
+// validateEligibility checks that the eligibility rule, if any, parses.
+func validateEligibility(election *Election) error {
+	if election.Eligibility == "" {
+		return nil
+	}
+	_, err := eligibility.Parse(election.Eligibility)
+	return err
+}
+
 func (service *ElectionService) GetElections(ctx context.Context, params ElectionQueryParams) ([]Election, error) {
 	requestId, _ := ctx.Value("requestId").(string)
 	elections, err := service.repo.GetAllWithFilters(ctx, params)
@@ -59,8 +148,12 @@ func (service *ElectionService) GetElection(ctx context.Context, id string) (*El
 	return election, nil
 }
 
-func (service *ElectionService) UpdateElection(ctx context.Context, id string, updatedElection *Election) error {
+func (service *ElectionService) UpdateElection(ctx context.Context, id string, update *ElectionUpdate) error {
 	requestId, _ := ctx.Value("requestId").(string)
+	err := service.authorize(ctx)
+	if err != nil {
+		return err
+	}
 	election, err := service.repo.GetById(ctx, id)
 	if err != nil {
 		service.log.Error(err.Error())
@@ -72,7 +165,21 @@ func (service *ElectionService) UpdateElection(ctx context.Context, id string, u
 		service.log.Warn("Cannot update active or closed election: " + id, zap.String("request_id", requestId))
 		return errors.New("Cannot update active or closed elections")
 	}
-	err = service.repo.UpdateOne(ctx, id, updatedElection)
+	// Status only changes through TransitionElection.
+	updatedElection := update.Apply(*election)
+	if !updatedElection.StartTime.Before(updatedElection.EndTime) {
+		service.log.Warn("Election start time must be before end time", zap.String("request_id", requestId))
+		return errors.New("Election start time must be before end time")
+	}
+	err = ValidateMethod(&updatedElection)
+	if err == nil {
+		err = validateEligibility(&updatedElection)
+	}
+	if err != nil {
+		service.log.Warn(err.Error(), zap.String("request_id", requestId))
+		return err
+	}
+	err = service.repo.UpdateOne(ctx, id, &updatedElection)
 	if err != nil {
 		service.log.Error(err.Error())
 		service.log.Error("Could not update election: " + id, zap.String("request_id", requestId))
@@ -80,4 +187,57 @@ func (service *ElectionService) UpdateElection(ctx context.Context, id string, u
 	}
 	service.log.Info("Updated election: " + id, zap.String("request_id", requestId))
 	return nil
+}
+
+func (service *ElectionService) TransitionElection(ctx context.Context, id string, target ElectionStatus) error {
+	err := service.authorize(ctx)
+	if err != nil {
+		return err
+	}
+	return service.moveElection(ctx, id, target)
+}
+
+// moveElection changes the status of an election without checking who asked,
+// for the scheduler.
+func (service *ElectionService) moveElection(ctx context.Context, id string, target ElectionStatus) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	election, err := service.repo.GetById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("Election with id: " + id + " does not exist", zap.String("request_id", requestId))
+		return ErrElectionNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get election: " + id, zap.String("request_id", requestId))
+		return errors.New("Could not change status of election: " + id)
+	}
+	legal := election.Status.CanTransitionTo(target)
+	if legal && election.Status == Archived {
+		// The archive does not remember whether it was certified first.
+		certified, err := service.repo.IsCertified(ctx, id)
+		if err != nil {
+			service.log.Error(err.Error())
+			service.log.Error("Could not check certification of election: " + id, zap.String("request_id", requestId))
+			return errors.New("Could not change status of election: " + id)
+		}
+		legal = !certified
+	}
+	if !legal {
+		transitionErr := &TransitionError{From: election.Status, To: target}
+		service.log.Warn(transitionErr.Error() + ": " + id, zap.String("request_id", requestId))
+		return transitionErr
+	}
+	updated, err := service.repo.UpdateStatus(ctx, id, election.Status, target)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not change status of election: " + id, zap.String("request_id", requestId))
+		return errors.New("Could not change status of election: " + id)
+	}
+	if !updated {
+		service.log.Warn("Status of election: " + id + " changed concurrently", zap.String("request_id", requestId))
+		return ErrStatusChanged
+	}
+	service.publisher.PublishStatus(ctx, id, target)
+	service.log.Info(fmt.Sprintf("Moved election: %s from %s to %s", id, election.Status, target), zap.String("request_id", requestId))
+	return nil
 }
\ No newline at end of file
@@ -2,16 +2,57 @@ package election_test
 
 import (
 	"context"
+	"errors"
 	"slices"
 	"testing"
 	"time"
 
 	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/user"
 	"geraldaddo.com/live-voting-system/mocks"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
 )
 
+// adminContext is a request made by an admin, who may manage elections.
+func adminContext() context.Context {
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	return context.WithValue(ctx, user.ContextKey, &user.User{ID: "test-admin", Role: user.Admin, Active: true})
+}
+
+func TestElectionManagementShouldRequireAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	base := context.WithValue(context.Background(), "requestId", "test-request-id")
+	tests := []struct {
+		name string
+		ctx context.Context
+	}{
+		{"Not signed in", base},
+		{"Base user", context.WithValue(base, user.ContextKey, &user.User{ID: "test-user", Role: user.Base, Active: true})},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The repository and publisher expect no calls.
+			service := election.NewElectionService(mocks.NewMockElectionRepository(ctrl), mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+			now := time.Now()
+			e := &election.Election{Title: "test-election", StartTime: now.Add(time.Hour), EndTime: now.Add(2 * time.Hour)}
+			errs := []error{
+				service.CreateElection(test.ctx, e),
+				service.UpdateElection(test.ctx, "test-election-id", &election.ElectionUpdate{Title: &e.Title}),
+				service.TransitionElection(test.ctx, "test-election-id", election.Active),
+			}
+			for _, err := range errs {
+				if !errors.Is(err, election.ErrForbidden) {
+					t.Errorf("Expected error: %v but got %v", election.ErrForbidden, err)
+				}
+			}
+		})
+	}
+}
+
 func TestCreateElection(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -31,8 +72,8 @@ func TestCreateElection(t *testing.T) {
 		Save(gomock.Any(), input).
 		Return(nil).
 		Times(1)
-	service := election.NewElectionService(mockElectionRepository, zap.NewNop())
-	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	service := election.NewElectionService(mockElectionRepository, mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+	ctx := adminContext()
 	err := service.CreateElection(ctx, input)
 
 	if err != nil {
@@ -40,6 +81,140 @@ func TestCreateElection(t *testing.T) {
 	}
 }
 
+func TestCreateElectionShouldValidateEligibilityRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	tests := []struct {
+		name string
+		rule string
+		err error
+	}{
+		{"No rule", "", nil},
+		{"Valid rule", `"staff" in groups and account_age >= 90d`, nil},
+		{"Unknown attribute", `department == "sales"`, election.ErrInvalidEligibility},
+		{"Incomplete rule", `role ==`, election.ErrInvalidEligibility},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			input := &election.Election{StartTime: now, EndTime: now.Add(time.Hour), Eligibility: test.rule}
+			if test.err == nil {
+				mockElectionRepository.
+					EXPECT().
+					Save(gomock.Any(), input).
+					Return(nil).
+					Times(1)
+			}
+			service := election.NewElectionService(mockElectionRepository, mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+			ctx := adminContext()
+			err := service.CreateElection(ctx, input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error: %v but got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestCreateElectionVotingMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	tests := []struct {
+		name string
+		method election.VotingMethod
+		expected election.VotingMethod
+		err error
+	}{
+		{"Defaults to plurality", "", election.Plurality, nil},
+		{"Instant runoff", election.InstantRunoff, election.InstantRunoff, nil},
+		{"Unsupported method", "coin-toss", "coin-toss", election.ErrInvalidMethod},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			input := &election.Election{StartTime: now, EndTime: now.Add(time.Hour), Method: test.method}
+			if test.err == nil {
+				mockElectionRepository.
+					EXPECT().
+					Save(gomock.Any(), input).
+					Return(nil).
+					Times(1)
+			}
+			service := election.NewElectionService(mockElectionRepository, mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+			ctx := adminContext()
+			err := service.CreateElection(ctx, input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error: %v but got %v", test.err, err)
+			}
+			if input.Method != test.expected {
+				t.Errorf("Expected method: %s but got %s", test.expected, input.Method)
+			}
+		})
+	}
+}
+
+func TestCreateElectionVotingOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	tests := []struct {
+		name string
+		input election.Election
+		seats int
+		transfer election.SurplusTransfer
+		err error
+	}{
+		{"Defaults to one seat", election.Election{}, 1, "", nil},
+		{"Single transferable vote defaults to Gregory", election.Election{Method: election.SingleTransferableVote, Seats: 3}, 3, election.GregoryTransfer, nil},
+		{"Meek transfer", election.Election{Method: election.SingleTransferableVote, Seats: 2, SurplusTransfer: election.MeekTransfer}, 2, election.MeekTransfer, nil},
+		{"Negative seats", election.Election{Method: election.SingleTransferableVote, Seats: -1}, -1, "", election.ErrInvalidSeats},
+		{"Several seats for a single winner method", election.Election{Method: election.Plurality, Seats: 2}, 2, "", election.ErrInvalidSeats},
+		{"Unknown surplus transfer", election.Election{Method: election.SingleTransferableVote, SurplusTransfer: "random"}, 1, "random", election.ErrInvalidSurplusTransfer},
+		{"Surplus transfer for a single winner method", election.Election{Method: election.InstantRunoff, SurplusTransfer: election.GregoryTransfer}, 1, election.GregoryTransfer, election.ErrInvalidSurplusTransfer},
+		{"Party list with threshold", election.Election{Method: election.DHondt, Seats: 10, Threshold: 5}, 10, "", nil},
+		{"Threshold of a hundred percent", election.Election{Method: election.Hare, Seats: 10, Threshold: 100}, 10, "", election.ErrInvalidThreshold},
+		{"Threshold for a candidate method", election.Election{Method: election.Plurality, Threshold: 5}, 1, "", election.ErrInvalidThreshold},
+		{"Cumulative with several seats", election.Election{Method: election.Cumulative, Seats: 3}, 3, "", nil},
+		{"Quadratic with credits", election.Election{Method: election.Quadratic, Credits: 100}, 1, "", nil},
+		{"Quadratic without credits", election.Election{Method: election.Quadratic}, 1, "", election.ErrInvalidCredits},
+		{"Credits for a method without a budget", election.Election{Method: election.Score, Credits: 100}, 1, "", election.ErrInvalidCredits},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			input := test.input
+			input.StartTime = now
+			input.EndTime = now.Add(time.Hour)
+			if test.err == nil {
+				mockElectionRepository.
+					EXPECT().
+					Save(gomock.Any(), &input).
+					Return(nil).
+					Times(1)
+			}
+			service := election.NewElectionService(mockElectionRepository, mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+			ctx := adminContext()
+			err := service.CreateElection(ctx, &input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error: %v but got %v", test.err, err)
+			}
+			if input.Seats != test.seats {
+				t.Errorf("Expected seats: %d but got %d", test.seats, input.Seats)
+			}
+			if input.SurplusTransfer != test.transfer {
+				t.Errorf("Expected surplus transfer: %s but got %s", test.transfer, input.SurplusTransfer)
+			}
+		})
+	}
+}
+
 func TestCreateElectionShouldFailIfStartTimeIsNotBeforeEndTime(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -58,8 +233,8 @@ func TestCreateElectionShouldFailIfStartTimeIsNotBeforeEndTime(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
 			input := &election.Election{StartTime: test.startTime, EndTime: test.endTime}
-			service := election.NewElectionService(mockElectionRepository, zap.NewNop())
-			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			service := election.NewElectionService(mockElectionRepository, mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+			ctx := adminContext()
 			err := service.CreateElection(ctx, input)
 			if err == nil {
 				t.Fatal("Should create election where start date is after end date")
@@ -89,8 +264,8 @@ func TestGetElections(t *testing.T) {
 		Return(elections, nil).
 		Times(1)
 
-	service := election.NewElectionService(mockElectionRepository, zap.NewNop())
-	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	service := election.NewElectionService(mockElectionRepository, mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+	ctx := adminContext()
 	result, err := service.GetElections(ctx, queryParams)
 
 	if err != nil {
@@ -115,8 +290,8 @@ func TestGetElection(t *testing.T) {
 		Return(expected, nil).
 		Times(1)
 
-	service := election.NewElectionService(mockElectionRepository, zap.NewNop())
-	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	service := election.NewElectionService(mockElectionRepository, mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+	ctx := adminContext()
 	result, err := service.GetElection(ctx, electionId)
 
 	if err != nil {
@@ -141,7 +316,7 @@ func TestUpdateElection(t *testing.T) {
 		EndTime: now.Add(3 * time.Hour),
 		Status: election.Draft,
 	}
-	updatedElection := &election.Election{ID: electionId, Title: "Updated"}
+	title := "Updated"
 
 	mockElectionRepository.
 		EXPECT().
@@ -150,19 +325,86 @@ func TestUpdateElection(t *testing.T) {
 		Times(1)
 	mockElectionRepository.
 		EXPECT().
-		UpdateOne(gomock.Any(), electionId, updatedElection).
-		Return(nil).
+		UpdateOne(gomock.Any(), electionId, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id string, updatedElection *election.Election) error {
+			if updatedElection.Title != title || !updatedElection.StartTime.Equal(existingElection.StartTime) {
+				t.Errorf("Expected title to change and start time to stay but got %+v", updatedElection)
+			}
+			return nil
+		}).
 		Times(1)
 	
-	service := election.NewElectionService(mockElectionRepository, zap.NewNop())
-	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
-	err := service.UpdateElection(ctx, electionId, updatedElection)
+	service := election.NewElectionService(mockElectionRepository, mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+	ctx := adminContext()
+	err := service.UpdateElection(ctx, electionId, &election.ElectionUpdate{Title: &title})
 
 	if err != nil {
 		t.Error("Could not update election", err.Error())
 	}
 }
 
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func TestUpdateElectionShouldOnlyChangeFieldsSent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	existingElection := election.Election{
+		ID: "test-election-id",
+		StartTime: now.Add(2 * time.Hour),
+		EndTime: now.Add(3 * time.Hour),
+		Status: election.Draft,
+		Method: election.Hare,
+		Seats: 5,
+		Threshold: 5,
+		Eligibility: `role == "admin"`,
+	}
+
+	tests := []struct {
+		name string
+		update election.ElectionUpdate
+		threshold float64
+		eligibility string
+	}{
+		{"Missing fields are kept", election.ElectionUpdate{Title: ptr("Updated")}, 5, `role == "admin"`},
+		{"Eligibility rule is cleared", election.ElectionUpdate{Eligibility: ptr("")}, 5, ""},
+		{"Threshold is reset", election.ElectionUpdate{Threshold: ptr(0.0)}, 0, `role == "admin"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			existing := existingElection
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&existing, nil).
+				Times(1)
+			mockElectionRepository.
+				EXPECT().
+				UpdateOne(gomock.Any(), "test-election-id", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, id string, updatedElection *election.Election) error {
+					if updatedElection.Threshold != test.threshold || updatedElection.Eligibility != test.eligibility {
+						t.Errorf("Expected threshold: %v and eligibility: %q but got %v and %q", test.threshold, test.eligibility, updatedElection.Threshold, updatedElection.Eligibility)
+					}
+					if updatedElection.Seats != 5 {
+						t.Errorf("Expected seats: 5 but got %d", updatedElection.Seats)
+					}
+					return nil
+				}).
+				Times(1)
+			service := election.NewElectionService(mockElectionRepository, mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+			err := service.UpdateElection(adminContext(), "test-election-id", &test.update)
+			if err != nil {
+				t.Errorf("Expected no error but got %v", err)
+			}
+		})
+	}
+}
+
 func TestUpdateElectionShouldNotUpdateActiveOrPastElection(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -187,17 +429,225 @@ func TestUpdateElectionShouldNotUpdateActiveOrPastElection(t *testing.T) {
 				EndTime: test.endTime,
 			}
 			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
-			service := election.NewElectionService(mockElectionRepository, zap.NewNop())
-			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			service := election.NewElectionService(mockElectionRepository, mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+			ctx := adminContext()
 			mockElectionRepository.
 				EXPECT().
 				GetById(gomock.Any(), gomock.Any()).
 				Return(existingElection, nil).
 				Times(1)
-			err := service.UpdateElection(ctx, "test-id", &election.Election{})
+			err := service.UpdateElection(ctx, "test-id", &election.ElectionUpdate{})
 			if err == nil {
 				t.Error("Attempted to update an active or closed election")
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestUpdateElectionShouldNotChangeStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+
+	electionId := "test-election-id"
+	now := time.Now()
+	existingElection := &election.Election{
+		ID: electionId,
+		StartTime: now.Add(2 * time.Hour),
+		EndTime: now.Add(3 * time.Hour),
+		Status: election.Draft,
+	}
+	var updatedElection *election.Election
+
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), electionId).
+		Return(existingElection, nil).
+		Times(1)
+	mockElectionRepository.
+		EXPECT().
+		UpdateOne(gomock.Any(), electionId, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id string, updated *election.Election) error {
+			updatedElection = updated
+			return nil
+		}).
+		Times(1)
+
+	service := election.NewElectionService(mockElectionRepository, mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+	ctx := adminContext()
+	err := service.UpdateElection(ctx, electionId, &election.ElectionUpdate{Title: ptr("Updated")})
+
+	if err != nil {
+		t.Error("Could not update election", err.Error())
+	}
+	if updatedElection == nil || updatedElection.Status != election.Draft {
+		t.Errorf("Expected status to stay %s but got %v", election.Draft, updatedElection)
+	}
+}
+
+func TestElectionStatusTransitions(t *testing.T) {
+	statuses := []election.ElectionStatus{election.Draft, election.Active, election.Closed, election.Certified, election.Archived}
+	allowed := map[election.ElectionStatus][]election.ElectionStatus{
+		election.Draft: {election.Active},
+		election.Active: {election.Closed},
+		election.Closed: {election.Active, election.Certified, election.Archived},
+		election.Certified: {election.Archived},
+		election.Archived: {election.Closed},
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			expected := slices.Contains(allowed[from], to)
+			if from.CanTransitionTo(to) != expected {
+				t.Errorf("Expected transition from %s to %s allowed: %t", from, to, expected)
+			}
+		}
+	}
+}
+
+func TestTransitionElection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockStatusPublisher := mocks.NewMockStatusPublisher(ctrl)
+
+	electionId := "test-election-id"
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), electionId).
+		Return(&election.Election{ID: electionId, Status: election.Draft}, nil).
+		Times(1)
+	mockElectionRepository.
+		EXPECT().
+		UpdateStatus(gomock.Any(), electionId, election.Draft, election.Active).
+		Return(true, nil).
+		Times(1)
+	mockStatusPublisher.
+		EXPECT().
+		PublishStatus(gomock.Any(), electionId, election.Active).
+		Times(1)
+
+	service := election.NewElectionService(mockElectionRepository, mockStatusPublisher, zap.NewNop())
+	ctx := adminContext()
+	err := service.TransitionElection(ctx, electionId, election.Active)
+
+	if err != nil {
+		t.Error("Could not transition election", err.Error())
+	}
+}
+
+func TestTransitionElectionShouldRejectIllegalTransitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		from election.ElectionStatus
+		to election.ElectionStatus
+	}{
+		{"Draft to archived", election.Draft, election.Archived},
+		{"Draft to closed", election.Draft, election.Closed},
+		{"Active to archived", election.Active, election.Archived},
+		{"Archived to active", election.Archived, election.Active},
+		{"Certified to active", election.Certified, election.Active},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), gomock.Any()).
+				Return(&election.Election{Status: test.from}, nil).
+				Times(1)
+			service := election.NewElectionService(mockElectionRepository, mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+			ctx := adminContext()
+			err := service.TransitionElection(ctx, "test-id", test.to)
+
+			var transitionErr *election.TransitionError
+			if !errors.As(err, &transitionErr) || !errors.Is(err, election.ErrIllegalTransition) {
+				t.Fatalf("Expected an illegal transition error but got %v", err)
+			}
+			if transitionErr.From != test.from || transitionErr.To != test.to {
+				t.Errorf("Expected transition error from %s to %s but got %s to %s", test.from, test.to, transitionErr.From, transitionErr.To)
+			}
+		})
+	}
+}
+
+func TestTransitionElectionShouldOnlyRestoreUncertifiedArchives(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		certified bool
+	}{
+		{"Never certified", false},
+		{"Certified before archiving", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			electionId := "test-election-id"
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockStatusPublisher := mocks.NewMockStatusPublisher(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), electionId).
+				Return(&election.Election{ID: electionId, Status: election.Archived}, nil).
+				Times(1)
+			mockElectionRepository.
+				EXPECT().
+				IsCertified(gomock.Any(), electionId).
+				Return(test.certified, nil).
+				Times(1)
+			if !test.certified {
+				mockElectionRepository.
+					EXPECT().
+					UpdateStatus(gomock.Any(), electionId, election.Archived, election.Closed).
+					Return(true, nil).
+					Times(1)
+				mockStatusPublisher.
+					EXPECT().
+					PublishStatus(gomock.Any(), electionId, election.Closed).
+					Times(1)
+			}
+			service := election.NewElectionService(mockElectionRepository, mockStatusPublisher, zap.NewNop())
+			ctx := adminContext()
+			err := service.TransitionElection(ctx, electionId, election.Closed)
+
+			if test.certified && !errors.Is(err, election.ErrIllegalTransition) {
+				t.Errorf("Expected an illegal transition error but got %v", err)
+			}
+			if !test.certified && err != nil {
+				t.Error("Could not restore archived election", err.Error())
+			}
+		})
+	}
+}
+
+func TestTransitionElectionShouldFailIfStatusChangedConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), gomock.Any()).
+		Return(&election.Election{Status: election.Active}, nil).
+		Times(1)
+	mockElectionRepository.
+		EXPECT().
+		UpdateStatus(gomock.Any(), gomock.Any(), election.Active, election.Closed).
+		Return(false, nil).
+		Times(1)
+
+	service := election.NewElectionService(mockElectionRepository, mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+	ctx := adminContext()
+	err := service.TransitionElection(ctx, "test-id", election.Closed)
+
+	if !errors.Is(err, election.ErrStatusChanged) {
+		t.Errorf("Expected error: %v but got %v", election.ErrStatusChanged, err)
+	}
+}
@@ -0,0 +1,48 @@
+package eligibility
+
+import (
+	"errors"
+	"net/http"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type EligibilityAPI struct {
+	service *EligibilityService
+	log *zap.Logger
+}
+
+func NewEligibilityAPI(service *EligibilityService, logger *zap.Logger) *EligibilityAPI {
+	return &EligibilityAPI{service: service, log: logger}
+}
+
+func (api *EligibilityAPI) RegisterRoutes(server *gin.Engine) {
+	server.POST("/eligibility/preview", user.Require(user.ManageElections), api.previewRule)
+}
+
+func (api *EligibilityAPI) previewRule(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var preview Preview
+	err := ctx.ShouldBindJSON(&preview)
+	if err != nil {
+		api.log.Error(err.Error())
+		api.log.Error("could not parse eligibility rule", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse eligibility rule"})
+		return
+	}
+	err = api.service.PreviewRule(ctx, &preview)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, preview)
+}
+
+func statusForError(err error) int {
+	if errors.Is(err, ErrInvalidRule) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,10 @@
+package eligibility
+
+// Preview reports how many users a rule matches. Eligible counts the
+// matching users whose accounts are active, since only they can vote.
+type Preview struct {
+	Rule string `binding:"required"`
+	Matches int
+	Eligible int
+	Total int
+}
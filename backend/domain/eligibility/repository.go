@@ -0,0 +1,53 @@
+package eligibility
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// EligibilityRepository counts the users a rule matches in the database
+// rather than loading every user to evaluate the rule.
+//
+//go:generate mockgen -destination=../../mocks/mock_eligibility_repo.go -package=mocks . EligibilityRepository
+type EligibilityRepository interface {
+	// CountEligible counts the active users on the electoral roll of the
+	// election or matching the rule at the given time.
+	CountEligible(ctx context.Context, electionId string, rule *Rule, now time.Time) (int, error)
+	// CountMatching fills in the preview with the users the rule matches at
+	// the given time, those of them who are active and all users.
+	CountMatching(ctx context.Context, rule *Rule, now time.Time, preview *Preview) error
+}
+
+type EligibilityRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewEligibilityRepository(db *sql.DB) *EligibilityRepositoryImpl {
+	return &EligibilityRepositoryImpl{db: db}
+}
+
+func (repo *EligibilityRepositoryImpl) CountEligible(ctx context.Context, electionId string, rule *Rule, now time.Time) (int, error) {
+	matches, args := rule.Where(now, []any{electionId})
+	query := `
+	SELECT COUNT(*)
+	FROM users
+	WHERE active AND (
+		EXISTS (SELECT 1 FROM electoral_rolls r WHERE r.election_id = $1 AND r.user_id = users.id)
+		OR ` + matches + `
+	)`
+	var count int
+	err := repo.db.QueryRow(query, args...).Scan(&count)
+	return count, err
+}
+
+func (repo *EligibilityRepositoryImpl) CountMatching(ctx context.Context, rule *Rule, now time.Time, preview *Preview) error {
+	matches, args := rule.Where(now, nil)
+	query := `
+	SELECT
+		COUNT(*) FILTER (WHERE ` + matches + `),
+		COUNT(*) FILTER (WHERE active AND ` + matches + `),
+		COUNT(*)
+	FROM users`
+	return repo.db.QueryRow(query, args...).Scan(&preview.Matches, &preview.Eligible, &preview.Total)
+}
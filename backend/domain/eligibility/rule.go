@@ -0,0 +1,392 @@
+package eligibility
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+)
+
+var ErrInvalidRule = errors.New("Eligibility rule is invalid")
+
+// Rule decides which users may vote in an election. Rules compare the
+// attributes of a user and combine the comparisons with and, or, not and
+// parentheses:
+//
+//	role == "admin"
+//	"board" in groups
+//	account_age >= 30d
+//	email_domain == "example.com"
+//	active
+//
+// Account ages are written in hours (h), days (d), weeks (w) or years (y).
+// Email domains are compared without regard to case.
+//
+// Email addresses are not verified and anyone may register with any address,
+// so email_domain does not prove a voter belongs to the domain. While
+// registration at /auth/register is open, combine it with the electoral roll
+// or with groups an admin assigns.
+type Rule struct {
+	source string
+	root node
+}
+
+// Parse checks the rule and prepares it for evaluation.
+func Parse(source string) (*Rule, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: rule is empty", ErrInvalidRule)
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, p.unexpected()
+	}
+	return &Rule{source: source, root: root}, nil
+}
+
+func (rule *Rule) String() string {
+	return rule.source
+}
+
+// Matches reports whether the user satisfies the rule at the given time.
+func (rule *Rule) Matches(u *user.User, now time.Time) bool {
+	return rule.root.eval(u, now)
+}
+
+type node interface {
+	eval(u *user.User, now time.Time) bool
+	where(now time.Time, args *[]any) string
+}
+
+type and struct{ left, right node }
+type or struct{ left, right node }
+type not struct{ operand node }
+
+func (n and) eval(u *user.User, now time.Time) bool { return n.left.eval(u, now) && n.right.eval(u, now) }
+func (n or) eval(u *user.User, now time.Time) bool { return n.left.eval(u, now) || n.right.eval(u, now) }
+func (n not) eval(u *user.User, now time.Time) bool { return !n.operand.eval(u, now) }
+
+type inGroup struct{ group string }
+
+func (n inGroup) eval(u *user.User, now time.Time) bool {
+	return slices.Contains(u.Groups, n.group)
+}
+
+type compareText struct {
+	field string
+	equal bool
+	value string
+}
+
+func (n compareText) eval(u *user.User, now time.Time) bool {
+	var actual string
+	switch n.field {
+	case "role":
+		actual = string(u.Role)
+	case "email_domain":
+		actual = emailDomain(u.Email)
+	}
+	return strings.EqualFold(actual, n.value) == n.equal
+}
+
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return email[at + 1:]
+}
+
+type compareBool struct {
+	equal bool
+	value bool
+}
+
+func (n compareBool) eval(u *user.User, now time.Time) bool {
+	return (u.Active == n.value) == n.equal
+}
+
+type compareAge struct {
+	op string
+	age time.Duration
+}
+
+func (n compareAge) eval(u *user.User, now time.Time) bool {
+	age := now.Sub(u.CreatedAt)
+	switch n.op {
+	case "<":
+		return age < n.age
+	case "<=":
+		return age <= n.age
+	case ">":
+		return age > n.age
+	case ">=":
+		return age >= n.age
+	case "==":
+		return age == n.age
+	}
+	return age != n.age
+}
+
+type tokenKind int
+
+const (
+	identifier tokenKind = iota
+	text
+	duration
+	operator
+	openParen
+	closeParen
+)
+
+type token struct {
+	kind tokenKind
+	value string
+	position int
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!"}
+
+func lex(source string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(source); {
+		c := rune(source[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, token{openParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{closeParen, ")", i})
+			i++
+		case c == '"':
+			end := strings.IndexByte(source[i + 1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated string at position %d", ErrInvalidRule, i)
+			}
+			tokens = append(tokens, token{text, source[i + 1 : i + 1 + end], i})
+			i += end + 2
+		case unicode.IsDigit(c):
+			start := i
+			for i < len(source) && (unicode.IsDigit(rune(source[i])) || unicode.IsLetter(rune(source[i]))) {
+				i++
+			}
+			tokens = append(tokens, token{duration, source[start:i], start})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(source) && (unicode.IsLetter(rune(source[i])) || unicode.IsDigit(rune(source[i])) || source[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{identifier, source[start:i], start})
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(source[i:], op) {
+					tokens = append(tokens, token{operator, op, i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrInvalidRule, c, i)
+			}
+		}
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	next int
+}
+
+func (p *parser) done() bool {
+	return p.next >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+// accept consumes the next token if it is one of the words or operators.
+func (p *parser) accept(values ...string) bool {
+	if p.done() {
+		return false
+	}
+	t := p.peek()
+	if (t.kind == identifier || t.kind == operator) && slices.Contains(values, t.value) {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func (p *parser) unexpected() error {
+	if p.done() {
+		return fmt.Errorf("%w: rule ends too early", ErrInvalidRule)
+	}
+	t := p.peek()
+	return fmt.Errorf("%w: unexpected %q at position %d", ErrInvalidRule, t.value, t.position)
+}
+
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("or", "||") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = or{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (node, error) {
+	left, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("and", "&&") {
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		left = and{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) not() (node, error) {
+	if p.accept("not", "!") {
+		operand, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return not{operand}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (node, error) {
+	if p.done() {
+		return nil, p.unexpected()
+	}
+	t := p.peek()
+	switch t.kind {
+	case openParen:
+		p.next++
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.done() || p.peek().kind != closeParen {
+			return nil, p.unexpected()
+		}
+		p.next++
+		return inner, nil
+	case text:
+		p.next++
+		if !p.accept("in") || !p.accept("groups") {
+			return nil, fmt.Errorf("%w: expected \"in groups\" after %q at position %d", ErrInvalidRule, t.value, t.position)
+		}
+		return inGroup{t.value}, nil
+	case identifier:
+		p.next++
+		return p.comparison(t)
+	}
+	return nil, p.unexpected()
+}
+
+func (p *parser) comparison(field token) (node, error) {
+	switch field.value {
+	case "active":
+		if !p.accept("==", "!=") {
+			return compareBool{equal: true, value: true}, nil
+		}
+		equal := p.tokens[p.next - 1].value == "=="
+		if p.accept("true") {
+			return compareBool{equal, true}, nil
+		}
+		if p.accept("false") {
+			return compareBool{equal, false}, nil
+		}
+		return nil, p.expected("true or false")
+	case "role", "email_domain":
+		if !p.accept("==", "!=") {
+			return nil, p.expected("== or !=")
+		}
+		equal := p.tokens[p.next - 1].value == "=="
+		if p.done() || p.peek().kind != text {
+			return nil, p.expected("a quoted string")
+		}
+		value := p.peek().value
+		if field.value == "role" && !user.UserRole(value).IsValid() {
+			return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidRule, value)
+		}
+		p.next++
+		return compareText{field.value, equal, value}, nil
+	case "account_age":
+		if !p.accept("<", "<=", ">", ">=", "==", "!=") {
+			return nil, p.expected("a comparison")
+		}
+		op := p.tokens[p.next - 1].value
+		if p.done() || p.peek().kind != duration {
+			return nil, p.expected("an age such as 30d")
+		}
+		age, err := parseAge(p.peek())
+		if err != nil {
+			return nil, err
+		}
+		p.next++
+		return compareAge{op, age}, nil
+	case "groups":
+		return nil, fmt.Errorf("%w: write group membership as \"name\" in groups", ErrInvalidRule)
+	}
+	return nil, fmt.Errorf("%w: unknown attribute %q at position %d", ErrInvalidRule, field.value, field.position)
+}
+
+func (p *parser) expected(what string) error {
+	if p.done() {
+		return fmt.Errorf("%w: expected %s at the end of the rule", ErrInvalidRule, what)
+	}
+	t := p.peek()
+	return fmt.Errorf("%w: expected %s at position %d but got %q", ErrInvalidRule, what, t.position, t.value)
+}
+
+var ageUnits = map[string]time.Duration{
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+	"y": 365 * 24 * time.Hour,
+}
+
+func parseAge(t token) (time.Duration, error) {
+	digits := strings.TrimRightFunc(t.value, unicode.IsLetter)
+	unit, ok := ageUnits[t.value[len(digits):]]
+	count, err := strconv.Atoi(digits)
+	if !ok || err != nil {
+		return 0, fmt.Errorf("%w: %q at position %d is not an age such as 30d", ErrInvalidRule, t.value, t.position)
+	}
+	if int64(count) > math.MaxInt64 / int64(unit) {
+		return 0, fmt.Errorf("%w: %q at position %d is too long an age", ErrInvalidRule, t.value, t.position)
+	}
+	return time.Duration(count) * unit, nil
+}
@@ -0,0 +1,95 @@
+package eligibility_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/eligibility"
+	"geraldaddo.com/live-voting-system/domain/user"
+)
+
+func TestRuleMatches(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	member := &user.User{
+		Email: "ada@Example.com",
+		Role: user.Base,
+		Active: true,
+		Groups: []string{"board", "finance"},
+		CreatedAt: now.Add(-45 * 24 * time.Hour),
+	}
+	newcomer := &user.User{
+		Email: "brian@other.org",
+		Role: user.Admin,
+		Active: false,
+		CreatedAt: now.Add(-2 * time.Hour),
+	}
+
+	tests := []struct {
+		rule string
+		member bool
+		newcomer bool
+	}{
+		{`role == "base"`, true, false},
+		{`role != "base"`, false, true},
+		{`"board" in groups`, true, false},
+		{`not "board" in groups`, false, true},
+		{`account_age >= 30d`, true, false},
+		{`account_age < 1d`, false, true},
+		{`account_age > 6w`, true, false},
+		{`email_domain == "example.com"`, true, false},
+		{`active`, true, false},
+		{`active == false`, false, true},
+		{`!active`, false, true},
+		{`role == "admin" or "finance" in groups`, true, true},
+		{`role == "admin" || "finance" in groups && active`, true, true},
+		{`(role == "admin" || "finance" in groups) && active`, true, false},
+		{`active and account_age >= 1y`, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.rule, func(t *testing.T) {
+			rule, err := eligibility.Parse(test.rule)
+			if err != nil {
+				t.Fatal("Could not parse rule", err.Error())
+			}
+			if rule.Matches(member, now) != test.member {
+				t.Errorf("Expected rule to match member: %t", test.member)
+			}
+			if rule.Matches(newcomer, now) != test.newcomer {
+				t.Errorf("Expected rule to match newcomer: %t", test.newcomer)
+			}
+		})
+	}
+}
+
+func TestParseShouldRejectInvalidRules(t *testing.T) {
+	rules := []string{
+		``,
+		`role == "owner"`,
+		`role > "admin"`,
+		`role == admin`,
+		`department == "sales"`,
+		`groups == "board"`,
+		`"board" in`,
+		`account_age >= 30`,
+		`account_age >= 30m`,
+		`account_age >= 300y`,
+		`account_age >= 9223372036854775807h`,
+		`email_domain == "example.com`,
+		`active and`,
+		`(active`,
+		`active)`,
+		`active == maybe`,
+		`role == "admin" @ active`,
+	}
+
+	for _, source := range rules {
+		t.Run(source, func(t *testing.T) {
+			_, err := eligibility.Parse(source)
+			if !errors.Is(err, eligibility.ErrInvalidRule) {
+				t.Errorf("Expected error: %v but got %v", eligibility.ErrInvalidRule, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package eligibility
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type EligibilityService struct {
+	repo EligibilityRepository
+	log *zap.Logger
+}
+
+func NewEligibilityService(repo EligibilityRepository, logger *zap.Logger) *EligibilityService {
+	return &EligibilityService{repo: repo, log: logger}
+}
+
+// PreviewRule counts the users the rule matches right now.
+func (service *EligibilityService) PreviewRule(ctx context.Context, preview *Preview) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	rule, err := Parse(preview.Rule)
+	if err != nil {
+		service.log.Warn(err.Error(), zap.String("request_id", requestId))
+		return err
+	}
+	err = service.repo.CountMatching(ctx, rule, time.Now(), preview)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not count users matching eligibility rule", zap.String("request_id", requestId))
+		return errors.New("Could not preview eligibility rule")
+	}
+	service.log.Info(fmt.Sprintf("Eligibility rule matches %d of %d users", preview.Matches, preview.Total), zap.String("request_id", requestId))
+	return nil
+}
@@ -0,0 +1,59 @@
+package eligibility_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/eligibility"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/mocks"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestPreviewRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockEligibilityRepository := mocks.NewMockEligibilityRepository(ctrl)
+	mockEligibilityRepository.
+		EXPECT().
+		CountMatching(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, rule *eligibility.Rule, now time.Time, preview *eligibility.Preview) error {
+			veteran := &user.User{Email: "ada@example.com", CreatedAt: now.Add(-100 * 24 * time.Hour)}
+			newcomer := &user.User{Email: "carol@example.com", CreatedAt: now}
+			if !rule.Matches(veteran, now) || rule.Matches(newcomer, now) {
+				t.Errorf("Expected the rule of the preview but got %+v", rule)
+			}
+			preview.Matches, preview.Eligible, preview.Total = 2, 1, 4
+			return nil
+		}).
+		Times(1)
+
+	service := eligibility.NewEligibilityService(mockEligibilityRepository, zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	preview := &eligibility.Preview{Rule: `email_domain == "example.com" and account_age >= 30d`}
+	err := service.PreviewRule(ctx, preview)
+
+	if err != nil {
+		t.Fatal("Could not preview rule", err.Error())
+	}
+	if preview.Matches != 2 || preview.Eligible != 1 || preview.Total != 4 {
+		t.Errorf("Expected %d matches, %d eligible of %d users but got %d, %d of %d",
+			2, 1, 4, preview.Matches, preview.Eligible, preview.Total)
+	}
+}
+
+func TestPreviewRuleShouldRejectInvalidRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := eligibility.NewEligibilityService(mocks.NewMockEligibilityRepository(ctrl), zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	err := service.PreviewRule(ctx, &eligibility.Preview{Rule: `role == "owner"`})
+
+	if !errors.Is(err, eligibility.ErrInvalidRule) {
+		t.Errorf("Expected error: %v but got %v", eligibility.ErrInvalidRule, err)
+	}
+}
@@ -0,0 +1,76 @@
+package eligibility
+
+import (
+	"fmt"
+	"time"
+)
+
+// Where translates the rule into a condition on the columns of the users
+// table, so the users it matches can be counted without loading them. The
+// values of the condition are appended to args and numbered after those
+// already in it.
+func (rule *Rule) Where(now time.Time, args []any) (string, []any) {
+	condition := rule.root.where(now, &args)
+	return condition, args
+}
+
+// param adds a value to the arguments of a query and returns its placeholder.
+func param(args *[]any, value any) string {
+	*args = append(*args, value)
+	return fmt.Sprintf("$%d", len(*args))
+}
+
+func (n and) where(now time.Time, args *[]any) string {
+	return "(" + n.left.where(now, args) + " AND " + n.right.where(now, args) + ")"
+}
+
+func (n or) where(now time.Time, args *[]any) string {
+	return "(" + n.left.where(now, args) + " OR " + n.right.where(now, args) + ")"
+}
+
+func (n not) where(now time.Time, args *[]any) string {
+	return "NOT " + n.operand.where(now, args)
+}
+
+func (n inGroup) where(now time.Time, args *[]any) string {
+	return "(" + param(args, n.group) + " = ANY(groups))"
+}
+
+// emailDomainColumn is the part of the email after the last @, or empty like
+// emailDomain when there is none.
+const emailDomainColumn = `COALESCE(SUBSTRING(email FROM '@([^@]*)$'), '')`
+
+func (n compareText) where(now time.Time, args *[]any) string {
+	column := "role"
+	if n.field == "email_domain" {
+		column = emailDomainColumn
+	}
+	op := "="
+	if !n.equal {
+		op = "<>"
+	}
+	return "(LOWER(" + column + ") " + op + " LOWER(" + param(args, n.value) + "))"
+}
+
+func (n compareBool) where(now time.Time, args *[]any) string {
+	op := "="
+	if !n.equal {
+		op = "<>"
+	}
+	return "(active " + op + " " + param(args, n.value) + ")"
+}
+
+// ageOps compares the creation time of an account with the time it would have
+// been created at to be exactly the age; older accounts were created earlier.
+var ageOps = map[string]string{
+	"<": ">",
+	"<=": ">=",
+	">": "<",
+	">=": "<=",
+	"==": "=",
+	"!=": "<>",
+}
+
+func (n compareAge) where(now time.Time, args *[]any) string {
+	return "(created_at " + ageOps[n.op] + " " + param(args, now.Add(-n.age)) + ")"
+}
@@ -0,0 +1,53 @@
+package eligibility_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/eligibility"
+)
+
+func TestRuleWhere(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule string
+		where string
+		args []any
+	}{
+		{`"board" in groups`, `($2 = ANY(groups))`, []any{"board"}},
+		{`role != "admin"`, `(LOWER(role) <> LOWER($2))`, []any{"admin"}},
+		{
+			`email_domain == "example.com"`,
+			`(LOWER(COALESCE(SUBSTRING(email FROM '@([^@]*)$'), '')) = LOWER($2))`,
+			[]any{"example.com"},
+		},
+		{`active`, `(active = $2)`, []any{true}},
+		{`account_age >= 30d`, `(created_at <= $2)`, []any{now.Add(-30 * 24 * time.Hour)}},
+		{`account_age < 2h`, `(created_at > $2)`, []any{now.Add(-2 * time.Hour)}},
+		{
+			`not active or "board" in groups and role == "base"`,
+			`(NOT (active = $2) OR (($3 = ANY(groups)) AND (LOWER(role) = LOWER($4))))`,
+			[]any{true, "board", "base"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.rule, func(t *testing.T) {
+			rule, err := eligibility.Parse(test.rule)
+			if err != nil {
+				t.Fatal("Could not parse rule", err.Error())
+			}
+			where, args := rule.Where(now, []any{"test-election-id"})
+
+			if where != test.where {
+				t.Errorf("Expected condition: %s but got %s", test.where, where)
+			}
+			expected := append([]any{"test-election-id"}, test.args...)
+			if !slices.Equal(args, expected) {
+				t.Errorf("Expected arguments: %v but got %v", expected, args)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/domain/vote"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	throttleInterval = time.Second
+	heartbeatInterval = 15 * time.Second
+)
+
+type TallySource interface {
+	GetTally(ctx context.Context, electionId string) (*vote.Tally, error)
+}
+
+type LiveAPI struct {
+	hub *Hub
+	tallies TallySource
+	log *zap.Logger
+}
+
+func NewLiveAPI(hub *Hub, tallies TallySource, logger *zap.Logger) *LiveAPI {
+	return &LiveAPI{hub: hub, tallies: tallies, log: logger}
+}
+
+func (api *LiveAPI) RegisterRoutes(server *gin.Engine) {
+	server.GET("/elections/:id/results/stream", user.Require(user.ReadElections), api.streamResults)
+	server.GET("/elections/:id/live", user.Require(user.ReadElections), api.openSocket)
+	server.GET("/live", user.Require(user.ReadElections), api.openSocket)
+}
+
+// requestContext carries the request id and signed in user of a request over
+// to a context that, unlike gin.Context, is done once the client goes away.
+// Tallies are only shown to users who may see them, so every refresh needs
+// the user.
+func requestContext(ctx *gin.Context, parent context.Context) context.Context {
+	requestCtx := context.WithValue(parent, "requestId", ctx.GetString("requestId"))
+	if u, ok := user.FromContext(ctx); ok {
+		requestCtx = context.WithValue(requestCtx, user.ContextKey, u)
+	}
+	return requestCtx
+}
+
+// sink receives everything a watched subscription produces.
+type sink interface {
+	tally(tally *vote.Tally)
+	event(event Event)
+	heartbeat()
+}
+
+// watch feeds one subscription into a sink until ctx is done. Tally refreshes
+// are throttled to one per throttleInterval and skipped when the voter count,
+// which doubles as the event id, has not moved past lastSeen.
+func (api *LiveAPI) watch(ctx context.Context, subscription *Subscription, current *vote.Tally, lastSeen string, out sink) {
+	requestId, _ := ctx.Value("requestId").(string)
+	if eventId(current) != lastSeen {
+		out.tally(current)
+		lastSeen = eventId(current)
+	}
+	lastSent := time.Now()
+	refresh := func() {
+		tally, err := api.tallies.GetTally(ctx, subscription.ElectionId)
+		if err != nil {
+			api.log.Error(err.Error())
+			api.log.Error("Could not refresh tally for election: " + subscription.ElectionId, zap.String("request_id", requestId))
+			return
+		}
+		lastSent = time.Now()
+		if eventId(tally) == lastSeen {
+			return
+		}
+		out.tally(tally)
+		lastSeen = eventId(tally)
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	var throttled <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			out.heartbeat()
+		case event := <-subscription.Events:
+			out.event(event)
+		case <-subscription.Changed:
+			if throttled != nil {
+				continue
+			}
+			wait := throttleInterval - time.Since(lastSent)
+			if wait > 0 {
+				throttled = time.After(wait)
+				continue
+			}
+			refresh()
+		case <-throttled:
+			throttled = nil
+			refresh()
+		}
+	}
+}
+
+// streamResults pushes the candidate tally as Server-Sent Events. Votes are
+// never changed or removed and each ballot adds a voter, so the voter count
+// doubles as a monotonically increasing event id and a reconnecting client
+// that sends Last-Event-ID is only resent the tally when it has actually
+// changed.
+func (api *LiveAPI) streamResults(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	electionId := ctx.Param("id")
+
+	subscription := api.hub.Subscribe(electionId)
+	defer api.hub.Unsubscribe(subscription)
+
+	tally, err := api.tallies.GetTally(ctx, electionId)
+	if errors.Is(err, vote.ErrElectionNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+	if errors.Is(err, vote.ErrResultsHidden) {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Type", sse.ContentType)
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	api.log.Info("Opened result stream for election: " + electionId, zap.String("request_id", requestId))
+	// gin.Context is never done on its own, so watch on the request context.
+	watchCtx := requestContext(ctx, ctx.Request.Context())
+	api.watch(watchCtx, subscription, tally, ctx.GetHeader("Last-Event-ID"), eventStream{ctx})
+	api.log.Info("Closed result stream for election: " + electionId, zap.String("request_id", requestId))
+}
+
+type eventStream struct {
+	ctx *gin.Context
+}
+
+func (stream eventStream) tally(tally *vote.Tally) {
+	stream.ctx.Render(-1, sse.Event{Id: eventId(tally), Event: string(TallyEvent), Data: tally})
+	stream.ctx.Writer.Flush()
+}
+
+func (stream eventStream) event(event Event) {
+	stream.ctx.Render(-1, sse.Event{Event: string(event.Type), Data: event})
+	stream.ctx.Writer.Flush()
+}
+
+func (stream eventStream) heartbeat() {
+	_, _ = stream.ctx.Writer.WriteString(": heartbeat\n\n")
+	stream.ctx.Writer.Flush()
+}
+
+func (api *LiveAPI) openSocket(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	electionId := ctx.Param("id")
+	websocket.Handler(func(conn *websocket.Conn) {
+		api.log.Info("Opened live socket", zap.String("request_id", requestId))
+		socketCtx := requestContext(ctx, conn.Request().Context())
+		api.serveSocket(socketCtx, conn, electionId)
+		api.log.Info("Closed live socket", zap.String("request_id", requestId))
+	}).ServeHTTP(ctx.Writer, ctx.Request)
+}
+
+func eventId(tally *vote.Tally) string {
+	return strconv.Itoa(tally.TotalVotes)
+}
@@ -0,0 +1,275 @@
+package live_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/live"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/domain/vote"
+	"geraldaddo.com/live-voting-system/platform/pubsub"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type fakeTallySource struct {
+	mu sync.Mutex
+	tally *vote.Tally
+	// hidden embargoes the tally from everyone but admins.
+	hidden bool
+}
+
+func (source *fakeTallySource) GetTally(ctx context.Context, electionId string) (*vote.Tally, error) {
+	source.mu.Lock()
+	defer source.mu.Unlock()
+	if source.hidden && !user.IsAdmin(ctx) {
+		return nil, vote.ErrResultsHidden
+	}
+	if source.tally == nil || source.tally.ElectionId != electionId {
+		return nil, vote.ErrElectionNotFound
+	}
+	copied := *source.tally
+	return &copied, nil
+}
+
+func (source *fakeTallySource) castVote(candidateId string) {
+	source.mu.Lock()
+	defer source.mu.Unlock()
+	source.tally.TotalVotes++
+	for i := range source.tally.Candidates {
+		if source.tally.Candidates[i].CandidateId == candidateId {
+			source.tally.Candidates[i].Votes++
+		}
+	}
+}
+
+func SetupHub(t *testing.T) *live.Hub {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	hub := live.NewHub(pubsub.NewMemoryPubSub(), zap.NewNop())
+	err := hub.Start(ctx)
+	if err != nil {
+		t.Fatal("Could not start hub", err.Error())
+	}
+	return hub
+}
+
+func SetupServer(hub *live.Hub, source *fakeTallySource) *httptest.Server {
+	return SetupServerAs(hub, source, &user.User{ID: "test-user", Role: user.Base, Active: true})
+}
+
+// SetupServerAs serves the live API to requests signed in as viewer, or to
+// anonymous requests when viewer is nil.
+func SetupServerAs(hub *live.Hub, source *fakeTallySource, viewer *user.User) *httptest.Server {
+	server := gin.New()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("requestId", uuid.New().String())
+		if viewer != nil {
+			ctx.Set(user.ContextKey, viewer)
+		}
+	})
+	live.NewLiveAPI(hub, source, zap.NewNop()).RegisterRoutes(server)
+	return httptest.NewServer(server)
+}
+
+type streamEvent struct {
+	id string
+	event string
+	data string
+}
+
+// readEvents parses the stream on a single goroutine so that an event is never
+// lost to a read that timed out.
+func readEvents(reader *bufio.Reader) <-chan *streamEvent {
+	events := make(chan *streamEvent, 16)
+	go func() {
+		defer close(events)
+		event := &streamEvent{}
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\n")
+			switch {
+			case line == "" && event.event != "":
+				events <- event
+				event = &streamEvent{}
+			case strings.HasPrefix(line, "id:"):
+				event.id = strings.TrimPrefix(line, "id:")
+			case strings.HasPrefix(line, "event:"):
+				event.event = strings.TrimPrefix(line, "event:")
+			case strings.HasPrefix(line, "data:"):
+				event.data = strings.TrimPrefix(line, "data:")
+			}
+		}
+	}()
+	return events
+}
+
+func nextEvent(events <-chan *streamEvent, eventType string, timeout time.Duration) (*streamEvent, bool) {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil, false
+			}
+			if event.event == eventType {
+				return event, true
+			}
+		case <-deadline:
+			return nil, false
+		}
+	}
+}
+
+func openStream(t *testing.T, ctx context.Context, url string, lastEventId string) *http.Response {
+	t.Helper()
+	request, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if lastEventId != "" {
+		request.Header.Set("Last-Event-ID", lastEventId)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal("Could not open result stream", err.Error())
+	}
+	return response
+}
+
+func TestStreamResultsAPIShouldFailForMissingElection(t *testing.T) {
+	hub := SetupHub(t)
+	server := SetupServer(hub, &fakeTallySource{})
+	defer server.Close()
+
+	response := openStream(t, context.Background(), server.URL + "/elections/missing/results/stream", "")
+	defer response.Body.Close()
+
+	if response.StatusCode != 404 {
+		t.Errorf("Expected status code: %d but got %d", 404, response.StatusCode)
+	}
+}
+
+func TestStreamResultsAPIShouldRequireSignIn(t *testing.T) {
+	hub := SetupHub(t)
+	source := &fakeTallySource{tally: &vote.Tally{ElectionId: "test-election-id"}}
+	server := SetupServerAs(hub, source, nil)
+	defer server.Close()
+
+	response := openStream(t, context.Background(), server.URL + "/elections/test-election-id/results/stream", "")
+	defer response.Body.Close()
+
+	if response.StatusCode != 401 {
+		t.Errorf("Expected status code: %d but got %d", 401, response.StatusCode)
+	}
+}
+
+func TestStreamResultsAPIShouldHideUncertifiedResults(t *testing.T) {
+	hub := SetupHub(t)
+	source := &fakeTallySource{tally: &vote.Tally{ElectionId: "test-election-id"}, hidden: true}
+
+	tests := []struct {
+		name string
+		viewer *user.User
+		status int
+	}{
+		{"Voter", &user.User{ID: "test-user", Role: user.Base, Active: true}, 403},
+		{"Admin", &user.User{ID: "test-admin", Role: user.Admin, Active: true}, 200},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := SetupServerAs(hub, source, test.viewer)
+			defer server.Close()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			response := openStream(t, ctx, server.URL + "/elections/test-election-id/results/stream", "")
+			defer response.Body.Close()
+
+			if response.StatusCode != test.status {
+				t.Errorf("Expected status code: %d but got %d", test.status, response.StatusCode)
+			}
+		})
+	}
+}
+
+func TestStreamResultsAPI(t *testing.T) {
+	hub := SetupHub(t)
+	source := &fakeTallySource{tally: &vote.Tally{
+		ElectionId: "test-election-id",
+		TotalVotes: 2,
+		Candidates: []vote.CandidateTally{{CandidateId: "candidate-1", Votes: 2}},
+	}}
+	server := SetupServer(hub, source)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	response := openStream(t, ctx, server.URL + "/elections/test-election-id/results/stream", "")
+	defer response.Body.Close()
+	events := readEvents(bufio.NewReader(response.Body))
+
+	if !strings.HasPrefix(response.Header.Get("Content-Type"), "text/event-stream") {
+		t.Errorf("Expected an event stream but got %s", response.Header.Get("Content-Type"))
+	}
+	event, ok := nextEvent(events, "tally", time.Second)
+	if !ok {
+		t.Fatal("Did not receive the initial tally")
+	}
+	if event.event != "tally" || event.id != "2" {
+		t.Errorf("Expected tally event with id 2 but got %s event with id %s", event.event, event.id)
+	}
+
+	for range 5 {
+		source.castVote("candidate-1")
+		hub.PublishVote(ctx, &vote.Vote{ElectionId: "test-election-id"})
+	}
+	event, ok = nextEvent(events, "tally", 3 * time.Second)
+	if !ok {
+		t.Fatal("Did not receive an updated tally")
+	}
+	if event.id != "7" {
+		t.Errorf("Expected coalesced tally with id 7 but got %s", event.id)
+	}
+	if !strings.Contains(event.data, `"Votes":7`) {
+		t.Errorf("Expected updated vote count in %s", event.data)
+	}
+}
+
+func TestStreamResultsAPIShouldHonourLastEventId(t *testing.T) {
+	hub := SetupHub(t)
+	source := &fakeTallySource{tally: &vote.Tally{
+		ElectionId: "test-election-id",
+		TotalVotes: 4,
+		Candidates: []vote.CandidateTally{{CandidateId: "candidate-1", Votes: 4}},
+	}}
+	server := SetupServer(hub, source)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	response := openStream(t, ctx, server.URL + "/elections/test-election-id/results/stream", "4")
+	defer response.Body.Close()
+	events := readEvents(bufio.NewReader(response.Body))
+
+	if _, ok := nextEvent(events, "tally", 300 * time.Millisecond); ok {
+		t.Fatal("Resent a tally the client had already seen")
+	}
+	source.castVote("candidate-1")
+	hub.PublishVote(ctx, &vote.Vote{ElectionId: "test-election-id"})
+	event, ok := nextEvent(events, "tally", 3 * time.Second)
+	if !ok {
+		t.Fatal("Did not receive an updated tally")
+	}
+	if event.id != "5" {
+		t.Errorf("Expected tally with id 5 but got %s", event.id)
+	}
+}
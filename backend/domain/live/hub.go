@@ -0,0 +1,165 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/vote"
+	"geraldaddo.com/live-voting-system/platform/pubsub"
+	"go.uber.org/zap"
+)
+
+// eventTopic carries vote and status events between instances.
+const eventTopic = "live_events"
+
+type EventType string
+
+const (
+	TallyEvent EventType = "tally"
+	StatusEvent EventType = "status"
+	PresenceEvent EventType = "presence"
+)
+
+type Event struct {
+	Type EventType
+	ElectionId string
+	Status election.ElectionStatus `json:",omitempty"`
+	Viewers int `json:",omitempty"`
+}
+
+// Subscription receives a signal on Changed whenever a vote is cast in its
+// election. The channel holds at most one pending signal, so a burst of votes
+// collapses into a single refresh for slow readers. Status and presence
+// changes arrive on Events and are dropped rather than blocking the hub if
+// the subscriber falls too far behind.
+type Subscription struct {
+	ElectionId string
+	Changed chan struct{}
+	Events chan Event
+}
+
+// Hub tracks the live viewers connected to this instance. Vote and status
+// events are routed through pub/sub so viewers see changes made on any
+// instance, while presence counts only cover the viewers of this instance.
+type Hub struct {
+	mu sync.RWMutex
+	subscriptions map[string]map[*Subscription]struct{}
+	pubsub pubsub.PubSub
+	log *zap.Logger
+}
+
+func NewHub(ps pubsub.PubSub, logger *zap.Logger) *Hub {
+	return &Hub{
+		subscriptions: make(map[string]map[*Subscription]struct{}),
+		pubsub: ps,
+		log: logger,
+	}
+}
+
+// Start relays events from pub/sub to local subscriptions until ctx is done.
+func (hub *Hub) Start(ctx context.Context) error {
+	messages, err := hub.pubsub.Subscribe(ctx, eventTopic)
+	if err != nil {
+		return err
+	}
+	go func() {
+		for message := range messages {
+			var event Event
+			err := json.Unmarshal(message.Payload, &event)
+			if err != nil {
+				hub.log.Error(err.Error())
+				hub.log.Error("Could not decode live event")
+				continue
+			}
+			hub.dispatch(event)
+		}
+	}()
+	return nil
+}
+
+func (hub *Hub) Subscribe(electionId string) *Subscription {
+	subscription := &Subscription{
+		ElectionId: electionId,
+		Changed: make(chan struct{}, 1),
+		Events: make(chan Event, 16),
+	}
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if hub.subscriptions[electionId] == nil {
+		hub.subscriptions[electionId] = make(map[*Subscription]struct{})
+	}
+	hub.subscriptions[electionId][subscription] = struct{}{}
+	hub.broadcastPresence(electionId)
+	return subscription
+}
+
+func (hub *Hub) Unsubscribe(subscription *Subscription) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	subscriptions := hub.subscriptions[subscription.ElectionId]
+	delete(subscriptions, subscription)
+	if len(subscriptions) == 0 {
+		delete(hub.subscriptions, subscription.ElectionId)
+		return
+	}
+	hub.broadcastPresence(subscription.ElectionId)
+}
+
+func (hub *Hub) Viewers(electionId string) int {
+	hub.mu.RLock()
+	defer hub.mu.RUnlock()
+	return len(hub.subscriptions[electionId])
+}
+
+func (hub *Hub) PublishVote(ctx context.Context, v *vote.Vote) {
+	hub.publish(ctx, Event{Type: TallyEvent, ElectionId: v.ElectionId})
+}
+
+func (hub *Hub) PublishStatus(ctx context.Context, electionId string, status election.ElectionStatus) {
+	hub.publish(ctx, Event{Type: StatusEvent, ElectionId: electionId, Status: status})
+}
+
+func (hub *Hub) publish(ctx context.Context, event Event) {
+	requestId, _ := ctx.Value("requestId").(string)
+	payload, err := json.Marshal(event)
+	if err == nil {
+		err = hub.pubsub.Publish(ctx, eventTopic, payload)
+	}
+	if err != nil {
+		hub.log.Error(err.Error())
+		hub.log.Error("Could not publish " + string(event.Type) + " event for election: " + event.ElectionId, zap.String("request_id", requestId))
+	}
+}
+
+func (hub *Hub) dispatch(event Event) {
+	hub.mu.RLock()
+	defer hub.mu.RUnlock()
+	if event.Type != TallyEvent {
+		hub.broadcast(event)
+		return
+	}
+	for subscription := range hub.subscriptions[event.ElectionId] {
+		select {
+		case subscription.Changed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// broadcastPresence must be called with the lock held.
+func (hub *Hub) broadcastPresence(electionId string) {
+	viewers := len(hub.subscriptions[electionId])
+	hub.broadcast(Event{Type: PresenceEvent, ElectionId: electionId, Viewers: viewers})
+}
+
+// broadcast must be called with the lock held.
+func (hub *Hub) broadcast(event Event) {
+	for subscription := range hub.subscriptions[event.ElectionId] {
+		select {
+		case subscription.Events <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/vote"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+type SocketAction string
+
+const (
+	Subscribe SocketAction = "subscribe"
+	Unsubscribe SocketAction = "unsubscribe"
+)
+
+// SocketRequest is sent by clients to follow or stop following an election.
+type SocketRequest struct {
+	Action SocketAction
+	ElectionId string
+}
+
+type SocketMessageType string
+
+const (
+	TallyMessage SocketMessageType = "tally"
+	StatusMessage SocketMessageType = "status"
+	PresenceMessage SocketMessageType = "presence"
+	HeartbeatMessage SocketMessageType = "heartbeat"
+	SubscribedMessage SocketMessageType = "subscribed"
+	UnsubscribedMessage SocketMessageType = "unsubscribed"
+	ErrorMessage SocketMessageType = "error"
+)
+
+type SocketMessage struct {
+	Type SocketMessageType
+	ElectionId string `json:",omitempty"`
+	Tally *vote.Tally `json:",omitempty"`
+	Status election.ElectionStatus `json:",omitempty"`
+	Viewers int `json:",omitempty"`
+	Message string `json:",omitempty"`
+}
+
+// serveSocket multiplexes any number of election subscriptions over one
+// connection. Every subscription is watched on its own goroutine while a
+// single writer owns the connection.
+func (api *LiveAPI) serveSocket(parent context.Context, conn *websocket.Conn, electionId string) {
+	ctx, cancel := context.WithCancel(parent)
+	var watchers sync.WaitGroup
+	defer func() {
+		cancel()
+		watchers.Wait()
+	}()
+
+	outgoing := make(chan SocketMessage, 64)
+	send := func(message SocketMessage) {
+		select {
+		case outgoing <- message:
+		case <-ctx.Done():
+		}
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message := <-outgoing:
+				if websocket.JSON.Send(conn, message) != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	subscriptions := make(map[string]context.CancelFunc)
+	defer func() {
+		for _, stop := range subscriptions {
+			stop()
+		}
+	}()
+	subscribe := func(electionId string) {
+		if _, exists := subscriptions[electionId]; exists {
+			send(SocketMessage{Type: SubscribedMessage, ElectionId: electionId})
+			return
+		}
+		subscription := api.hub.Subscribe(electionId)
+		tally, err := api.tallies.GetTally(ctx, electionId)
+		if err != nil {
+			api.hub.Unsubscribe(subscription)
+			message := "could not subscribe to election"
+			if errors.Is(err, vote.ErrElectionNotFound) || errors.Is(err, vote.ErrResultsHidden) {
+				message = err.Error()
+			}
+			send(SocketMessage{Type: ErrorMessage, ElectionId: electionId, Message: message})
+			return
+		}
+		watchCtx, stop := context.WithCancel(ctx)
+		subscriptions[electionId] = stop
+		send(SocketMessage{Type: SubscribedMessage, ElectionId: electionId})
+		watchers.Add(1)
+		go func() {
+			defer watchers.Done()
+			defer api.hub.Unsubscribe(subscription)
+			api.watch(watchCtx, subscription, tally, "", socketSink{electionId: electionId, send: send})
+		}()
+	}
+	unsubscribe := func(electionId string) {
+		if stop, exists := subscriptions[electionId]; exists {
+			stop()
+			delete(subscriptions, electionId)
+		}
+		send(SocketMessage{Type: UnsubscribedMessage, ElectionId: electionId})
+	}
+
+	if electionId != "" {
+		subscribe(electionId)
+	}
+	for {
+		var request SocketRequest
+		err := websocket.JSON.Receive(conn, &request)
+		if err != nil {
+			requestId, _ := ctx.Value("requestId").(string)
+			api.log.Debug("Live socket receive ended: " + err.Error(), zap.String("request_id", requestId))
+			return
+		}
+		switch request.Action {
+		case Subscribe:
+			subscribe(request.ElectionId)
+		case Unsubscribe:
+			unsubscribe(request.ElectionId)
+		default:
+			send(SocketMessage{Type: ErrorMessage, ElectionId: request.ElectionId, Message: "unknown action"})
+		}
+	}
+}
+
+type socketSink struct {
+	electionId string
+	send func(SocketMessage)
+}
+
+func (out socketSink) tally(tally *vote.Tally) {
+	out.send(SocketMessage{Type: TallyMessage, ElectionId: out.electionId, Tally: tally})
+}
+
+func (out socketSink) event(event Event) {
+	switch event.Type {
+	case StatusEvent:
+		out.send(SocketMessage{Type: StatusMessage, ElectionId: event.ElectionId, Status: event.Status})
+	case PresenceEvent:
+		out.send(SocketMessage{Type: PresenceMessage, ElectionId: event.ElectionId, Viewers: event.Viewers})
+	}
+}
+
+func (out socketSink) heartbeat() {
+	out.send(SocketMessage{Type: HeartbeatMessage, ElectionId: out.electionId})
+}
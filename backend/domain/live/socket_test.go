@@ -0,0 +1,115 @@
+package live_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/live"
+	"geraldaddo.com/live-voting-system/domain/vote"
+	"golang.org/x/net/websocket"
+)
+
+func dialSocket(t *testing.T, serverUrl string, path string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(serverUrl, "http") + path
+	conn, err := websocket.Dial(url, "", serverUrl)
+	if err != nil {
+		t.Fatal("Could not open live socket", err.Error())
+	}
+	return conn
+}
+
+// nextMessage returns the next message of the given type for the given
+// election, skipping anything else the socket sends in between.
+func nextMessage(t *testing.T, conn *websocket.Conn, messageType live.SocketMessageType, electionId string) live.SocketMessage {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	for {
+		var message live.SocketMessage
+		err := websocket.JSON.Receive(conn, &message)
+		if err != nil {
+			t.Fatalf("Did not receive %s message for %s: %s", messageType, electionId, err.Error())
+		}
+		if message.Type == messageType && message.ElectionId == electionId {
+			return message
+		}
+	}
+}
+
+func TestLiveSocket(t *testing.T) {
+	hub := SetupHub(t)
+	source := &fakeTallySource{tally: &vote.Tally{
+		ElectionId: "test-election-id",
+		TotalVotes: 1,
+		Candidates: []vote.CandidateTally{{CandidateId: "candidate-1", Votes: 1}},
+	}}
+	server := SetupServer(hub, source)
+	defer server.Close()
+
+	conn := dialSocket(t, server.URL, "/elections/test-election-id/live")
+	defer conn.Close()
+
+	nextMessage(t, conn, live.SubscribedMessage, "test-election-id")
+	tally := nextMessage(t, conn, live.TallyMessage, "test-election-id")
+	if tally.Tally == nil || tally.Tally.TotalVotes != 1 {
+		t.Errorf("Expected initial tally with one vote but got %+v", tally.Tally)
+	}
+	presence := nextMessage(t, conn, live.PresenceMessage, "test-election-id")
+	if presence.Viewers != 1 {
+		t.Errorf("Expected %d viewers but got %d", 1, presence.Viewers)
+	}
+
+	other := dialSocket(t, server.URL, "/live")
+	err := websocket.JSON.Send(other, live.SocketRequest{Action: live.Subscribe, ElectionId: "test-election-id"})
+	if err != nil {
+		t.Fatal("Could not subscribe", err.Error())
+	}
+	nextMessage(t, other, live.SubscribedMessage, "test-election-id")
+	presence = nextMessage(t, conn, live.PresenceMessage, "test-election-id")
+	if presence.Viewers != 2 {
+		t.Errorf("Expected %d viewers but got %d", 2, presence.Viewers)
+	}
+
+	hub.PublishStatus(context.Background(), "test-election-id", election.Closed)
+	status := nextMessage(t, conn, live.StatusMessage, "test-election-id")
+	if status.Status != election.Closed {
+		t.Errorf("Expected status: %s but got %s", election.Closed, status.Status)
+	}
+
+	source.castVote("candidate-1")
+	hub.PublishVote(context.Background(), &vote.Vote{ElectionId: "test-election-id"})
+	tally = nextMessage(t, conn, live.TallyMessage, "test-election-id")
+	if tally.Tally == nil || tally.Tally.TotalVotes != 2 {
+		t.Errorf("Expected updated tally with two votes but got %+v", tally.Tally)
+	}
+
+	other.Close()
+	presence = nextMessage(t, conn, live.PresenceMessage, "test-election-id")
+	if presence.Viewers != 1 {
+		t.Errorf("Expected %d viewers after disconnect but got %d", 1, presence.Viewers)
+	}
+}
+
+func TestLiveSocketShouldReportUnknownElection(t *testing.T) {
+	hub := SetupHub(t)
+	server := SetupServer(hub, &fakeTallySource{})
+	defer server.Close()
+
+	conn := dialSocket(t, server.URL, "/live")
+	defer conn.Close()
+
+	err := websocket.JSON.Send(conn, live.SocketRequest{Action: live.Subscribe, ElectionId: "missing"})
+	if err != nil {
+		t.Fatal("Could not subscribe", err.Error())
+	}
+	message := nextMessage(t, conn, live.ErrorMessage, "missing")
+	if message.Message != vote.ErrElectionNotFound.Error() {
+		t.Errorf("Expected message: %s but got %s", vote.ErrElectionNotFound.Error(), message.Message)
+	}
+	if hub.Viewers("missing") != 0 {
+		t.Errorf("Expected no viewers for a missing election but got %d", hub.Viewers("missing"))
+	}
+}
@@ -0,0 +1,102 @@
+package partylist
+
+import (
+	"errors"
+	"net/http"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type PartyListAPI struct {
+	service *PartyListService
+	log *zap.Logger
+}
+
+func NewPartyListAPI(service *PartyListService, logger *zap.Logger) *PartyListAPI {
+	return &PartyListAPI{service: service, log: logger}
+}
+
+func (api *PartyListAPI) RegisterRoutes(server *gin.Engine) {
+	server.GET("/elections/:id/lists", user.Require(user.ReadElections), api.getLists)
+	server.GET("/elections/:id/lists/:listId", user.Require(user.ReadElections), api.getList)
+	server.POST("/elections/:id/lists", user.Require(user.ManageElections), api.createList)
+	server.PATCH("/elections/:id/lists/:listId", user.Require(user.ManageElections), api.updateList)
+	server.DELETE("/elections/:id/lists/:listId", user.Require(user.ManageElections), api.deleteList)
+}
+
+func (api *PartyListAPI) createList(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var list PartyList
+	err := ctx.ShouldBindJSON(&list)
+	if err != nil {
+		api.log.Error(err.Error())
+		api.log.Error("could not parse party list", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse party list"})
+		return
+	}
+	err = api.service.CreateList(ctx, ctx.Param("id"), &list)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "created party list"})
+}
+
+func (api *PartyListAPI) getLists(ctx *gin.Context) {
+	lists, err := api.service.GetLists(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, lists)
+}
+
+func (api *PartyListAPI) getList(ctx *gin.Context) {
+	list, err := api.service.GetList(ctx, ctx.Param("id"), ctx.Param("listId"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+func (api *PartyListAPI) updateList(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var updatedList PartyList
+	err := ctx.ShouldBindJSON(&updatedList)
+	if err != nil {
+		api.log.Error(err.Error())
+		api.log.Error("could not parse update information", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse update information"})
+		return
+	}
+	err = api.service.UpdateList(ctx, ctx.Param("id"), ctx.Param("listId"), &updatedList)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "updated party list"})
+}
+
+func (api *PartyListAPI) deleteList(ctx *gin.Context) {
+	err := api.service.DeleteList(ctx, ctx.Param("id"), ctx.Param("listId"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "deleted party list"})
+}
+
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrElectionNotFound), errors.Is(err, ErrListNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotPartyListElection):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrElectionNotDraft):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
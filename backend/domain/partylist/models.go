@@ -0,0 +1,15 @@
+package partylist
+
+import "time"
+
+// PartyList is a list of candidates that voters choose between in party-list
+// elections. Seats won by the list go to its candidates in the order they
+// were added.
+type PartyList struct {
+	ID string
+	ElectionId string
+	Name string `binding:"required"`
+	Description string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
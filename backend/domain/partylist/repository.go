@@ -0,0 +1,86 @@
+package partylist
+
+import (
+	"context"
+	"database/sql"
+
+	"geraldaddo.com/live-voting-system/platform/models"
+)
+
+//go:generate mockgen -destination=../../mocks/mock_party_list_repo.go -package=mocks . PartyListRepository
+type PartyListRepository interface {
+	models.Repository[PartyList]
+	GetAllByElection(ctx context.Context, electionId string) ([]PartyList, error)
+	DeleteOne(ctx context.Context, id string) error
+}
+type PartyListRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewPartyListRepository(db *sql.DB) *PartyListRepositoryImpl {
+	return &PartyListRepositoryImpl{db: db}
+}
+
+func (repo *PartyListRepositoryImpl) Save(ctx context.Context, list *PartyList) error {
+	insertStatement := `
+	INSERT INTO party_lists(election_id, name, description)
+	VALUES ($1, $2, $3)`
+	_, err := repo.db.Exec(insertStatement, list.ElectionId, list.Name, list.Description)
+	return err
+}
+
+func (repo *PartyListRepositoryImpl) GetById(ctx context.Context, id string) (*PartyList, error) {
+	query := `
+	SELECT id, election_id, name, description, created_at, updated_at
+	FROM party_lists
+	WHERE id = $1
+	`
+	row := repo.db.QueryRow(query, id)
+
+	var l PartyList
+	err := row.Scan(&l.ID, &l.ElectionId, &l.Name, &l.Description, &l.CreatedAt, &l.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (repo *PartyListRepositoryImpl) GetAllByElection(ctx context.Context, electionId string) ([]PartyList, error) {
+	query := `
+	SELECT id, election_id, name, description, created_at, updated_at
+	FROM party_lists
+	WHERE election_id = $1
+	ORDER BY created_at ASC
+	`
+	rows, err := repo.db.Query(query, electionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lists []PartyList
+	for rows.Next() {
+		var l PartyList
+		err := rows.Scan(&l.ID, &l.ElectionId, &l.Name, &l.Description, &l.CreatedAt, &l.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, l)
+	}
+	return lists, nil
+}
+
+func (repo *PartyListRepositoryImpl) UpdateOne(ctx context.Context, id string, l *PartyList) error {
+	updateStatement := `
+	UPDATE party_lists
+	SET name = $1, description = $2, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $3
+	`
+	_, err := repo.db.Exec(updateStatement, l.Name, l.Description, id)
+	return err
+}
+
+func (repo *PartyListRepositoryImpl) DeleteOne(ctx context.Context, id string) error {
+	_, err := repo.db.Exec(`DELETE FROM party_lists WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,151 @@
+package partylist
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrElectionNotFound = errors.New("Election does not exist")
+	ErrListNotFound = errors.New("Party list does not exist")
+	ErrElectionNotDraft = errors.New("Party lists can only be changed while the election is a draft")
+	ErrNotPartyListElection = errors.New("Election does not use a party-list voting method")
+)
+
+type PartyListService struct {
+	repo PartyListRepository
+	elections election.ElectionRepository
+	log *zap.Logger
+}
+
+func NewPartyListService(repo PartyListRepository, elections election.ElectionRepository, logger *zap.Logger) *PartyListService {
+	return &PartyListService{repo: repo, elections: elections, log: logger}
+}
+
+func (service *PartyListService) CreateList(ctx context.Context, electionId string, list *PartyList) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.requireDraft(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	if !e.Method.IsPartyList() {
+		service.log.Warn("Election does not use party lists: " + electionId, zap.String("request_id", requestId))
+		return ErrNotPartyListElection
+	}
+	list.ElectionId = electionId
+	err = service.repo.Save(ctx, list)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not create party list for election: " + electionId, zap.String("request_id", requestId))
+		return errors.New("Could not create party list")
+	}
+	service.log.Info("Created party list for election: " + electionId, zap.String("request_id", requestId))
+	return nil
+}
+
+func (service *PartyListService) GetLists(ctx context.Context, electionId string) ([]PartyList, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	_, err := service.getElection(ctx, electionId)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := service.repo.GetAllByElection(ctx, electionId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Failed to get party lists for election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get party lists")
+	}
+	service.log.Info(fmt.Sprintf("Got party lists of length: %d", len(lists)), zap.String("request_id", requestId))
+	return lists, nil
+}
+
+func (service *PartyListService) GetList(ctx context.Context, electionId string, id string) (*PartyList, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	list, err := service.repo.GetById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && list.ElectionId != electionId) {
+		service.log.Warn("Could not find party list with id: " + id, zap.String("request_id", requestId))
+		return nil, ErrListNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get party list with id: " + id, zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get party list with ID: " + id)
+	}
+	service.log.Info("Found party list with ID: " + id, zap.String("request_id", requestId))
+	return list, nil
+}
+
+func (service *PartyListService) UpdateList(ctx context.Context, electionId string, id string, updatedList *PartyList) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	_, err := service.requireDraft(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	_, err = service.GetList(ctx, electionId, id)
+	if err != nil {
+		return err
+	}
+	err = service.repo.UpdateOne(ctx, id, updatedList)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not update party list: " + id, zap.String("request_id", requestId))
+		return errors.New("Could not update party list: " + id)
+	}
+	service.log.Info("Updated party list: " + id, zap.String("request_id", requestId))
+	return nil
+}
+
+// DeleteList removes the list; its candidates stay on the ballot without a
+// list.
+func (service *PartyListService) DeleteList(ctx context.Context, electionId string, id string) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	_, err := service.requireDraft(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	_, err = service.GetList(ctx, electionId, id)
+	if err != nil {
+		return err
+	}
+	err = service.repo.DeleteOne(ctx, id)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not delete party list: " + id, zap.String("request_id", requestId))
+		return errors.New("Could not delete party list: " + id)
+	}
+	service.log.Info("Deleted party list: " + id, zap.String("request_id", requestId))
+	return nil
+}
+
+func (service *PartyListService) getElection(ctx context.Context, electionId string) (*election.Election, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.elections.GetById(ctx, electionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("Election with id: " + electionId + " does not exist", zap.String("request_id", requestId))
+		return nil, ErrElectionNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get election with ID: " + electionId)
+	}
+	return e, nil
+}
+
+func (service *PartyListService) requireDraft(ctx context.Context, electionId string) (*election.Election, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.getElection(ctx, electionId)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status != election.Draft {
+		service.log.Warn("Cannot change party lists of election: " + electionId, zap.String("request_id", requestId))
+		return nil, ErrElectionNotDraft
+	}
+	return e, nil
+}
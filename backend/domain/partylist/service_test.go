@@ -0,0 +1,101 @@
+package partylist_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/partylist"
+	"geraldaddo.com/live-voting-system/mocks"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestCreateList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		election *election.Election
+		expected error
+	}{
+		{"Party-list election", &election.Election{Status: election.Draft, Method: election.DHondt}, nil},
+		{"Candidate election", &election.Election{Status: election.Draft, Method: election.Plurality}, partylist.ErrNotPartyListElection},
+		{"Active election", &election.Election{Status: election.Active, Method: election.Hare}, partylist.ErrElectionNotDraft},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockPartyListRepository := mocks.NewMockPartyListRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			input := &partylist.PartyList{Name: "test list"}
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(test.election, nil).
+				Times(1)
+			if test.expected == nil {
+				mockPartyListRepository.
+					EXPECT().
+					Save(gomock.Any(), input).
+					Return(nil).
+					Times(1)
+			}
+			service := partylist.NewPartyListService(mockPartyListRepository, mockElectionRepository, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			err := service.CreateList(ctx, "test-election-id", input)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+			if test.expected == nil && input.ElectionId != "test-election-id" {
+				t.Errorf("Expected list election id: %s but got %s", "test-election-id", input.ElectionId)
+			}
+		})
+	}
+}
+
+func TestListsShouldOnlyChangeWhileElectionIsDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockPartyListRepository := mocks.NewMockPartyListRepository(ctrl)
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), gomock.Any()).
+		Return(&election.Election{Status: election.Closed, Method: election.SainteLague}, nil).
+		Times(2)
+
+	service := partylist.NewPartyListService(mockPartyListRepository, mockElectionRepository, zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+
+	err := service.UpdateList(ctx, "test-id", "test-list-id", &partylist.PartyList{Name: "test"})
+	if !errors.Is(err, partylist.ErrElectionNotDraft) {
+		t.Errorf("Expected update to fail with: %v but got %v", partylist.ErrElectionNotDraft, err)
+	}
+	err = service.DeleteList(ctx, "test-id", "test-list-id")
+	if !errors.Is(err, partylist.ErrElectionNotDraft) {
+		t.Errorf("Expected delete to fail with: %v but got %v", partylist.ErrElectionNotDraft, err)
+	}
+}
+
+func TestGetListShouldNotReturnListFromAnotherElection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockPartyListRepository := mocks.NewMockPartyListRepository(ctrl)
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockPartyListRepository.
+		EXPECT().
+		GetById(gomock.Any(), "test-list-id").
+		Return(&partylist.PartyList{ID: "test-list-id", ElectionId: "other-election-id"}, nil).
+		Times(1)
+
+	service := partylist.NewPartyListService(mockPartyListRepository, mockElectionRepository, zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	_, err := service.GetList(ctx, "test-election-id", "test-list-id")
+
+	if !errors.Is(err, partylist.ErrListNotFound) {
+		t.Errorf("Expected error: %v but got %v", partylist.ErrListNotFound, err)
+	}
+}
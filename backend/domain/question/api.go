@@ -0,0 +1,104 @@
+package question
+
+import (
+	"errors"
+	"net/http"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type QuestionAPI struct {
+	service *QuestionService
+	log *zap.Logger
+}
+
+func NewQuestionAPI(service *QuestionService, logger *zap.Logger) *QuestionAPI {
+	return &QuestionAPI{service: service, log: logger}
+}
+
+func (api *QuestionAPI) RegisterRoutes(server *gin.Engine) {
+	server.GET("/elections/:id/questions", user.Require(user.ReadElections), api.getQuestions)
+	server.GET("/elections/:id/questions/:questionId", user.Require(user.ReadElections), api.getQuestion)
+	server.POST("/elections/:id/questions", user.Require(user.ManageElections), api.createQuestion)
+	server.PATCH("/elections/:id/questions/:questionId", user.Require(user.ManageElections), api.updateQuestion)
+	server.DELETE("/elections/:id/questions/:questionId", user.Require(user.ManageElections), api.deleteQuestion)
+}
+
+func (api *QuestionAPI) createQuestion(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var question Question
+	err := ctx.ShouldBindJSON(&question)
+	if err != nil {
+		api.log.Error(err.Error())
+		api.log.Error("could not parse question", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse question"})
+		return
+	}
+	err = api.service.CreateQuestion(ctx, ctx.Param("id"), &question)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "created question"})
+}
+
+func (api *QuestionAPI) getQuestions(ctx *gin.Context) {
+	questions, err := api.service.GetQuestions(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, questions)
+}
+
+func (api *QuestionAPI) getQuestion(ctx *gin.Context) {
+	question, err := api.service.GetQuestion(ctx, ctx.Param("id"), ctx.Param("questionId"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, question)
+}
+
+func (api *QuestionAPI) updateQuestion(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var updatedQuestion Question
+	err := ctx.ShouldBindJSON(&updatedQuestion)
+	if err != nil {
+		api.log.Error(err.Error())
+		api.log.Error("could not parse update information", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse update information"})
+		return
+	}
+	err = api.service.UpdateQuestion(ctx, ctx.Param("id"), ctx.Param("questionId"), &updatedQuestion)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "updated question"})
+}
+
+func (api *QuestionAPI) deleteQuestion(ctx *gin.Context) {
+	err := api.service.DeleteQuestion(ctx, ctx.Param("id"), ctx.Param("questionId"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "deleted question"})
+}
+
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrElectionNotFound), errors.Is(err, ErrQuestionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrPartyListQuestion), errors.Is(err, election.ErrInvalidMethod), errors.Is(err, election.ErrInvalidSeats),
+		errors.Is(err, election.ErrInvalidSurplusTransfer), errors.Is(err, election.ErrInvalidCredits):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrElectionNotDraft):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,34 @@
+package question
+
+import (
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/tally"
+)
+
+// Question is one proposition put to the voters of an election, such as a
+// motion at a meeting. Its options are the candidates added for it, and it
+// is counted by its own voting method.
+type Question struct {
+	ID string
+	ElectionId string
+	Title string `binding:"required"`
+	Description string
+	Method election.VotingMethod
+	Seats int
+	SurplusTransfer election.SurplusTransfer
+	Credits int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Contest describes the question to the tally method counting it.
+func (question *Question) Contest(options []string) tally.Contest {
+	return tally.Contest{
+		Candidates: options,
+		Seats: question.Seats,
+		SurplusTransfer: string(question.SurplusTransfer),
+		Credits: question.Credits,
+	}
+}
@@ -0,0 +1,118 @@
+package question
+
+import (
+	"context"
+	"database/sql"
+
+	"geraldaddo.com/live-voting-system/platform/models"
+)
+
+//go:generate mockgen -destination=../../mocks/mock_question_repo.go -package=mocks . QuestionRepository
+type QuestionRepository interface {
+	models.Repository[Question]
+	GetAllByElection(ctx context.Context, electionId string) ([]Question, error)
+	DeleteOne(ctx context.Context, id string) error
+}
+
+const questionColumns = `id, election_id, title, description, method, seats, surplus_transfer, credits, created_at, updated_at`
+
+type QuestionRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewQuestionRepository(db *sql.DB) *QuestionRepositoryImpl {
+	return &QuestionRepositoryImpl{db: db}
+}
+
+func (repo *QuestionRepositoryImpl) Save(ctx context.Context, question *Question) error {
+	insertStatement := `
+	INSERT INTO questions(election_id, title, description, method, seats, surplus_transfer, credits)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := repo.db.Exec(
+		insertStatement,
+		question.ElectionId,
+		question.Title,
+		question.Description,
+		question.Method,
+		question.Seats,
+		question.SurplusTransfer,
+		question.Credits,
+	)
+	return err
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanQuestion(row scanner) (*Question, error) {
+	var q Question
+	err := row.Scan(
+		&q.ID,
+		&q.ElectionId,
+		&q.Title,
+		&q.Description,
+		&q.Method,
+		&q.Seats,
+		&q.SurplusTransfer,
+		&q.Credits,
+		&q.CreatedAt,
+		&q.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+func (repo *QuestionRepositoryImpl) GetById(ctx context.Context, id string) (*Question, error) {
+	query := `SELECT ` + questionColumns + ` FROM questions WHERE id = $1`
+	row := repo.db.QueryRow(query, id)
+	return scanQuestion(row)
+}
+
+// GetAllByElection returns the questions of the election in the order they
+// were added, which is the order they appear on the ballot.
+func (repo *QuestionRepositoryImpl) GetAllByElection(ctx context.Context, electionId string) ([]Question, error) {
+	query := `SELECT ` + questionColumns + ` FROM questions WHERE election_id = $1 ORDER BY created_at ASC`
+	rows, err := repo.db.Query(query, electionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var questions []Question
+	for rows.Next() {
+		q, err := scanQuestion(rows)
+		if err != nil {
+			return nil, err
+		}
+		questions = append(questions, *q)
+	}
+	return questions, nil
+}
+
+func (repo *QuestionRepositoryImpl) UpdateOne(ctx context.Context, id string, q *Question) error {
+	updateStatement := `
+	UPDATE questions
+	SET title = $1, description = $2, method = $3, seats = $4, surplus_transfer = $5, credits = $6,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $7
+	`
+	_, err := repo.db.Exec(
+		updateStatement,
+		q.Title,
+		q.Description,
+		q.Method,
+		q.Seats,
+		q.SurplusTransfer,
+		q.Credits,
+		id,
+	)
+	return err
+}
+
+func (repo *QuestionRepositoryImpl) DeleteOne(ctx context.Context, id string) error {
+	_, err := repo.db.Exec(`DELETE FROM questions WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,193 @@
+package question
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrElectionNotFound = errors.New("Election does not exist")
+	ErrQuestionNotFound = errors.New("Question does not exist")
+	ErrElectionNotDraft = errors.New("Questions can only be changed while the election is a draft")
+	ErrPartyListQuestion = errors.New("Questions cannot use a party-list voting method")
+)
+
+type QuestionService struct {
+	repo QuestionRepository
+	elections election.ElectionRepository
+	log *zap.Logger
+}
+
+func NewQuestionService(repo QuestionRepository, elections election.ElectionRepository, logger *zap.Logger) *QuestionService {
+	return &QuestionService{repo: repo, elections: elections, log: logger}
+}
+
+func (service *QuestionService) CreateQuestion(ctx context.Context, electionId string, question *Question) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	err := service.requireDraft(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	err = validateMethod(question)
+	if err != nil {
+		service.log.Warn(err.Error(), zap.String("request_id", requestId))
+		return err
+	}
+	question.ElectionId = electionId
+	err = service.repo.Save(ctx, question)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not create question for election: " + electionId, zap.String("request_id", requestId))
+		return errors.New("Could not create question")
+	}
+	service.log.Info("Created question for election: " + electionId, zap.String("request_id", requestId))
+	return nil
+}
+
+// validateMethod applies the voting option rules of elections to the
+// question. Party lists belong to the election, so questions cannot be
+// counted by a party-list method.
+func validateMethod(question *Question) error {
+	options := &election.Election{
+		Method: question.Method,
+		Seats: question.Seats,
+		SurplusTransfer: question.SurplusTransfer,
+		Credits: question.Credits,
+	}
+	err := election.ValidateMethod(options)
+	if err != nil {
+		return err
+	}
+	if options.Method.IsPartyList() {
+		return fmt.Errorf("%w: %s", ErrPartyListQuestion, options.Method)
+	}
+	question.Method = options.Method
+	question.Seats = options.Seats
+	question.SurplusTransfer = options.SurplusTransfer
+	return nil
+}
+
+func (service *QuestionService) GetQuestions(ctx context.Context, electionId string) ([]Question, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	_, err := service.getElection(ctx, electionId)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := service.repo.GetAllByElection(ctx, electionId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Failed to get questions for election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get questions")
+	}
+	service.log.Info(fmt.Sprintf("Got questions of length: %d", len(questions)), zap.String("request_id", requestId))
+	return questions, nil
+}
+
+func (service *QuestionService) GetQuestion(ctx context.Context, electionId string, id string) (*Question, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	question, err := service.repo.GetById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && question.ElectionId != electionId) {
+		service.log.Warn("Could not find question with id: " + id, zap.String("request_id", requestId))
+		return nil, ErrQuestionNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get question with id: " + id, zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get question with ID: " + id)
+	}
+	service.log.Info("Found question with ID: " + id, zap.String("request_id", requestId))
+	return question, nil
+}
+
+func (service *QuestionService) UpdateQuestion(ctx context.Context, electionId string, id string, updatedQuestion *Question) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	err := service.requireDraft(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	question, err := service.GetQuestion(ctx, electionId, id)
+	if err != nil {
+		return err
+	}
+	if updatedQuestion.Method == "" {
+		updatedQuestion.Method = question.Method
+	}
+	if updatedQuestion.Seats == 0 {
+		updatedQuestion.Seats = question.Seats
+	}
+	if updatedQuestion.Method == question.Method {
+		if updatedQuestion.SurplusTransfer == "" {
+			updatedQuestion.SurplusTransfer = question.SurplusTransfer
+		}
+		if updatedQuestion.Credits == 0 {
+			updatedQuestion.Credits = question.Credits
+		}
+	}
+	err = validateMethod(updatedQuestion)
+	if err != nil {
+		service.log.Warn(err.Error(), zap.String("request_id", requestId))
+		return err
+	}
+	err = service.repo.UpdateOne(ctx, id, updatedQuestion)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not update question: " + id, zap.String("request_id", requestId))
+		return errors.New("Could not update question: " + id)
+	}
+	service.log.Info("Updated question: " + id, zap.String("request_id", requestId))
+	return nil
+}
+
+// DeleteQuestion removes the question along with its options.
+func (service *QuestionService) DeleteQuestion(ctx context.Context, electionId string, id string) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	err := service.requireDraft(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	_, err = service.GetQuestion(ctx, electionId, id)
+	if err != nil {
+		return err
+	}
+	err = service.repo.DeleteOne(ctx, id)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not delete question: " + id, zap.String("request_id", requestId))
+		return errors.New("Could not delete question: " + id)
+	}
+	service.log.Info("Deleted question: " + id, zap.String("request_id", requestId))
+	return nil
+}
+
+func (service *QuestionService) getElection(ctx context.Context, electionId string) (*election.Election, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.elections.GetById(ctx, electionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("Election with id: " + electionId + " does not exist", zap.String("request_id", requestId))
+		return nil, ErrElectionNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get election with ID: " + electionId)
+	}
+	return e, nil
+}
+
+func (service *QuestionService) requireDraft(ctx context.Context, electionId string) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.getElection(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	if e.Status != election.Draft {
+		service.log.Warn("Cannot change questions of election: " + electionId, zap.String("request_id", requestId))
+		return ErrElectionNotDraft
+	}
+	return nil
+}
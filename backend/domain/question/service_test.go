@@ -0,0 +1,61 @@
+package question_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/question"
+	"geraldaddo.com/live-voting-system/mocks"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestCreateQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		status election.ElectionStatus
+		input question.Question
+		method election.VotingMethod
+		expected error
+	}{
+		{"Defaults to plurality", election.Draft, question.Question{Title: "Adopt the minutes"}, election.Plurality, nil},
+		{"Own voting method", election.Draft, question.Question{Title: "Board", Method: election.SingleTransferableVote, Seats: 3}, election.SingleTransferableVote, nil},
+		{"Party-list method", election.Draft, question.Question{Title: "Lists", Method: election.DHondt, Seats: 3}, election.DHondt, question.ErrPartyListQuestion},
+		{"Unsupported seats", election.Draft, question.Question{Title: "Motion", Seats: 2}, election.Plurality, election.ErrInvalidSeats},
+		{"Active election", election.Active, question.Question{Title: "Late motion"}, "", question.ErrElectionNotDraft},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockQuestionRepository := mocks.NewMockQuestionRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			input := test.input
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&election.Election{Status: test.status}, nil).
+				Times(1)
+			if test.expected == nil {
+				mockQuestionRepository.
+					EXPECT().
+					Save(gomock.Any(), &input).
+					Return(nil).
+					Times(1)
+			}
+			service := question.NewQuestionService(mockQuestionRepository, mockElectionRepository, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			err := service.CreateQuestion(ctx, "test-election-id", &input)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+			if test.expected == nil && (input.Method != test.method || input.Seats < 1 || input.ElectionId != "test-election-id") {
+				t.Errorf("Expected %s question with seats in election %s but got %+v", test.method, "test-election-id", input)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package result
+
+import (
+	"errors"
+	"net/http"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ResultAPI struct {
+	service *ResultService
+	log *zap.Logger
+}
+
+func NewResultAPI(service *ResultService, logger *zap.Logger) *ResultAPI {
+	return &ResultAPI{service: service, log: logger}
+}
+
+func (api *ResultAPI) RegisterRoutes(server *gin.Engine) {
+	server.GET("/elections/:id/results", user.Require(user.ReadElections), api.getResults)
+	server.POST("/elections/:id/certify", user.Require(user.ManageElections), api.certify)
+	server.GET("/elections/:id/certification", user.Require(user.ReadElections), api.getCertification)
+	server.GET("/elections/:id/recount", user.Require(user.ManageElections), api.recount)
+	server.GET("/elections/:id/certificate", user.Require(user.ReadElections), api.getCertificate)
+	// Anyone may fetch the key to check certificates offline.
+	server.GET("/certificates/public-key", api.getPublicKey)
+}
+
+func (api *ResultAPI) getResults(ctx *gin.Context) {
+	results, err := api.service.GetResults(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, results)
+}
+
+func (api *ResultAPI) certify(ctx *gin.Context) {
+	certification, err := api.service.Certify(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, certification)
+}
+
+func (api *ResultAPI) getCertification(ctx *gin.Context) {
+	certification, err := api.service.GetCertification(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, certification)
+}
+
+func (api *ResultAPI) getCertificate(ctx *gin.Context) {
+	certificate, err := api.service.GetCertificate(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, certificate)
+}
+
+func (api *ResultAPI) getPublicKey(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, api.service.PublicKey())
+}
+
+func (api *ResultAPI) recount(ctx *gin.Context) {
+	recount, err := api.service.Recount(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, recount)
+}
+
+func statusForError(err error) int {
+	if errors.Is(err, ErrElectionNotFound) || errors.Is(err, ErrNotCertified) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, ErrResultsHidden) || errors.Is(err, ErrAdminOnly) {
+		return http.StatusForbidden
+	}
+	if errors.Is(err, ErrNotClosed) || errors.Is(err, ErrStatusChanged) {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
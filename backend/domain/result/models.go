@@ -0,0 +1,88 @@
+package result
+
+import (
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/tally"
+	"geraldaddo.com/live-voting-system/platform/signing"
+)
+
+// Result is the outcome of an election under its voting method, with the
+// rounds, pairwise matrix or other workings the method reports. BallotHash
+// fingerprints the ballots that were counted. Eligible counts the active
+// users on the electoral roll or matching the eligibility rule, Turnout is
+// the percentage of them who cast a ballot and Abstentions those who did
+// not. Elections with questions also report the outcome of each question.
+type Result struct {
+	ElectionId string
+	Method election.VotingMethod
+	TotalBallots int
+	BlankBallots int
+	BallotHash string `json:",omitempty"`
+	Eligible int
+	Voters int
+	Turnout float64
+	Abstentions int
+	Options []Option
+	tally.Result
+	Questions []QuestionResult `json:",omitempty"`
+}
+
+// QuestionResult is the outcome of one question under its own voting method.
+type QuestionResult struct {
+	QuestionId string
+	Title string
+	Method election.VotingMethod
+	TotalBallots int
+	BlankBallots int
+	Options []Option
+	tally.Result
+}
+
+// Option is the count of one candidate, or of one party list in party-list
+// elections. Votes are taken from the last round the option took part in and
+// Percentage is its share of the votes counted in that round.
+type Option struct {
+	Id string
+	Name string
+	Votes float64
+	Percentage float64
+}
+
+// Certification is the sign-off of an election's results by an admin. The
+// results are frozen when the election is certified, and the Certificate
+// lets anyone check them against the server's public key.
+type Certification struct {
+	ElectionId string
+	CertifiedBy string
+	CertifiedAt time.Time
+	Results Result
+	Certificate *signing.Certificate `json:",omitempty"`
+}
+
+// CertificateDocument is the signed content of a results certificate.
+type CertificateDocument struct {
+	ElectionId string
+	Title string
+	Description string
+	Method election.VotingMethod
+	Seats int
+	StartTime time.Time
+	EndTime time.Time
+	CertifiedBy string
+	CertifiedAt time.Time
+	BallotHash string
+	Results Result
+}
+
+// Recount compares a fresh count of the ballots with the certified results.
+// Differences describes every way the two disagree.
+type Recount struct {
+	ElectionId string
+	CertifiedAt time.Time
+	Certified Result
+	Recounted Result
+	Matches bool
+	Differences []string
+}
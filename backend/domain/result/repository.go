@@ -0,0 +1,101 @@
+package result
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/platform/signing"
+)
+
+//go:generate mockgen -destination=../../mocks/mock_certification_repo.go -package=mocks . CertificationRepository
+type CertificationRepository interface {
+	// Certify stores the results snapshot and moves the election from closed
+	// to certified in one transaction. It reports whether the election was
+	// still closed; if not, nothing is stored.
+	Certify(ctx context.Context, certification *Certification) (bool, error)
+	GetByElection(ctx context.Context, electionId string) (*Certification, error)
+}
+
+type CertificationRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewCertificationRepository(db *sql.DB) *CertificationRepositoryImpl {
+	return &CertificationRepositoryImpl{db: db}
+}
+
+func (repo *CertificationRepositoryImpl) Certify(ctx context.Context, certification *Certification) (bool, error) {
+	results, err := json.Marshal(certification.Results)
+	if err != nil {
+		return false, err
+	}
+	var certificate []byte
+	if certification.Certificate != nil {
+		certificate, err = json.Marshal(certification.Certificate)
+		if err != nil {
+			return false, err
+		}
+	}
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	updateStatement := `
+	UPDATE elections
+	SET status = $1, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $2 AND status = $3
+	`
+	updated, err := tx.Exec(updateStatement, election.Certified, certification.ElectionId, election.Closed)
+	if err != nil {
+		return false, err
+	}
+	rows, err := updated.RowsAffected()
+	if err != nil || rows != 1 {
+		return false, err
+	}
+	insertStatement := `
+	INSERT INTO certifications(election_id, certified_by, certified_at, results, certificate)
+	VALUES ($1, $2, $3, $4, $5)`
+	_, err = tx.Exec(
+		insertStatement,
+		certification.ElectionId,
+		certification.CertifiedBy,
+		certification.CertifiedAt,
+		results,
+		certificate,
+	)
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+func (repo *CertificationRepositoryImpl) GetByElection(ctx context.Context, electionId string) (*Certification, error) {
+	query := `
+	SELECT election_id, certified_by, certified_at, results, certificate
+	FROM certifications
+	WHERE election_id = $1`
+	var c Certification
+	var results []byte
+	var certificate []byte
+	err := repo.db.QueryRow(query, electionId).Scan(&c.ElectionId, &c.CertifiedBy, &c.CertifiedAt, &results, &certificate)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(results, &c.Results)
+	if err != nil {
+		return nil, err
+	}
+	// Elections certified before certificates were introduced have none.
+	if certificate != nil {
+		c.Certificate = &signing.Certificate{}
+		err = json.Unmarshal(certificate, c.Certificate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &c, nil
+}
@@ -0,0 +1,480 @@
+package result
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/candidate"
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/eligibility"
+	"geraldaddo.com/live-voting-system/domain/partylist"
+	"geraldaddo.com/live-voting-system/domain/question"
+	"geraldaddo.com/live-voting-system/domain/roll"
+	"geraldaddo.com/live-voting-system/domain/tally"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/domain/vote"
+	"geraldaddo.com/live-voting-system/platform/signing"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrElectionNotFound = errors.New("Election does not exist")
+	ErrResultsHidden = errors.New("Results are only shown to admins until the election is certified")
+	ErrAdminOnly = errors.New("Only admins can certify or recount elections")
+	ErrNotClosed = errors.New("Only closed elections can be certified")
+	ErrNotCertified = errors.New("Election has not been certified")
+	ErrStatusChanged = errors.New("Election status was changed by another request")
+)
+
+type ResultService struct {
+	elections election.ElectionRepository
+	candidates candidate.CandidateRepository
+	lists partylist.PartyListRepository
+	questions question.QuestionRepository
+	votes vote.VoteRepository
+	roll roll.RollRepository
+	eligibility eligibility.EligibilityRepository
+	certifications CertificationRepository
+	signer *signing.Signer
+	publisher election.StatusPublisher
+	log *zap.Logger
+}
+
+func NewResultService(
+	elections election.ElectionRepository,
+	candidates candidate.CandidateRepository,
+	lists partylist.PartyListRepository,
+	questions question.QuestionRepository,
+	votes vote.VoteRepository,
+	roll roll.RollRepository,
+	eligible eligibility.EligibilityRepository,
+	certifications CertificationRepository,
+	signer *signing.Signer,
+	publisher election.StatusPublisher,
+	logger *zap.Logger,
+) *ResultService {
+	return &ResultService{
+		elections: elections,
+		candidates: candidates,
+		lists: lists,
+		questions: questions,
+		votes: votes,
+		roll: roll,
+		eligibility: eligible,
+		certifications: certifications,
+		signer: signer,
+		publisher: publisher,
+		log: logger,
+	}
+}
+
+// GetResults returns the frozen results of certified elections. Until an
+// election is certified its results are counted afresh and only shown to
+// admins.
+func (service *ResultService) GetResults(ctx context.Context, electionId string) (*Result, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.getElection(ctx, electionId)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status == election.Certified || e.Status == election.Archived {
+		certification, err := service.certifications.GetByElection(ctx, electionId)
+		if err == nil {
+			return &certification.Results, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			service.log.Error(err.Error())
+			service.log.Error("Could not get certification of election: " + electionId, zap.String("request_id", requestId))
+			return nil, errors.New("Could not get results")
+		}
+	}
+	embargoed := e.Status == election.Active || e.Status == election.Closed || e.Status == election.Archived
+	if embargoed && !user.IsAdmin(ctx) {
+		service.log.Warn("Results of uncertified election: " + electionId + " requested by a non-admin", zap.String("request_id", requestId))
+		return nil, ErrResultsHidden
+	}
+	return service.countElection(ctx, e)
+}
+
+// Certify freezes the current results of a closed election, marks it
+// certified by the signed in admin and signs a certificate of the results.
+func (service *ResultService) Certify(ctx context.Context, electionId string) (*Certification, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	admin, ok := user.FromContext(ctx)
+	if !ok || admin.Role != user.Admin {
+		service.log.Warn("Certification of election: " + electionId + " requested by a non-admin", zap.String("request_id", requestId))
+		return nil, ErrAdminOnly
+	}
+	e, err := service.getElection(ctx, electionId)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status != election.Closed {
+		service.log.Warn("Cannot certify election: " + electionId + " while " + string(e.Status), zap.String("request_id", requestId))
+		return nil, ErrNotClosed
+	}
+	results, err := service.countElection(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	certification := &Certification{
+		ElectionId: electionId,
+		CertifiedBy: admin.ID,
+		// Postgres keeps microseconds, so the stored time matches the signed one.
+		CertifiedAt: time.Now().UTC().Truncate(time.Microsecond),
+		Results: *results,
+	}
+	certification.Certificate, err = service.signer.Sign(CertificateDocument{
+		ElectionId: electionId,
+		Title: e.Title,
+		Description: e.Description,
+		Method: e.Method,
+		Seats: e.Seats,
+		StartTime: e.StartTime,
+		EndTime: e.EndTime,
+		CertifiedBy: admin.ID,
+		CertifiedAt: certification.CertifiedAt,
+		BallotHash: results.BallotHash,
+		Results: *results,
+	})
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not sign certificate for election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not certify election: " + electionId)
+	}
+	certified, err := service.certifications.Certify(ctx, certification)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not certify election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not certify election: " + electionId)
+	}
+	if !certified {
+		service.log.Warn("Status of election: " + electionId + " changed during certification", zap.String("request_id", requestId))
+		return nil, ErrStatusChanged
+	}
+	// Certifying moves the election, so live viewers are told like for any
+	// other transition.
+	service.publisher.PublishStatus(ctx, electionId, election.Certified)
+	service.log.Info("Certified election: " + electionId + " by " + admin.ID, zap.String("request_id", requestId))
+	return certification, nil
+}
+
+func (service *ResultService) GetCertification(ctx context.Context, electionId string) (*Certification, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	certification, err := service.certifications.GetByElection(ctx, electionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("Election: " + electionId + " has not been certified", zap.String("request_id", requestId))
+		return nil, ErrNotCertified
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get certification of election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not get certification of election: " + electionId)
+	}
+	return certification, nil
+}
+
+// GetCertificate returns the signed certificate of a certified election.
+func (service *ResultService) GetCertificate(ctx context.Context, electionId string) (*signing.Certificate, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	certification, err := service.GetCertification(ctx, electionId)
+	if err != nil {
+		return nil, err
+	}
+	if certification.Certificate == nil {
+		service.log.Warn("Election: " + electionId + " was certified without a certificate", zap.String("request_id", requestId))
+		return nil, ErrNotCertified
+	}
+	return certification.Certificate, nil
+}
+
+// PublicKey is the key certificates are signed with.
+func (service *ResultService) PublicKey() signing.PublicKey {
+	return service.signer.PublicKey()
+}
+
+// Recount counts the ballots of a certified election again and compares the
+// outcome with the certified snapshot.
+func (service *ResultService) Recount(ctx context.Context, electionId string) (*Recount, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	if !user.IsAdmin(ctx) {
+		service.log.Warn("Recount of election: " + electionId + " requested by a non-admin", zap.String("request_id", requestId))
+		return nil, ErrAdminOnly
+	}
+	e, err := service.getElection(ctx, electionId)
+	if err != nil {
+		return nil, err
+	}
+	certification, err := service.GetCertification(ctx, electionId)
+	if err != nil {
+		return nil, err
+	}
+	recounted, err := service.countElection(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	differences := compare(&certification.Results, recounted)
+	if len(differences) > 0 {
+		service.log.Warn(fmt.Sprintf("Recount of election: %s differs from certified results in %d ways", electionId, len(differences)),
+			zap.String("request_id", requestId))
+	}
+	return &Recount{
+		ElectionId: electionId,
+		CertifiedAt: certification.CertifiedAt,
+		Certified: certification.Results,
+		Recounted: *recounted,
+		Matches: len(differences) == 0,
+		Differences: differences,
+	}, nil
+}
+
+// countEligible counts the active users on the electoral roll or matching the
+// eligibility rule of the election.
+func (service *ResultService) countEligible(ctx context.Context, e *election.Election) (int, error) {
+	if e.Eligibility == "" {
+		return service.roll.CountEligible(ctx, e.ID)
+	}
+	rule, err := eligibility.Parse(e.Eligibility)
+	if err != nil {
+		return 0, err
+	}
+	return service.eligibility.CountEligible(ctx, e.ID, rule, time.Now())
+}
+
+func (service *ResultService) getElection(ctx context.Context, electionId string) (*election.Election, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.elections.GetById(ctx, electionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("Election with id: " + electionId + " does not exist", zap.String("request_id", requestId))
+		return nil, ErrElectionNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not get results")
+	}
+	return e, nil
+}
+
+// countElection counts every ballot cast in the election and its questions.
+func (service *ResultService) countElection(ctx context.Context, e *election.Election) (*Result, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	electionId := e.ID
+	candidates, err := service.candidates.GetAllByElection(ctx, electionId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get candidates for election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not get results")
+	}
+	own := candidate.ForQuestion(candidates, "")
+	var lists []tally.List
+	var partyLists []partylist.PartyList
+	if e.Method.IsPartyList() {
+		partyLists, err = service.lists.GetAllByElection(ctx, electionId)
+		if err != nil {
+			service.log.Error(err.Error())
+			service.log.Error("Could not get party lists for election: " + electionId, zap.String("request_id", requestId))
+			return nil, errors.New("Could not get results")
+		}
+		lists = candidate.GroupByList(partyLists, own)
+	}
+	questions, err := service.questions.GetAllByElection(ctx, electionId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get questions for election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not get results")
+	}
+	votes, err := service.votes.GetAllByElection(ctx, electionId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get votes for election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not get results")
+	}
+	eligible, err := service.countEligible(ctx, e)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not count eligible voters for election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not get results")
+	}
+	answers := make(map[string][]tally.Ballot, len(questions) + 1)
+	blanks := make(map[string]int, len(questions) + 1)
+	voters := make(map[string]bool)
+	for _, v := range votes {
+		voters[v.UserId] = true
+		if v.Blank {
+			blanks[v.QuestionId]++
+			continue
+		}
+		answers[v.QuestionId] = append(answers[v.QuestionId], v.Ballot())
+	}
+
+	names := make(map[string]string, len(candidates) + len(partyLists))
+	for _, c := range candidates {
+		names[c.ID] = c.Name
+	}
+	optionIds := candidate.Ids(own)
+	if e.Method.IsPartyList() {
+		optionIds = make([]string, len(partyLists))
+		for i, l := range partyLists {
+			names[l.ID] = l.Name
+			optionIds[i] = l.ID
+		}
+	}
+
+	outcome, err := count(e.Method, e.Contest(candidate.Ids(own), lists), answers[""])
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not count votes for election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not get results")
+	}
+	results := &Result{
+		ElectionId: electionId,
+		Method: e.Method,
+		TotalBallots: len(answers[""]) + blanks[""],
+		BlankBallots: blanks[""],
+		BallotHash: vote.HashBallots(votes),
+		Eligible: eligible,
+		Voters: len(voters),
+		Abstentions: max(eligible - len(voters), 0),
+		Options: options(outcome, optionIds, answers[""], names),
+		Result: *outcome,
+	}
+	if eligible > 0 {
+		results.Turnout = percentage(float64(len(voters)), float64(eligible))
+	}
+	for _, q := range questions {
+		optionIds := candidate.Ids(candidate.ForQuestion(candidates, q.ID))
+		outcome, err := count(q.Method, q.Contest(optionIds), answers[q.ID])
+		if err != nil {
+			service.log.Error(err.Error())
+			service.log.Error("Could not count votes for question: " + q.ID, zap.String("request_id", requestId))
+			return nil, errors.New("Could not get results")
+		}
+		results.Questions = append(results.Questions, QuestionResult{
+			QuestionId: q.ID,
+			Title: q.Title,
+			Method: q.Method,
+			TotalBallots: len(answers[q.ID]) + blanks[q.ID],
+			BlankBallots: blanks[q.ID],
+			Options: options(outcome, optionIds, answers[q.ID], names),
+			Result: *outcome,
+		})
+	}
+	return results, nil
+}
+
+func count(method election.VotingMethod, contest tally.Contest, ballots []tally.Ballot) (*tally.Result, error) {
+	m, err := tally.Lookup(string(method))
+	if err != nil {
+		return nil, err
+	}
+	return m.Tally(contest, ballots), nil
+}
+
+// options reports the votes of every candidate or party list from the last
+// round it took part in. Condorcet methods count no rounds, so their options
+// report first preferences instead.
+func options(outcome *tally.Result, ids []string, ballots []tally.Ballot, names map[string]string) []Option {
+	rounds := outcome.Rounds
+	if len(rounds) == 0 {
+		rounds = []tally.Round{firstPreferences(ids, ballots)}
+	}
+	options := make([]Option, 0, len(ids))
+	for _, id := range ids {
+		for i := len(rounds) - 1; i >= 0; i-- {
+			votes, ok := rounds[i].Counts[id]
+			if !ok {
+				continue
+			}
+			total := 0.0
+			for _, v := range rounds[i].Counts {
+				total += v
+			}
+			options = append(options, Option{
+				Id: id,
+				Name: names[id],
+				Votes: votes,
+				Percentage: percentage(votes, total),
+			})
+			break
+		}
+	}
+	return options
+}
+
+func firstPreferences(candidates []string, ballots []tally.Ballot) tally.Round {
+	round := tally.Round{Number: 1, Counts: make(map[string]float64, len(candidates))}
+	for _, c := range candidates {
+		round.Counts[c] = 0
+	}
+	for _, b := range ballots {
+		if len(b.Rankings) == 0 {
+			continue
+		}
+		if _, ok := round.Counts[b.Rankings[0]]; ok {
+			round.Counts[b.Rankings[0]] += float64(max(b.Weight, 1))
+		}
+	}
+	return round
+}
+
+// percentage is part as a percentage of total, rounded to two decimal places.
+func percentage(part float64, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(part * 10000 / total) / 100
+}
+
+// compare lists the differences between the certified results and a recount.
+func compare(certified *Result, recounted *Result) []string {
+	var differences []string
+	if certified.TotalBallots != recounted.TotalBallots {
+		differences = append(differences, fmt.Sprintf("total ballots: certified %d, recounted %d", certified.TotalBallots, recounted.TotalBallots))
+	}
+	if certified.BlankBallots != recounted.BlankBallots {
+		differences = append(differences, fmt.Sprintf("blank ballots: certified %d, recounted %d", certified.BlankBallots, recounted.BlankBallots))
+	}
+	if certified.BallotHash != "" && certified.BallotHash != recounted.BallotHash {
+		differences = append(differences, fmt.Sprintf("ballot hash: certified %s, recounted %s", certified.BallotHash, recounted.BallotHash))
+	}
+	differences = append(differences, compareCount("", certified.Winners, recounted.Winners, certified.Options, recounted.Options)...)
+	recountedQuestions := make(map[string]QuestionResult, len(recounted.Questions))
+	for _, q := range recounted.Questions {
+		recountedQuestions[q.QuestionId] = q
+	}
+	for _, q := range certified.Questions {
+		r, ok := recountedQuestions[q.QuestionId]
+		if !ok {
+			differences = append(differences, fmt.Sprintf("question %s: missing from recount", q.QuestionId))
+			continue
+		}
+		if q.TotalBallots != r.TotalBallots {
+			differences = append(differences, fmt.Sprintf("question %s total ballots: certified %d, recounted %d", q.QuestionId, q.TotalBallots, r.TotalBallots))
+		}
+		differences = append(differences, compareCount("question " + q.QuestionId + " ", q.Winners, r.Winners, q.Options, r.Options)...)
+	}
+	return differences
+}
+
+func compareCount(prefix string, certifiedWinners []string, recountedWinners []string, certified []Option, recounted []Option) []string {
+	var differences []string
+	if !slices.Equal(certifiedWinners, recountedWinners) {
+		differences = append(differences, fmt.Sprintf("%swinners: certified %v, recounted %v", prefix, certifiedWinners, recountedWinners))
+	}
+	votes := make(map[string]float64, len(recounted))
+	for _, o := range recounted {
+		votes[o.Id] = o.Votes
+	}
+	for _, o := range certified {
+		if votes[o.Id] != o.Votes {
+			differences = append(differences, fmt.Sprintf("%svotes for %s: certified %v, recounted %v", prefix, o.Id, o.Votes, votes[o.Id]))
+		}
+	}
+	return differences
+}
@@ -0,0 +1,587 @@
+package result_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/candidate"
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/eligibility"
+	"geraldaddo.com/live-voting-system/domain/question"
+	"geraldaddo.com/live-voting-system/domain/result"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/domain/vote"
+	"geraldaddo.com/live-voting-system/mocks"
+	"geraldaddo.com/live-voting-system/platform/signing"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestGetResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	candidates := []candidate.Candidate{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	tests := []struct {
+		name string
+		method election.VotingMethod
+		votes []vote.Vote
+		rounds int
+		winners []string
+	}{
+		{
+			"Plurality",
+			election.Plurality,
+			[]vote.Vote{{CandidateId: "a"}, {CandidateId: "b"}, {CandidateId: "b"}},
+			1,
+			[]string{"b"},
+		},
+		{
+			"Instant runoff",
+			election.InstantRunoff,
+			[]vote.Vote{
+				{Rankings: []string{"a"}},
+				{Rankings: []string{"a"}},
+				{Rankings: []string{"b", "c"}},
+				{Rankings: []string{"c", "b"}},
+				{Rankings: []string{"b"}},
+			},
+			2,
+			[]string{"b"},
+		},
+		{
+			"Schulze",
+			election.Schulze,
+			[]vote.Vote{
+				{Rankings: []string{"a", "b", "c"}},
+				{Rankings: []string{"b", "c", "a"}},
+				{Rankings: []string{"c", "a", "b"}},
+				{Rankings: []string{"a", "b"}},
+			},
+			0,
+			[]string{"a"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&election.Election{ID: "test-election-id", Method: test.method}, nil).
+				Times(1)
+			mockCandidateRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return(candidates, nil).
+				Times(1)
+			mockVoteRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return(test.votes, nil).
+				Times(1)
+
+			service := result.NewResultService(mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), mockVoteRepository, eligible(ctrl, 10), mocks.NewMockEligibilityRepository(ctrl), mocks.NewMockCertificationRepository(ctrl), newSigner(t), mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			results, err := service.GetResults(ctx, "test-election-id")
+
+			if err != nil {
+				t.Fatal("Could not get results", err.Error())
+			}
+			if results.Method != test.method {
+				t.Errorf("Expected method: %s but got %s", test.method, results.Method)
+			}
+			if results.TotalBallots != len(test.votes) {
+				t.Errorf("Expected total ballots: %d but got %d", len(test.votes), results.TotalBallots)
+			}
+			if len(results.Rounds) != test.rounds {
+				t.Errorf("Expected %d rounds but got %d", test.rounds, len(results.Rounds))
+			}
+			if !slices.Equal(results.Winners, test.winners) {
+				t.Errorf("Expected winners: %v but got %v", test.winners, results.Winners)
+			}
+			if test.method == election.Schulze && (results.Pairwise == nil || results.StrongestPaths == nil) {
+				t.Error("Expected pairwise matrix and strongest paths in results")
+			}
+		})
+	}
+}
+
+func TestGetResultsShouldFailIfElectionDoesNotExist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+	mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), "test-election-id").
+		Return(nil, sql.ErrNoRows).
+		Times(1)
+
+	service := result.NewResultService(mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), mockVoteRepository, eligible(ctrl, 10), mocks.NewMockEligibilityRepository(ctrl), mocks.NewMockCertificationRepository(ctrl), newSigner(t), mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	_, err := service.GetResults(ctx, "test-election-id")
+
+	if !errors.Is(err, result.ErrElectionNotFound) {
+		t.Errorf("Expected error: %v but got %v", result.ErrElectionNotFound, err)
+	}
+}
+
+func TestGetResultsShouldCountEachQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+	mockQuestionRepository := mocks.NewMockQuestionRepository(ctrl)
+	mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), "test-election-id").
+		Return(&election.Election{ID: "test-election-id", Method: election.Plurality}, nil).
+		Times(1)
+	mockCandidateRepository.
+		EXPECT().
+		GetAllByElection(gomock.Any(), "test-election-id").
+		Return([]candidate.Candidate{
+			{ID: "motion-1-for", QuestionId: "motion-1"},
+			{ID: "motion-1-against", QuestionId: "motion-1"},
+			{ID: "budget-parks", QuestionId: "motion-2"},
+			{ID: "budget-roads", QuestionId: "motion-2"},
+		}, nil).
+		Times(1)
+	mockQuestionRepository.
+		EXPECT().
+		GetAllByElection(gomock.Any(), "test-election-id").
+		Return([]question.Question{
+			{ID: "motion-1", Title: "Adopt the minutes", Method: election.Plurality, Seats: 1},
+			{ID: "motion-2", Title: "Budget", Method: election.Approval, Seats: 1},
+		}, nil).
+		Times(1)
+	mockVoteRepository.
+		EXPECT().
+		GetAllByElection(gomock.Any(), "test-election-id").
+		Return([]vote.Vote{
+			{QuestionId: "motion-1", CandidateId: "motion-1-for"},
+			{QuestionId: "motion-2", Approvals: []string{"budget-parks", "budget-roads"}},
+			{QuestionId: "motion-1", CandidateId: "motion-1-against", Weight: 3},
+			{QuestionId: "motion-2", Approvals: []string{"budget-roads"}},
+		}, nil).
+		Times(1)
+
+	service := result.NewResultService(mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), mockQuestionRepository, mockVoteRepository, eligible(ctrl, 10), mocks.NewMockEligibilityRepository(ctrl), mocks.NewMockCertificationRepository(ctrl), newSigner(t), mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	results, err := service.GetResults(ctx, "test-election-id")
+
+	if err != nil {
+		t.Fatal("Could not get results", err.Error())
+	}
+	if results.TotalBallots != 0 || len(results.Winners) != 0 {
+		t.Errorf("Expected no ballots in the election itself but got %d", results.TotalBallots)
+	}
+	if len(results.Questions) != 2 {
+		t.Fatalf("Expected results for %d questions but got %d", 2, len(results.Questions))
+	}
+	expected := [][]string{{"motion-1-against"}, {"budget-roads"}}
+	for i, q := range results.Questions {
+		if q.TotalBallots != 2 {
+			t.Errorf("Expected %d ballots for %s but got %d", 2, q.Title, q.TotalBallots)
+		}
+		if !slices.Equal(q.Winners, expected[i]) {
+			t.Errorf("Expected winners of %s: %v but got %v", q.Title, expected[i], q.Winners)
+		}
+	}
+}
+
+func TestGetResultsShouldReportTurnoutAndOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+	mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), "test-election-id").
+		Return(&election.Election{ID: "test-election-id", Method: election.Plurality, Status: election.Closed}, nil).
+		Times(1)
+	mockCandidateRepository.
+		EXPECT().
+		GetAllByElection(gomock.Any(), "test-election-id").
+		Return([]candidate.Candidate{{ID: "a", Name: "Ada"}, {ID: "b", Name: "Brian"}}, nil).
+		Times(1)
+	mockVoteRepository.
+		EXPECT().
+		GetAllByElection(gomock.Any(), "test-election-id").
+		Return([]vote.Vote{
+			{UserId: "user-1", CandidateId: "a"},
+			{UserId: "user-2", CandidateId: "b"},
+			{UserId: "user-3", CandidateId: "b"},
+			{UserId: "user-4", Blank: true},
+		}, nil).
+		Times(1)
+
+	service := result.NewResultService(mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), mockVoteRepository, eligible(ctrl, 8), mocks.NewMockEligibilityRepository(ctrl), mocks.NewMockCertificationRepository(ctrl), newSigner(t), mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	ctx = context.WithValue(ctx, user.ContextKey, &user.User{ID: "test-admin-id", Role: user.Admin})
+	results, err := service.GetResults(ctx, "test-election-id")
+
+	if err != nil {
+		t.Fatal("Could not get results", err.Error())
+	}
+	if results.TotalBallots != 4 || results.BlankBallots != 1 {
+		t.Errorf("Expected %d ballots with %d blank but got %d with %d blank", 4, 1, results.TotalBallots, results.BlankBallots)
+	}
+	if results.Eligible != 8 || results.Voters != 4 || results.Abstentions != 4 {
+		t.Errorf("Expected %d of %d eligible voters with %d abstentions but got %d of %d with %d",
+			4, 8, 4, results.Voters, results.Eligible, results.Abstentions)
+	}
+	if results.Turnout != 50 {
+		t.Errorf("Expected turnout: %v but got %v", 50.0, results.Turnout)
+	}
+	expected := []result.Option{
+		{Id: "a", Name: "Ada", Votes: 1, Percentage: 33.33},
+		{Id: "b", Name: "Brian", Votes: 2, Percentage: 66.67},
+	}
+	if !slices.Equal(results.Options, expected) {
+		t.Errorf("Expected options: %v but got %v", expected, results.Options)
+	}
+	if !slices.Equal(results.Winners, []string{"b"}) {
+		t.Errorf("Expected winners: %v but got %v", []string{"b"}, results.Winners)
+	}
+}
+
+func TestGetResultsShouldCountUsersMatchingEligibilityRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+	mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+	mockEligibilityRepository := mocks.NewMockEligibilityRepository(ctrl)
+
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), "test-election-id").
+		Return(&election.Election{ID: "test-election-id", Method: election.Plurality, Eligibility: `"board" in groups`}, nil).
+		Times(1)
+	mockCandidateRepository.
+		EXPECT().
+		GetAllByElection(gomock.Any(), "test-election-id").
+		Return([]candidate.Candidate{{ID: "a", Name: "Ada"}}, nil).
+		Times(1)
+	mockVoteRepository.
+		EXPECT().
+		GetAllByElection(gomock.Any(), "test-election-id").
+		Return([]vote.Vote{{UserId: "user-1", CandidateId: "a"}}, nil).
+		Times(1)
+	// The count is left to the database rather than loading every user.
+	mockEligibilityRepository.
+		EXPECT().
+		CountEligible(gomock.Any(), "test-election-id", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, electionId string, rule *eligibility.Rule, now time.Time) (int, error) {
+			if rule.String() != `"board" in groups` {
+				t.Errorf("Expected rule: %s but got %s", `"board" in groups`, rule)
+			}
+			return 4, nil
+		}).
+		Times(1)
+
+	service := result.NewResultService(mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), mockVoteRepository, mocks.NewMockRollRepository(ctrl), mockEligibilityRepository, mocks.NewMockCertificationRepository(ctrl), newSigner(t), mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	results, err := service.GetResults(ctx, "test-election-id")
+
+	if err != nil {
+		t.Fatal("Could not get results", err.Error())
+	}
+	if results.Eligible != 4 || results.Turnout != 25 {
+		t.Errorf("Expected %d eligible voters with turnout %v but got %d with %v", 4, 25.0, results.Eligible, results.Turnout)
+	}
+}
+
+func TestGetResultsShouldOnlyShowActiveResultsToAdmins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		user *user.User
+		expected error
+	}{
+		{"Anonymous", nil, result.ErrResultsHidden},
+		{"Base user", &user.User{ID: "test-user-id", Role: user.Base}, result.ErrResultsHidden},
+		{"Admin", &user.User{ID: "test-admin-id", Role: user.Admin}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&election.Election{ID: "test-election-id", Method: election.Plurality, Status: election.Active}, nil).
+				Times(1)
+			mockCandidateRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return([]candidate.Candidate{{ID: "a"}}, nil).
+				MaxTimes(1)
+			mockVoteRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return([]vote.Vote{{UserId: "user-1", CandidateId: "a"}}, nil).
+				MaxTimes(1)
+
+			service := result.NewResultService(mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), mockVoteRepository, eligible(ctrl, 1), mocks.NewMockEligibilityRepository(ctrl), mocks.NewMockCertificationRepository(ctrl), newSigner(t), mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			if test.user != nil {
+				ctx = context.WithValue(ctx, user.ContextKey, test.user)
+			}
+			_, err := service.GetResults(ctx, "test-election-id")
+
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestGetResultsShouldHideClosedResultsUntilCertified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), "test-election-id").
+		Return(&election.Election{ID: "test-election-id", Method: election.Plurality, Status: election.Closed}, nil).
+		Times(1)
+
+	service := result.NewResultService(mockElectionRepository, mocks.NewMockCandidateRepository(ctrl), mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), mocks.NewMockVoteRepository(ctrl), eligible(ctrl, 1), mocks.NewMockEligibilityRepository(ctrl), mocks.NewMockCertificationRepository(ctrl), newSigner(t), mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	ctx = context.WithValue(ctx, user.ContextKey, &user.User{ID: "test-user-id", Role: user.Base})
+	_, err := service.GetResults(ctx, "test-election-id")
+
+	if !errors.Is(err, result.ErrResultsHidden) {
+		t.Errorf("Expected error: %v but got %v", result.ErrResultsHidden, err)
+	}
+}
+
+func TestGetResultsShouldReturnCertifiedSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockCertificationRepository := mocks.NewMockCertificationRepository(ctrl)
+	snapshot := result.Result{ElectionId: "test-election-id", TotalBallots: 3}
+	snapshot.Winners = []string{"a"}
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), "test-election-id").
+		Return(&election.Election{ID: "test-election-id", Method: election.Plurality, Status: election.Certified}, nil).
+		Times(1)
+	mockCertificationRepository.
+		EXPECT().
+		GetByElection(gomock.Any(), "test-election-id").
+		Return(&result.Certification{ElectionId: "test-election-id", CertifiedBy: "test-admin-id", Results: snapshot}, nil).
+		Times(1)
+
+	service := result.NewResultService(mockElectionRepository, mocks.NewMockCandidateRepository(ctrl), mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), mocks.NewMockVoteRepository(ctrl), eligible(ctrl, 3), mocks.NewMockEligibilityRepository(ctrl), mockCertificationRepository, newSigner(t), mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	results, err := service.GetResults(ctx, "test-election-id")
+
+	if err != nil {
+		t.Fatal("Could not get results", err.Error())
+	}
+	if results.TotalBallots != 3 || !slices.Equal(results.Winners, []string{"a"}) {
+		t.Errorf("Expected certified results but got %+v", results)
+	}
+}
+
+func TestCertify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	admin := &user.User{ID: "test-admin-id", Role: user.Admin}
+	signer := newSigner(t)
+	tests := []struct {
+		name string
+		user *user.User
+		status election.ElectionStatus
+		stillClosed bool
+		expected error
+	}{
+		{"Admin certifies closed election", admin, election.Closed, true, nil},
+		{"Base user", &user.User{ID: "test-user-id", Role: user.Base}, election.Closed, true, result.ErrAdminOnly},
+		{"Anonymous", nil, election.Closed, true, result.ErrAdminOnly},
+		{"Active election", admin, election.Active, true, result.ErrNotClosed},
+		{"Already certified", admin, election.Certified, true, result.ErrNotClosed},
+		{"Reopened during certification", admin, election.Closed, false, result.ErrStatusChanged},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+			mockCertificationRepository := mocks.NewMockCertificationRepository(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&election.Election{ID: "test-election-id", Method: election.Plurality, Status: test.status}, nil).
+				MaxTimes(1)
+			mockCandidateRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return([]candidate.Candidate{{ID: "a"}, {ID: "b"}}, nil).
+				AnyTimes()
+			mockVoteRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return([]vote.Vote{{UserId: "user-1", CandidateId: "b"}}, nil).
+				AnyTimes()
+			if test.expected == nil || errors.Is(test.expected, result.ErrStatusChanged) {
+				mockCertificationRepository.
+					EXPECT().
+					Certify(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, c *result.Certification) (bool, error) {
+						if c.CertifiedBy != admin.ID || !slices.Equal(c.Results.Winners, []string{"b"}) {
+							t.Errorf("Expected results won by b certified by %s but got %+v", admin.ID, c)
+						}
+						err := signing.Verify(c.Certificate, signer.PublicKey().Key)
+						if err != nil {
+							t.Fatal("Expected a valid certificate but got", err.Error())
+						}
+						var document result.CertificateDocument
+						_ = json.Unmarshal([]byte(c.Certificate.Document), &document)
+						if document.BallotHash == "" || document.BallotHash != c.Results.BallotHash || !document.CertifiedAt.Equal(c.CertifiedAt) {
+							t.Errorf("Expected certificate of the certified results but got %+v", document)
+						}
+						return test.stillClosed, nil
+					}).
+					Times(1)
+			}
+			mockStatusPublisher := mocks.NewMockStatusPublisher(ctrl)
+			if test.expected == nil {
+				mockStatusPublisher.
+					EXPECT().
+					PublishStatus(gomock.Any(), "test-election-id", election.Certified).
+					Times(1)
+			}
+
+			service := result.NewResultService(mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), mockVoteRepository, eligible(ctrl, 1), mocks.NewMockEligibilityRepository(ctrl), mockCertificationRepository, signer, mockStatusPublisher, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			if test.user != nil {
+				ctx = context.WithValue(ctx, user.ContextKey, test.user)
+			}
+			_, err := service.Certify(ctx, "test-election-id")
+
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestRecountShouldCompareWithCertifiedResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		votes []vote.Vote
+		matches bool
+	}{
+		{"Same ballots", []vote.Vote{{UserId: "user-1", CandidateId: "a"}, {UserId: "user-2", CandidateId: "a"}, {UserId: "user-3", CandidateId: "b"}}, true},
+		{"Ballot changed since certification", []vote.Vote{{UserId: "user-1", CandidateId: "a"}, {UserId: "user-2", CandidateId: "b"}, {UserId: "user-3", CandidateId: "b"}}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot := result.Result{
+				ElectionId: "test-election-id",
+				TotalBallots: 3,
+				Options: []result.Option{{Id: "a", Votes: 2}, {Id: "b", Votes: 1}},
+			}
+			snapshot.Winners = []string{"a"}
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+			mockCertificationRepository := mocks.NewMockCertificationRepository(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&election.Election{ID: "test-election-id", Method: election.Plurality, Status: election.Certified}, nil).
+				Times(1)
+			mockCertificationRepository.
+				EXPECT().
+				GetByElection(gomock.Any(), "test-election-id").
+				Return(&result.Certification{ElectionId: "test-election-id", Results: snapshot}, nil).
+				Times(1)
+			mockCandidateRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return([]candidate.Candidate{{ID: "a"}, {ID: "b"}}, nil).
+				Times(1)
+			mockVoteRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return(test.votes, nil).
+				Times(1)
+
+			service := result.NewResultService(mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), mockVoteRepository, eligible(ctrl, 3), mocks.NewMockEligibilityRepository(ctrl), mockCertificationRepository, newSigner(t), mocks.NewMockStatusPublisher(ctrl), zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			ctx = context.WithValue(ctx, user.ContextKey, &user.User{ID: "test-admin-id", Role: user.Admin})
+			recount, err := service.Recount(ctx, "test-election-id")
+
+			if err != nil {
+				t.Fatal("Could not recount election", err.Error())
+			}
+			if recount.Matches != test.matches {
+				t.Errorf("Expected recount to match: %t but got differences %v", test.matches, recount.Differences)
+			}
+			if !test.matches && len(recount.Differences) == 0 {
+				t.Error("Expected differences between recount and certified results")
+			}
+		})
+	}
+}
+
+func newSigner(t *testing.T) *signing.Signer {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal("Could not generate signing key", err.Error())
+	}
+	return signing.NewSigner(key)
+}
+
+// eligible returns an electoral roll with the given number of active voters.
+func eligible(ctrl *gomock.Controller, count int) *mocks.MockRollRepository {
+	voters := mocks.NewMockRollRepository(ctrl)
+	voters.
+		EXPECT().
+		CountEligible(gomock.Any(), gomock.Any()).
+		Return(count, nil).
+		AnyTimes()
+	return voters
+}
+
+// noQuestions returns a question repository for elections without questions.
+func noQuestions(ctrl *gomock.Controller) *mocks.MockQuestionRepository {
+	questions := mocks.NewMockQuestionRepository(ctrl)
+	questions.
+		EXPECT().
+		GetAllByElection(gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+	return questions
+}
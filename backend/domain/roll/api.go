@@ -0,0 +1,92 @@
+package roll
+
+import (
+	"errors"
+	"net/http"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// maxImportSize caps the CSV body of a roll import.
+const maxImportSize = 10 << 20
+
+type RollAPI struct {
+	service *RollService
+	log *zap.Logger
+}
+
+func NewRollAPI(service *RollService, logger *zap.Logger) *RollAPI {
+	return &RollAPI{service: service, log: logger}
+}
+
+func (api *RollAPI) RegisterRoutes(server *gin.Engine) {
+	server.GET("/elections/:id/roll", user.Require(user.ManageElections), api.getVoters)
+	server.POST("/elections/:id/roll", user.Require(user.ManageElections), api.addVoter)
+	server.POST("/elections/:id/roll/import", user.Require(user.ManageElections), api.importVoters)
+	server.DELETE("/elections/:id/roll/:userId", user.Require(user.ManageElections), api.removeVoter)
+}
+
+func (api *RollAPI) addVoter(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var enrolment Enrolment
+	err := ctx.ShouldBindJSON(&enrolment)
+	if err != nil {
+		api.log.Error(err.Error())
+		api.log.Error("could not parse voter", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse voter"})
+		return
+	}
+	err = api.service.AddVoter(ctx, ctx.Param("id"), &enrolment)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "added voter"})
+}
+
+// importVoters reads a CSV of email addresses from the request body, of at
+// most maxImportSize bytes.
+func (api *RollAPI) importVoters(ctx *gin.Context) {
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize)
+	imported, err := api.service.ImportVoters(ctx, ctx.Param("id"), body)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "imported voters", "imported": imported})
+}
+
+func (api *RollAPI) getVoters(ctx *gin.Context) {
+	voters, err := api.service.GetVoters(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, voters)
+}
+
+func (api *RollAPI) removeVoter(ctx *gin.Context) {
+	err := api.service.RemoveVoter(ctx, ctx.Param("id"), ctx.Param("userId"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "removed voter"})
+}
+
+func statusForError(err error) int {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrElectionNotFound), errors.Is(err, ErrVoterNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrUnknownVoter), errors.Is(err, ErrInvalidImport):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrElectionNotDraft):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,70 @@
+package roll_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/roll"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func SetupServer() *gin.Engine {
+	server := gin.Default()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("requestId", uuid.New().String())
+		ctx.Set(user.ContextKey, &user.User{ID: "test-admin", Role: user.Admin, Active: true})
+	})
+	return server
+}
+
+func TestImportVotersAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		body string
+		statusCode int
+	}{
+		{"Import voters", "user@example.com\n", 200},
+		{"Body too large", strings.Repeat("user@example.com\n", (10 << 20) / 17 + 1), 413},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockRollRepository := mocks.NewMockRollRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			if test.statusCode == 200 {
+				mockElectionRepository.
+					EXPECT().
+					GetById(gomock.Any(), "test-election-id").
+					Return(&election.Election{Status: election.Draft}, nil).
+					Times(1)
+				mockRollRepository.
+					EXPECT().
+					AddAll(gomock.Any(), "test-election-id", []string{"user@example.com"}).
+					Return(1, nil).
+					Times(1)
+			}
+			server := SetupServer()
+			service := roll.NewRollService(mockRollRepository, mockElectionRepository, zap.NewNop())
+			roll.NewRollAPI(service, zap.NewNop()).RegisterRoutes(server)
+
+			recorder := httptest.NewRecorder()
+			request, _ := http.NewRequest("POST", "/elections/test-election-id/roll/import", strings.NewReader(test.body))
+			server.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.statusCode {
+				t.Errorf("Expected status code: %d but got %d", test.statusCode, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package roll
+
+import "time"
+
+// Voter is a user on the electoral roll of an election. Only voters on the
+// roll whose account is active may vote.
+type Voter struct {
+	ElectionId string
+	UserId string
+	Email string
+	FirstName string
+	LastName string
+	Active bool
+	CreatedAt time.Time
+}
+
+// Enrolment adds the user with the email address to the roll.
+type Enrolment struct {
+	Email string `binding:"required,email"`
+}
@@ -0,0 +1,129 @@
+package roll
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+//go:generate mockgen -destination=../../mocks/mock_roll_repo.go -package=mocks . RollRepository
+type RollRepository interface {
+	// AddAll puts the users with the email addresses on the roll in one
+	// transaction, so an import either applies in full or not at all. Users
+	// already on the roll are left as they are. It returns the number of
+	// voters added.
+	AddAll(ctx context.Context, electionId string, emails []string) (int, error)
+	GetVoter(ctx context.Context, electionId string, userId string) (*Voter, error)
+	GetAllByElection(ctx context.Context, electionId string) ([]Voter, error)
+	// CountEligible counts the active voters on the roll.
+	CountEligible(ctx context.Context, electionId string) (int, error)
+	DeleteOne(ctx context.Context, electionId string, userId string) error
+}
+
+const voterColumns = `r.election_id, r.user_id, u.email, u.first_name, u.last_name, u.active, r.created_at`
+
+type RollRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewRollRepository(db *sql.DB) *RollRepositoryImpl {
+	return &RollRepositoryImpl{db: db}
+}
+
+// AddAll enrols the users with the given emails. Emails are matched exactly,
+// as they are at login, since users.email is only unique as written.
+func (repo *RollRepositoryImpl) AddAll(ctx context.Context, electionId string, emails []string) (int, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	insertStatement := `
+	INSERT INTO electoral_rolls(election_id, user_id)
+	VALUES ($1, $2)
+	ON CONFLICT DO NOTHING`
+	added := 0
+	for _, email := range emails {
+		var userId string
+		err := tx.QueryRow(`SELECT id FROM users WHERE email = $1`, email).Scan(&userId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%w: %s", ErrUnknownVoter, email)
+		}
+		if err != nil {
+			return 0, err
+		}
+		result, err := tx.Exec(insertStatement, electionId, userId)
+		if err != nil {
+			return 0, err
+		}
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		added += int(inserted)
+	}
+	return added, tx.Commit()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanVoter(row scanner) (*Voter, error) {
+	var v Voter
+	err := row.Scan(&v.ElectionId, &v.UserId, &v.Email, &v.FirstName, &v.LastName, &v.Active, &v.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (repo *RollRepositoryImpl) GetVoter(ctx context.Context, electionId string, userId string) (*Voter, error) {
+	query := `
+	SELECT ` + voterColumns + `
+	FROM electoral_rolls r JOIN users u ON u.id = r.user_id
+	WHERE r.election_id = $1 AND r.user_id = $2
+	`
+	return scanVoter(repo.db.QueryRow(query, electionId, userId))
+}
+
+func (repo *RollRepositoryImpl) GetAllByElection(ctx context.Context, electionId string) ([]Voter, error) {
+	query := `
+	SELECT ` + voterColumns + `
+	FROM electoral_rolls r JOIN users u ON u.id = r.user_id
+	WHERE r.election_id = $1
+	ORDER BY u.last_name, u.first_name
+	`
+	rows, err := repo.db.Query(query, electionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var voters []Voter
+	for rows.Next() {
+		v, err := scanVoter(rows)
+		if err != nil {
+			return nil, err
+		}
+		voters = append(voters, *v)
+	}
+	return voters, nil
+}
+
+func (repo *RollRepositoryImpl) CountEligible(ctx context.Context, electionId string) (int, error) {
+	query := `
+	SELECT COUNT(*)
+	FROM electoral_rolls r JOIN users u ON u.id = r.user_id
+	WHERE r.election_id = $1 AND u.active
+	`
+	var count int
+	err := repo.db.QueryRow(query, electionId).Scan(&count)
+	return count, err
+}
+
+func (repo *RollRepositoryImpl) DeleteOne(ctx context.Context, electionId string, userId string) error {
+	_, err := repo.db.Exec(`DELETE FROM electoral_rolls WHERE election_id = $1 AND user_id = $2`, electionId, userId)
+	return err
+}
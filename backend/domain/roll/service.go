@@ -0,0 +1,172 @@
+package roll
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrElectionNotFound = errors.New("Election does not exist")
+	ErrElectionNotDraft = errors.New("Electoral roll can only be changed while the election is a draft")
+	ErrVoterNotFound = errors.New("Voter is not on the electoral roll")
+	ErrUnknownVoter = errors.New("No user has this email address")
+	ErrInvalidImport = errors.New("Could not read electoral roll")
+)
+
+type RollService struct {
+	repo RollRepository
+	elections election.ElectionRepository
+	log *zap.Logger
+}
+
+func NewRollService(repo RollRepository, elections election.ElectionRepository, logger *zap.Logger) *RollService {
+	return &RollService{repo: repo, elections: elections, log: logger}
+}
+
+func (service *RollService) AddVoter(ctx context.Context, electionId string, enrolment *Enrolment) error {
+	_, err := service.addVoters(ctx, electionId, []string{enrolment.Email})
+	return err
+}
+
+// ImportVoters puts every user listed by email in a CSV on the roll. The
+// email is read from the first column, below an optional header row.
+// Nothing is imported unless every email belongs to a user. It returns the
+// number of voters added.
+func (service *RollService) ImportVoters(ctx context.Context, electionId string, source io.Reader) (int, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	emails, err := parseEmails(source)
+	if err != nil {
+		service.log.Warn(err.Error(), zap.String("request_id", requestId))
+		return 0, err
+	}
+	return service.addVoters(ctx, electionId, emails)
+}
+
+func parseEmails(source io.Reader) ([]string, error) {
+	reader := csv.NewReader(source)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+	if len(records) > 0 && strings.EqualFold(strings.TrimSpace(records[0][0]), "email") {
+		records = records[1:]
+	}
+	emails := make([]string, 0, len(records))
+	for _, record := range records {
+		email := strings.TrimSpace(record[0])
+		if email == "" {
+			continue
+		}
+		_, err := mail.ParseAddress(email)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not an email address", ErrInvalidImport, email)
+		}
+		emails = append(emails, email)
+	}
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("%w: no voters", ErrInvalidImport)
+	}
+	return emails, nil
+}
+
+func (service *RollService) addVoters(ctx context.Context, electionId string, emails []string) (int, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	_, err := service.requireDraft(ctx, electionId)
+	if err != nil {
+		return 0, err
+	}
+	added, err := service.repo.AddAll(ctx, electionId, emails)
+	if errors.Is(err, ErrUnknownVoter) {
+		service.log.Warn(err.Error(), zap.String("request_id", requestId))
+		return 0, err
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not add voters to election: " + electionId, zap.String("request_id", requestId))
+		return 0, errors.New("Could not add voters to the electoral roll")
+	}
+	service.log.Info(fmt.Sprintf("Added %d voters to election: %s", added, electionId), zap.String("request_id", requestId))
+	return added, nil
+}
+
+func (service *RollService) GetVoters(ctx context.Context, electionId string) ([]Voter, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	_, err := service.getElection(ctx, electionId)
+	if err != nil {
+		return nil, err
+	}
+	voters, err := service.repo.GetAllByElection(ctx, electionId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Failed to get electoral roll for election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get electoral roll")
+	}
+	service.log.Info(fmt.Sprintf("Got electoral roll of length: %d", len(voters)), zap.String("request_id", requestId))
+	return voters, nil
+}
+
+func (service *RollService) RemoveVoter(ctx context.Context, electionId string, userId string) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	_, err := service.requireDraft(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	_, err = service.repo.GetVoter(ctx, electionId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("Voter: " + userId + " is not on the roll of election: " + electionId, zap.String("request_id", requestId))
+		return ErrVoterNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get voter: " + userId, zap.String("request_id", requestId))
+		return errors.New("Could not remove voter from the electoral roll")
+	}
+	err = service.repo.DeleteOne(ctx, electionId, userId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not remove voter: " + userId, zap.String("request_id", requestId))
+		return errors.New("Could not remove voter from the electoral roll")
+	}
+	service.log.Info("Removed voter: " + userId + " from election: " + electionId, zap.String("request_id", requestId))
+	return nil
+}
+
+func (service *RollService) getElection(ctx context.Context, electionId string) (*election.Election, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.elections.GetById(ctx, electionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("Election with id: " + electionId + " does not exist", zap.String("request_id", requestId))
+		return nil, ErrElectionNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get election with ID: " + electionId)
+	}
+	return e, nil
+}
+
+// requireDraft closes the roll once voting starts.
+func (service *RollService) requireDraft(ctx context.Context, electionId string) (*election.Election, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.getElection(ctx, electionId)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status != election.Draft {
+		service.log.Warn("Cannot change electoral roll of election: " + electionId, zap.String("request_id", requestId))
+		return nil, ErrElectionNotDraft
+	}
+	return e, nil
+}
@@ -0,0 +1,133 @@
+package roll_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/roll"
+	"geraldaddo.com/live-voting-system/mocks"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestAddVoter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		election *election.Election
+		addErr error
+		expected error
+	}{
+		{"Draft election", &election.Election{Status: election.Draft}, nil, nil},
+		{"Unknown email", &election.Election{Status: election.Draft}, fmt.Errorf("%w: ada@example.com", roll.ErrUnknownVoter), roll.ErrUnknownVoter},
+		{"Active election", &election.Election{Status: election.Active}, nil, roll.ErrElectionNotDraft},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockRollRepository := mocks.NewMockRollRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(test.election, nil).
+				Times(1)
+			if test.election.Status == election.Draft {
+				mockRollRepository.
+					EXPECT().
+					AddAll(gomock.Any(), "test-election-id", []string{"ada@example.com"}).
+					Return(1, test.addErr).
+					Times(1)
+			}
+			service := roll.NewRollService(mockRollRepository, mockElectionRepository, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			err := service.AddVoter(ctx, "test-election-id", &roll.Enrolment{Email: "ada@example.com"})
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestImportVoters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		csv string
+		emails []string
+		expected error
+	}{
+		{"With header", "email\nada@example.com\nbrian@example.com\n", []string{"ada@example.com", "brian@example.com"}, nil},
+		{"Without header", "ada@example.com, Ada Lovelace\n brian@example.com\n\n", []string{"ada@example.com", "brian@example.com"}, nil},
+		{"Invalid email", "email\nada@example.com\nnot-an-email\n", nil, roll.ErrInvalidImport},
+		{"Header only", "email\n", nil, roll.ErrInvalidImport},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockRollRepository := mocks.NewMockRollRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&election.Election{Status: election.Draft}, nil).
+				AnyTimes()
+			if test.expected == nil {
+				mockRollRepository.
+					EXPECT().
+					AddAll(gomock.Any(), "test-election-id", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, electionId string, emails []string) (int, error) {
+						if !slices.Equal(emails, test.emails) {
+							t.Errorf("Expected emails: %v but got %v", test.emails, emails)
+						}
+						return len(emails), nil
+					}).
+					Times(1)
+			}
+			service := roll.NewRollService(mockRollRepository, mockElectionRepository, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			imported, err := service.ImportVoters(ctx, "test-election-id", strings.NewReader(test.csv))
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+			if imported != len(test.emails) {
+				t.Errorf("Expected %d voters imported but got %d", len(test.emails), imported)
+			}
+		})
+	}
+}
+
+func TestRemoveVoterShouldFailIfVoterIsNotOnRoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRollRepository := mocks.NewMockRollRepository(ctrl)
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), "test-election-id").
+		Return(&election.Election{Status: election.Draft}, nil).
+		Times(1)
+	mockRollRepository.
+		EXPECT().
+		GetVoter(gomock.Any(), "test-election-id", "test-user-id").
+		Return(nil, sql.ErrNoRows).
+		Times(1)
+
+	service := roll.NewRollService(mockRollRepository, mockElectionRepository, zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	err := service.RemoveVoter(ctx, "test-election-id", "test-user-id")
+
+	if !errors.Is(err, roll.ErrVoterNotFound) {
+		t.Errorf("Expected error: %v but got %v", roll.ErrVoterNotFound, err)
+	}
+}
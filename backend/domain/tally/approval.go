@@ -0,0 +1,38 @@
+package tally
+
+import "fmt"
+
+// Approval counts one vote for every candidate a ballot approves of. The
+// candidates approved by the most ballots win.
+func Approval(candidates []string, approvals [][]string) *Result {
+	return approval(candidates, approvals, nil)
+}
+
+func approval(candidates []string, approvals [][]string, weights []float64) *Result {
+	counts := emptyCounts(candidates)
+	for i, approved := range approvals {
+		for _, candidate := range approved {
+			if _, ok := counts[candidate]; ok {
+				counts[candidate] += weightOf(weights, i)
+			}
+		}
+	}
+	return singleRound(candidates, counts)
+}
+
+type approvalMethod struct{}
+
+func (approvalMethod) Validate(contest Contest, ballot Ballot) error {
+	if ballot.kinds() != approvalsKind {
+		return fmt.Errorf("%w: approve one or more candidates", ErrInvalidBallot)
+	}
+	return validateSelection(contest.Candidates, ballot.Approvals)
+}
+
+func (approvalMethod) Tally(contest Contest, ballots []Ballot) *Result {
+	approvals := make([][]string, len(ballots))
+	for i, ballot := range ballots {
+		approvals[i] = ballot.Approvals
+	}
+	return approval(contest.Candidates, approvals, weights(ballots))
+}
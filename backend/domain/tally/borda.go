@@ -0,0 +1,34 @@
+package tally
+
+// Borda awards points by position: with n candidates on the ballot the first
+// preference earns n-1 points, the second n-2 and so on. Candidates a ballot
+// leaves unranked earn nothing from it. The candidates with the most points
+// win.
+func Borda(candidates []string, ballots [][]string) *Result {
+	return borda(candidates, ballots, nil)
+}
+
+func borda(candidates []string, ballots [][]string, weights []float64) *Result {
+	counts := emptyCounts(candidates)
+	for i, ranked := range ballots {
+		position := 0
+		for _, candidate := range ranked {
+			if _, ok := counts[candidate]; !ok {
+				continue
+			}
+			counts[candidate] += float64(len(candidates) - 1 - position) * weightOf(weights, i)
+			position++
+		}
+	}
+	return singleRound(candidates, counts)
+}
+
+type bordaMethod struct{}
+
+func (bordaMethod) Validate(contest Contest, ballot Ballot) error {
+	return validateRanking(contest.Candidates, ballot)
+}
+
+func (bordaMethod) Tally(contest Contest, ballots []Ballot) *Result {
+	return borda(contest.Candidates, rankings(ballots), weights(ballots))
+}
@@ -0,0 +1,227 @@
+package tally
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Pair is a head to head contest between two candidates, won by Winner with
+// Votes ballots to Against.
+type Pair struct {
+	Winner string
+	Loser string
+	Votes float64
+	Against float64
+}
+
+// PairwiseMatrix counts, for every ordered pair of candidates, the ballots
+// that rank the first above the second. A ranked candidate is preferred to
+// every candidate the ballot leaves unranked; unranked candidates are tied.
+func PairwiseMatrix(candidates []string, ballots [][]string) map[string]map[string]float64 {
+	return pairwiseMatrix(candidates, ballots, nil)
+}
+
+func pairwiseMatrix(candidates []string, ballots [][]string, weights []float64) map[string]map[string]float64 {
+	matrix := make(map[string]map[string]float64, len(candidates))
+	for _, candidate := range candidates {
+		matrix[candidate] = make(map[string]float64, len(candidates) - 1)
+		for _, other := range candidates {
+			if other != candidate {
+				matrix[candidate][other] = 0
+			}
+		}
+	}
+	for i, ballot := range ballots {
+		ranked := make(map[string]bool, len(ballot))
+		for _, candidate := range ballot {
+			if _, ok := matrix[candidate]; !ok || ranked[candidate] {
+				continue
+			}
+			ranked[candidate] = true
+			for _, other := range candidates {
+				if !ranked[other] {
+					matrix[candidate][other] += weightOf(weights, i)
+				}
+			}
+		}
+	}
+	return matrix
+}
+
+// Schulze finds the candidates whose strongest path to every rival is at
+// least as strong as the rival's path back. The strength of a path is its
+// weakest pairwise win. Several candidates can win when paths are tied.
+func Schulze(candidates []string, ballots [][]string) *Result {
+	return schulze(candidates, PairwiseMatrix(candidates, ballots), len(ballots) > 0)
+}
+
+// schulze finds the winners from the pairwise matrix; without any ballots
+// counted there is no winner.
+func schulze(candidates []string, matrix map[string]map[string]float64, counted bool) *Result {
+	paths := make(map[string]map[string]float64, len(candidates))
+	for _, i := range candidates {
+		paths[i] = make(map[string]float64, len(candidates) - 1)
+		for _, j := range candidates {
+			if i != j && matrix[i][j] > matrix[j][i] {
+				paths[i][j] = matrix[i][j]
+			} else if i != j {
+				paths[i][j] = 0
+			}
+		}
+	}
+	for _, k := range candidates {
+		for _, i := range candidates {
+			if i == k {
+				continue
+			}
+			for _, j := range candidates {
+				if j == i || j == k {
+					continue
+				}
+				paths[i][j] = max(paths[i][j], min(paths[i][k], paths[k][j]))
+			}
+		}
+	}
+
+	winners := []string{}
+	for _, i := range candidates {
+		if !counted {
+			break
+		}
+		beaten := false
+		for _, j := range candidates {
+			if i != j && paths[j][i] > paths[i][j] {
+				beaten = true
+				break
+			}
+		}
+		if !beaten {
+			winners = append(winners, i)
+		}
+	}
+	return &Result{
+		Candidates: candidates,
+		Rounds: []Round{},
+		Winners: winners,
+		Pairwise: matrix,
+		StrongestPaths: paths,
+	}
+}
+
+// RankedPairs sorts every pairwise win from strongest to weakest and locks
+// each in unless it would create a cycle with the wins already locked. The
+// candidates no locked pair beats win. Wins are ordered by votes for the
+// winner, then by fewest votes against, then by candidate order.
+func RankedPairs(candidates []string, ballots [][]string) *Result {
+	return rankedPairs(candidates, PairwiseMatrix(candidates, ballots), len(ballots) > 0)
+}
+
+// rankedPairs locks in the wins of the pairwise matrix; without any ballots
+// counted there is no winner.
+func rankedPairs(candidates []string, matrix map[string]map[string]float64, counted bool) *Result {
+	order := make(map[string]int, len(candidates))
+	for i, candidate := range candidates {
+		order[candidate] = i
+	}
+
+	var pairs []Pair
+	for _, i := range candidates {
+		for _, j := range candidates {
+			if i != j && matrix[i][j] > matrix[j][i] {
+				pairs = append(pairs, Pair{Winner: i, Loser: j, Votes: matrix[i][j], Against: matrix[j][i]})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(a, b int) bool {
+		if pairs[a].Votes != pairs[b].Votes {
+			return pairs[a].Votes > pairs[b].Votes
+		}
+		if pairs[a].Against != pairs[b].Against {
+			return pairs[a].Against < pairs[b].Against
+		}
+		if pairs[a].Winner != pairs[b].Winner {
+			return order[pairs[a].Winner] < order[pairs[b].Winner]
+		}
+		return order[pairs[a].Loser] < order[pairs[b].Loser]
+	})
+
+	locked := []Pair{}
+	beats := make(map[string][]string, len(candidates))
+	for _, pair := range pairs {
+		if reaches(beats, pair.Loser, pair.Winner) {
+			continue
+		}
+		beats[pair.Winner] = append(beats[pair.Winner], pair.Loser)
+		locked = append(locked, pair)
+	}
+
+	beaten := make(map[string]bool, len(candidates))
+	for _, pair := range locked {
+		beaten[pair.Loser] = true
+	}
+	winners := []string{}
+	for _, candidate := range candidates {
+		if counted && !beaten[candidate] {
+			winners = append(winners, candidate)
+		}
+	}
+	return &Result{
+		Candidates: candidates,
+		Rounds: []Round{},
+		Winners: winners,
+		Pairwise: matrix,
+		LockedPairs: locked,
+	}
+}
+
+// reaches reports whether locked wins lead from one candidate to another.
+func reaches(beats map[string][]string, from string, to string) bool {
+	if from == to {
+		return true
+	}
+	visited := map[string]bool{from: true}
+	pending := []string{from}
+	for len(pending) > 0 {
+		current := pending[len(pending) - 1]
+		pending = pending[:len(pending) - 1]
+		for _, next := range beats[current] {
+			if next == to {
+				return true
+			}
+			if !visited[next] {
+				visited[next] = true
+				pending = append(pending, next)
+			}
+		}
+	}
+	return false
+}
+
+type schulzeMethod struct{}
+
+func (schulzeMethod) Validate(contest Contest, ballot Ballot) error {
+	return validateRanking(contest.Candidates, ballot)
+}
+
+func (schulzeMethod) Tally(contest Contest, ballots []Ballot) *Result {
+	matrix := pairwiseMatrix(contest.Candidates, rankings(ballots), weights(ballots))
+	return schulze(contest.Candidates, matrix, len(ballots) > 0)
+}
+
+type rankedPairsMethod struct{}
+
+func (rankedPairsMethod) Validate(contest Contest, ballot Ballot) error {
+	return validateRanking(contest.Candidates, ballot)
+}
+
+func (rankedPairsMethod) Tally(contest Contest, ballots []Ballot) *Result {
+	matrix := pairwiseMatrix(contest.Candidates, rankings(ballots), weights(ballots))
+	return rankedPairs(contest.Candidates, matrix, len(ballots) > 0)
+}
+
+func validateRanking(candidates []string, ballot Ballot) error {
+	if ballot.kinds() != rankingsKind {
+		return fmt.Errorf("%w: rank one or more candidates", ErrInvalidBallot)
+	}
+	return validateSelection(candidates, ballot.Rankings)
+}
@@ -0,0 +1,148 @@
+package tally_test
+
+import (
+	"slices"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/tally"
+)
+
+// schulzeExample is the 45 voter example from Schulze's paper, won by e.
+func schulzeExample() ([]string, [][]string) {
+	candidates := []string{"a", "b", "c", "d", "e"}
+	ballots := join(
+		repeat([]string{"a", "c", "b", "e", "d"}, 5),
+		repeat([]string{"a", "d", "e", "c", "b"}, 5),
+		repeat([]string{"b", "e", "d", "a", "c"}, 8),
+		repeat([]string{"c", "a", "b", "e", "d"}, 3),
+		repeat([]string{"c", "a", "e", "b", "d"}, 7),
+		repeat([]string{"c", "b", "a", "d", "e"}, 2),
+		repeat([]string{"d", "c", "e", "b", "a"}, 7),
+		repeat([]string{"e", "b", "a", "d", "c"}, 8),
+	)
+	return candidates, ballots
+}
+
+func TestPairwiseMatrix(t *testing.T) {
+	candidates, ballots := schulzeExample()
+	matrix := tally.PairwiseMatrix(candidates, ballots)
+
+	expected := map[string]map[string]float64{
+		"a": {"b": 20, "c": 26, "d": 30, "e": 22},
+		"b": {"a": 25, "c": 16, "d": 33, "e": 18},
+		"c": {"a": 19, "b": 29, "d": 17, "e": 24},
+		"d": {"a": 15, "b": 12, "c": 28, "e": 14},
+		"e": {"a": 23, "b": 27, "c": 21, "d": 31},
+	}
+	for i, row := range expected {
+		for j, votes := range row {
+			if matrix[i][j] != votes {
+				t.Errorf("Expected %s over %s: %v but got %v", i, j, votes, matrix[i][j])
+			}
+		}
+	}
+}
+
+func TestPairwiseMatrixShouldPreferRankedOverUnranked(t *testing.T) {
+	matrix := tally.PairwiseMatrix([]string{"a", "b", "c"}, [][]string{{"b"}})
+
+	if matrix["b"]["a"] != 1 || matrix["b"]["c"] != 1 {
+		t.Errorf("Expected b to be preferred to unranked candidates but got %v", matrix["b"])
+	}
+	if matrix["a"]["c"] != 0 || matrix["c"]["a"] != 0 {
+		t.Errorf("Expected unranked candidates to be tied but got %v and %v", matrix["a"], matrix["c"])
+	}
+}
+
+func TestSchulze(t *testing.T) {
+	candidates, ballots := schulzeExample()
+	result := tally.Schulze(candidates, ballots)
+
+	expected := map[string]map[string]float64{
+		"a": {"b": 28, "c": 28, "d": 30, "e": 24},
+		"b": {"a": 25, "c": 28, "d": 33, "e": 24},
+		"c": {"a": 25, "b": 29, "d": 29, "e": 24},
+		"d": {"a": 25, "b": 28, "c": 28, "e": 24},
+		"e": {"a": 25, "b": 28, "c": 28, "d": 31},
+	}
+	for i, row := range expected {
+		for j, strength := range row {
+			if result.StrongestPaths[i][j] != strength {
+				t.Errorf("Expected strongest path from %s to %s: %v but got %v", i, j, strength, result.StrongestPaths[i][j])
+			}
+		}
+	}
+	if !slices.Equal(result.Winners, []string{"e"}) {
+		t.Errorf("Expected winners: %v but got %v", []string{"e"}, result.Winners)
+	}
+	if result.Pairwise["e"]["d"] != 31 {
+		t.Errorf("Expected pairwise matrix in result but got %v", result.Pairwise)
+	}
+}
+
+func TestCondorcetMethods(t *testing.T) {
+	tests := []struct {
+		name string
+		candidates []string
+		ballots [][]string
+		winners []string
+	}{
+		{
+			"No ballots",
+			[]string{"a", "b"},
+			nil,
+			[]string{},
+		},
+		{
+			"Condorcet winner",
+			[]string{"memphis", "nashville", "chattanooga", "knoxville"},
+			join(
+				repeat([]string{"memphis", "nashville", "chattanooga", "knoxville"}, 42),
+				repeat([]string{"nashville", "chattanooga", "knoxville", "memphis"}, 26),
+				repeat([]string{"chattanooga", "knoxville", "nashville", "memphis"}, 15),
+				repeat([]string{"knoxville", "chattanooga", "nashville", "memphis"}, 17),
+			),
+			[]string{"nashville"},
+		},
+		{
+			"Cycle broken at its weakest win",
+			[]string{"a", "b", "c"},
+			join(repeat([]string{"a", "b", "c"}, 7), repeat([]string{"b", "c", "a"}, 5), repeat([]string{"c", "a", "b"}, 4)),
+			[]string{"a"},
+		},
+		{
+			"Tied candidates share the win",
+			[]string{"a", "b", "c"},
+			[][]string{{"a", "b", "c"}, {"b", "a", "c"}},
+			[]string{"a", "b"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schulze := tally.Schulze(test.candidates, test.ballots)
+			if !slices.Equal(schulze.Winners, test.winners) {
+				t.Errorf("Expected Schulze winners: %v but got %v", test.winners, schulze.Winners)
+			}
+			rankedPairs := tally.RankedPairs(test.candidates, test.ballots)
+			if !slices.Equal(rankedPairs.Winners, test.winners) {
+				t.Errorf("Expected ranked pairs winners: %v but got %v", test.winners, rankedPairs.Winners)
+			}
+		})
+	}
+}
+
+func TestRankedPairsShouldSkipPairsThatCreateCycles(t *testing.T) {
+	candidates := []string{"a", "b", "c"}
+	ballots := join(repeat([]string{"a", "b", "c"}, 7), repeat([]string{"b", "c", "a"}, 5), repeat([]string{"c", "a", "b"}, 4))
+
+	result := tally.RankedPairs(candidates, ballots)
+
+	expected := []tally.Pair{
+		{Winner: "b", Loser: "c", Votes: 12, Against: 4},
+		{Winner: "a", Loser: "b", Votes: 11, Against: 5},
+	}
+	if !slices.Equal(result.LockedPairs, expected) {
+		t.Errorf("Expected locked pairs: %v but got %v", expected, result.LockedPairs)
+	}
+}
@@ -0,0 +1,76 @@
+package tally
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Cumulative sums the votes each ballot spreads across the candidates and
+// elects the contest.Seats candidates with the most. Ties for the last seat
+// go to the candidate listed first, and candidates without votes are never
+// elected.
+//
+// A ballot's weight is already reflected in the votes it may spread, so
+// ballots are not weighted again when counted.
+func Cumulative(contest Contest, ballots []map[string]int) *Result {
+	counts := emptyCounts(contest.Candidates)
+	for _, votes := range ballots {
+		for candidate, k := range votes {
+			if _, ok := counts[candidate]; ok {
+				counts[candidate] += float64(k)
+			}
+		}
+	}
+
+	var ranked []string
+	for _, candidate := range contest.Candidates {
+		if counts[candidate] > 0 {
+			ranked = append(ranked, candidate)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return counts[ranked[i]] > counts[ranked[j]]
+	})
+	winners := ranked[:min(len(ranked), max(contest.Seats, 1))]
+	return &Result{
+		Candidates: contest.Candidates,
+		Rounds: []Round{{Number: 1, Counts: counts}},
+		Winners: append([]string{}, winners...),
+	}
+}
+
+type cumulativeMethod struct{}
+
+// Validate rejects ballots spreading more than their weight times the seats.
+// Each vote is checked before it is added, so huge votes cannot overflow the
+// sum into an allowed one.
+func (cumulativeMethod) Validate(contest Contest, ballot Ballot) error {
+	if ballot.kinds() != votesKind {
+		return fmt.Errorf("%w: give votes to one or more candidates", ErrInvalidBallot)
+	}
+	allowed := max(ballot.Weight, 1) * max(contest.Seats, 1)
+	spread := 0
+	voted := make([]string, 0, len(ballot.Votes))
+	for candidate, votes := range ballot.Votes {
+		if votes < 0 {
+			return fmt.Errorf("%w: votes cannot be negative", ErrInvalidBallot)
+		}
+		if votes > allowed {
+			return fmt.Errorf("%w: %d votes for one candidate is more than the %d allowed", ErrInvalidBallot, votes, allowed)
+		}
+		spread += votes
+		if spread > allowed {
+			return fmt.Errorf("%w: ballot spreads more than %d votes", ErrInvalidBallot, allowed)
+		}
+		voted = append(voted, candidate)
+	}
+	return validateSelection(contest.Candidates, voted)
+}
+
+func (cumulativeMethod) Tally(contest Contest, ballots []Ballot) *Result {
+	votes := make([]map[string]int, len(ballots))
+	for i, ballot := range ballots {
+		votes[i] = ballot.Votes
+	}
+	return Cumulative(contest, votes)
+}
@@ -0,0 +1,65 @@
+package tally_test
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/tally"
+)
+
+func TestCumulative(t *testing.T) {
+	contest := tally.Contest{Candidates: []string{"a", "b", "c", "d"}, Seats: 2}
+	tests := []struct {
+		name string
+		ballots []map[string]int
+		winners []string
+	}{
+		{"Minority concentrates its votes", []map[string]int{{"a": 30, "b": 30}, {"a": 10, "b": 10}, {"c": 50}}, []string{"c", "a"}},
+		{"Tie for the last seat goes to the first candidate", []map[string]int{{"a": 6}, {"b": 3, "c": 3}}, []string{"a", "b"}},
+		{"Fewer candidates with votes than seats", []map[string]int{{"d": 4}}, []string{"d"}},
+		{"No ballots", nil, []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := tally.Cumulative(contest, test.ballots)
+			if !slices.Equal(result.Winners, test.winners) {
+				t.Errorf("Expected winners: %v but got %v", test.winners, result.Winners)
+			}
+		})
+	}
+}
+
+func TestCumulativeValidate(t *testing.T) {
+	contest := tally.Contest{Candidates: []string{"a", "b", "c"}, Seats: 3}
+	tests := []struct {
+		name string
+		ballot tally.Ballot
+		expected error
+	}{
+		{"Spreads every vote", tally.Ballot{Votes: map[string]int{"a": 200, "b": 100}, Weight: 100}, nil},
+		{"Concentrates every vote", tally.Ballot{Votes: map[string]int{"c": 300}, Weight: 100}, nil},
+		{"Spreads more than weight times seats", tally.Ballot{Votes: map[string]int{"a": 200, "b": 101}, Weight: 100}, tally.ErrInvalidBallot},
+		{"Unweighted ballot spreads one vote per seat", tally.Ballot{Votes: map[string]int{"a": 2, "b": 1}}, nil},
+		{"Unweighted ballot overspreads", tally.Ballot{Votes: map[string]int{"a": 4}}, tally.ErrInvalidBallot},
+		{"Votes whose sum overflows", tally.Ballot{Votes: map[string]int{"a": math.MaxInt64, "b": 1}}, tally.ErrInvalidBallot},
+		{"Negative votes", tally.Ballot{Votes: map[string]int{"a": -1}}, tally.ErrInvalidBallot},
+		{"Unknown candidate", tally.Ballot{Votes: map[string]int{"d": 1}}, tally.ErrInvalidCandidate},
+		{"Ranking instead of votes", tally.Ballot{Rankings: []string{"a"}}, tally.ErrInvalidBallot},
+	}
+
+	method, err := tally.Lookup("cumulative")
+	if err != nil {
+		t.Fatal("Could not look up method", err.Error())
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := method.Validate(contest, test.ballot)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
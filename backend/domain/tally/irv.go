@@ -0,0 +1,120 @@
+package tally
+
+// InstantRunoff counts ranked ballots round by round. Each ballot counts for
+// its highest ranked candidate still in the running. A candidate wins once
+// they hold more than half of the ballots that are not exhausted; otherwise
+// the candidate with the fewest votes is eliminated and the next round is
+// counted. Rankings of unknown candidates are ignored.
+//
+// Ties for elimination are broken backwards: the tied candidate with fewer
+// votes in the previous round is eliminated, looking further back until the
+// tie is broken. Candidates tied in every round are eliminated in reverse
+// ballot order, so the candidate listed last in candidates goes first. A tie
+// between the final two candidates is resolved the same way.
+//
+// Without any countable ballots there is no winner.
+func InstantRunoff(candidates []string, ballots [][]string) *Result {
+	return instantRunoff(candidates, ballots, nil)
+}
+
+func instantRunoff(candidates []string, ballots [][]string, weights []float64) *Result {
+	total := 0.0
+	for i := range ballots {
+		total += weightOf(weights, i)
+	}
+	result := &Result{Candidates: candidates, Rounds: []Round{}, Winners: []string{}}
+	continuing := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		continuing[candidate] = true
+	}
+
+	for number := 1; len(continuing) > 0; number++ {
+		round := Round{Number: number, Counts: make(map[string]float64, len(continuing))}
+		for candidate := range continuing {
+			round.Counts[candidate] = 0
+		}
+		for i, ballot := range ballots {
+			choice, ok := firstContinuing(ballot, continuing)
+			if !ok {
+				round.Exhausted += weightOf(weights, i)
+				continue
+			}
+			round.Counts[choice] += weightOf(weights, i)
+		}
+		active := total - round.Exhausted
+		if active == 0 {
+			result.Rounds = append(result.Rounds, round)
+			return result
+		}
+
+		for candidate, votes := range round.Counts {
+			if votes * 2 > active {
+				result.Rounds = append(result.Rounds, round)
+				result.Winners = []string{candidate}
+				return result
+			}
+		}
+
+		round.Eliminated = lowest(candidates, continuing, round.Counts, result.Rounds)
+		delete(continuing, round.Eliminated)
+		result.Rounds = append(result.Rounds, round)
+	}
+	return result
+}
+
+func firstContinuing(ballot []string, continuing map[string]bool) (string, bool) {
+	for _, candidate := range ballot {
+		if continuing[candidate] {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// lowest picks the continuing candidate to eliminate, breaking ties as
+// described on InstantRunoff.
+func lowest(candidates []string, continuing map[string]bool, counts map[string]float64, previous []Round) string {
+	var remaining []string
+	for _, candidate := range candidates {
+		if continuing[candidate] {
+			remaining = append(remaining, candidate)
+		}
+	}
+	tied := fewest(remaining, counts)
+	for i := len(previous) - 1; i >= 0 && len(tied) > 1; i-- {
+		tied = fewest(tied, previous[i].Counts)
+	}
+	return tied[len(tied) - 1]
+}
+
+// fewest keeps the candidates with the fewest votes in counts, preserving
+// their order.
+func fewest(candidates []string, counts map[string]float64) []string {
+	var kept []string
+	for _, candidate := range candidates {
+		if len(kept) == 0 || counts[candidate] < counts[kept[0]] {
+			kept = []string{candidate}
+		} else if counts[candidate] == counts[kept[0]] {
+			kept = append(kept, candidate)
+		}
+	}
+	return kept
+}
+
+type instantRunoffMethod struct{}
+
+func (instantRunoffMethod) Validate(contest Contest, ballot Ballot) error {
+	return validateRanking(contest.Candidates, ballot)
+}
+
+func (instantRunoffMethod) Tally(contest Contest, ballots []Ballot) *Result {
+	return instantRunoff(contest.Candidates, rankings(ballots), weights(ballots))
+}
+
+func rankings(ballots []Ballot) [][]string {
+	ranked := make([][]string, len(ballots))
+	for i, ballot := range ballots {
+		ranked[i] = ballot.Rankings
+	}
+	return ranked
+}
@@ -0,0 +1,200 @@
+package tally_test
+
+import (
+	"slices"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/tally"
+)
+
+func repeat(ballot []string, times int) [][]string {
+	ballots := make([][]string, times)
+	for i := range ballots {
+		ballots[i] = ballot
+	}
+	return ballots
+}
+
+func join(groups ...[][]string) [][]string {
+	var ballots [][]string
+	for _, group := range groups {
+		ballots = append(ballots, group...)
+	}
+	return ballots
+}
+
+func TestInstantRunoff(t *testing.T) {
+	tests := []struct {
+		name string
+		candidates []string
+		ballots [][]string
+		winners []string
+		eliminated []string
+	}{
+		{
+			"No candidates",
+			nil,
+			[][]string{{"a"}},
+			[]string{},
+			nil,
+		},
+		{
+			"No ballots",
+			[]string{"a", "b"},
+			nil,
+			[]string{},
+			nil,
+		},
+		{
+			"Single candidate",
+			[]string{"a"},
+			[][]string{{"a"}},
+			[]string{"a"},
+			nil,
+		},
+		{
+			"Majority in first round",
+			[]string{"a", "b", "c"},
+			join(repeat([]string{"a"}, 3), repeat([]string{"b"}, 1), repeat([]string{"c"}, 1)),
+			[]string{"a"},
+			nil,
+		},
+		{
+			"Exactly half is not a majority",
+			[]string{"a", "b", "c"},
+			join(repeat([]string{"a"}, 2), repeat([]string{"b", "c"}, 1), repeat([]string{"c", "b"}, 1)),
+			[]string{"a"},
+			[]string{"c", "b"},
+		},
+		{
+			"Eliminated votes transfer to next preference",
+			[]string{"a", "b", "c"},
+			join(repeat([]string{"a"}, 4), repeat([]string{"b", "c"}, 3), repeat([]string{"c", "b"}, 2)),
+			[]string{"b"},
+			[]string{"c"},
+		},
+		{
+			"Exhausted ballots do not count towards the majority",
+			[]string{"a", "b", "c"},
+			join(repeat([]string{"a"}, 4), repeat([]string{"b"}, 3), repeat([]string{"c"}, 2)),
+			[]string{"a"},
+			[]string{"c"},
+		},
+		{
+			"Unknown candidates are skipped",
+			[]string{"a", "b"},
+			join(repeat([]string{"x", "a"}, 2), repeat([]string{"b"}, 1)),
+			[]string{"a"},
+			nil,
+		},
+		{
+			"Tie for last is broken by the previous round",
+			[]string{"a", "b", "c", "d"},
+			join(
+				repeat([]string{"a"}, 6),
+				repeat([]string{"b"}, 3),
+				repeat([]string{"c"}, 4),
+				repeat([]string{"d", "b"}, 1),
+			),
+			[]string{"a"},
+			[]string{"d", "b"},
+		},
+		{
+			"Tie without history eliminates the last listed candidate",
+			[]string{"a", "b", "c"},
+			join(repeat([]string{"a"}, 2), repeat([]string{"b"}, 1), repeat([]string{"c", "b"}, 1)),
+			[]string{"a"},
+			[]string{"c", "b"},
+		},
+		{
+			"Final two tied resolves to the earlier listed candidate",
+			[]string{"a", "b"},
+			join(repeat([]string{"a"}, 2), repeat([]string{"b"}, 2)),
+			[]string{"a"},
+			[]string{"b"},
+		},
+		{
+			"Candidates without votes are eliminated first",
+			[]string{"a", "b", "c"},
+			join(repeat([]string{"c"}, 1), repeat([]string{"b"}, 1), repeat([]string{"x"}, 1)),
+			[]string{"b"},
+			[]string{"a", "c"},
+		},
+		{
+			"Only unknown candidates ranked",
+			[]string{"a", "b"},
+			[][]string{{"x"}, {"y", "z"}},
+			[]string{},
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := tally.InstantRunoff(test.candidates, test.ballots)
+			if !slices.Equal(result.Winners, test.winners) {
+				t.Errorf("Expected winners: %v but got %v", test.winners, result.Winners)
+			}
+			var eliminated []string
+			for _, round := range result.Rounds {
+				if round.Eliminated != "" {
+					eliminated = append(eliminated, round.Eliminated)
+				}
+			}
+			if test.eliminated != nil && !slices.Equal(eliminated, test.eliminated) {
+				t.Errorf("Expected eliminations: %v but got %v", test.eliminated, eliminated)
+			}
+		})
+	}
+}
+
+func TestInstantRunoffRounds(t *testing.T) {
+	candidates := []string{"a", "b", "c"}
+	ballots := join(
+		repeat([]string{"a"}, 4),
+		repeat([]string{"b", "c"}, 3),
+		repeat([]string{"c", "b"}, 2),
+		repeat([]string{"c"}, 1),
+	)
+
+	result := tally.InstantRunoff(candidates, ballots)
+
+	if len(result.Rounds) != 2 {
+		t.Fatalf("Expected %d rounds but got %d", 2, len(result.Rounds))
+	}
+	first, second := result.Rounds[0], result.Rounds[1]
+	if first.Number != 1 || first.Counts["a"] != 4 || first.Counts["b"] != 3 || first.Counts["c"] != 3 || first.Eliminated != "c" {
+		t.Errorf("Unexpected first round: %+v", first)
+	}
+	if second.Number != 2 || second.Counts["a"] != 4 || second.Counts["b"] != 5 || second.Exhausted != 1 {
+		t.Errorf("Unexpected second round: %+v", second)
+	}
+	if _, counted := second.Counts["c"]; counted {
+		t.Error("Eliminated candidate was counted in a later round")
+	}
+	if !slices.Equal(result.Winners, []string{"b"}) {
+		t.Errorf("Expected winners: %v but got %v", []string{"b"}, result.Winners)
+	}
+}
+
+func TestPlurality(t *testing.T) {
+	tests := []struct {
+		name string
+		choices []string
+		winners []string
+	}{
+		{"No votes", nil, []string{}},
+		{"Single winner", []string{"a", "b", "a"}, []string{"a"}},
+		{"Tie", []string{"a", "b", "c", "b", "c"}, []string{"b", "c"}},
+		{"Unknown candidates are ignored", []string{"x", "x", "c"}, []string{"c"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := tally.Plurality([]string{"a", "b", "c"}, test.choices)
+			if !slices.Equal(result.Winners, test.winners) {
+				t.Errorf("Expected winners: %v but got %v", test.winners, result.Winners)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package tally
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownMethod = errors.New("Voting method is not supported")
+	ErrInvalidBallot = errors.New("Ballot does not match the voting method of this election")
+	ErrInvalidCandidate = errors.New("Candidate is not on the ballot for this election")
+	ErrInvalidList = errors.New("Party list is not on the ballot for this election")
+)
+
+// MaxScore is the highest score a score ballot may give a candidate.
+const MaxScore = 10
+
+// Ballot is one voter's ballot. Which fields are filled in depends on the
+// voting method: a single Choice, Rankings from most to least preferred, the
+// set of Approvals, Scores per candidate, a party List or the number of Votes
+// given to each candidate. Weight is the number of votes the ballot carries,
+// such as a shareholder's share count; unweighted ballots count once.
+type Ballot struct {
+	Choice string
+	Rankings []string
+	Approvals []string
+	Scores map[string]int
+	List string
+	Votes map[string]int
+	Weight int
+}
+
+// Round is one count of the ballots. Counts holds the votes of every
+// candidate still in the running, Exhausted the ballots that no longer rank
+// any of them. Multi-winner counts also report the quota, the candidates
+// elected in the round, whose surplus is transferred after it and the votes
+// moved into the round.
+type Round struct {
+	Number int
+	Counts map[string]float64
+	Exhausted float64
+	Eliminated string `json:",omitempty"`
+	Quota float64 `json:",omitempty"`
+	Elected []string `json:",omitempty"`
+	Surplus string `json:",omitempty"`
+	Transfers map[string]float64 `json:",omitempty"`
+	// Excluded holds the party lists that fell below the threshold.
+	Excluded []string `json:",omitempty"`
+}
+
+// Result is the outcome of a count. Condorcet methods also report the
+// pairwise preference matrix, where Pairwise[a][b] is the number of ballots
+// preferring a to b, along with the strongest path table or the locked pairs
+// behind their winners. Party-list methods report the seats won by each list
+// and quadratic voting the credits spent on each candidate.
+type Result struct {
+	Candidates []string
+	Rounds []Round
+	Winners []string
+	Pairwise map[string]map[string]float64 `json:",omitempty"`
+	StrongestPaths map[string]map[string]float64 `json:",omitempty"`
+	LockedPairs []Pair `json:",omitempty"`
+	Seats map[string]int `json:",omitempty"`
+	Credits map[string]int `json:",omitempty"`
+}
+
+// Contest describes what is being decided: the candidates on the ballot, in
+// the order used to break ties, the party lists they stand for and the
+// options of the voting method. Threshold is the percentage of votes a list
+// needs to win seats and Credits the budget of each quadratic ballot.
+type Contest struct {
+	Candidates []string
+	Lists []List
+	Seats int
+	SurplusTransfer string
+	Threshold float64
+	Credits int
+}
+
+// Method validates and counts ballots for one voting method.
+type Method interface {
+	// Validate reports why the ballot cannot be counted in the contest,
+	// wrapping ErrInvalidBallot or ErrInvalidCandidate.
+	Validate(contest Contest, ballot Ballot) error
+	// Tally counts valid ballots.
+	Tally(contest Contest, ballots []Ballot) *Result
+}
+
+var methods = map[string]Method{
+	"plurality": pluralityMethod{},
+	"irv": instantRunoffMethod{},
+	"approval": approvalMethod{},
+	"borda": bordaMethod{},
+	"score": scoreMethod{},
+	"schulze": schulzeMethod{},
+	"ranked_pairs": rankedPairsMethod{},
+	"stv": stvMethod{},
+	"dhondt": partyListMethod{allocate: DHondt},
+	"sainte_lague": partyListMethod{allocate: SainteLague},
+	"hare": partyListMethod{allocate: Hare},
+	"quadratic": quadraticMethod{},
+	"cumulative": cumulativeMethod{},
+}
+
+// Lookup returns the method registered under name.
+func Lookup(name string) (Method, error) {
+	method, ok := methods[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMethod, name)
+	}
+	return method, nil
+}
+
+// kind names the parts of a ballot that are filled in, so methods can reject
+// ballots meant for another method.
+type kind int
+
+const (
+	choiceKind kind = 1 << iota
+	rankingsKind
+	approvalsKind
+	scoresKind
+	listKind
+	votesKind
+)
+
+func (ballot Ballot) kinds() kind {
+	var filled kind
+	if ballot.Choice != "" {
+		filled |= choiceKind
+	}
+	if len(ballot.Rankings) > 0 {
+		filled |= rankingsKind
+	}
+	if len(ballot.Approvals) > 0 {
+		filled |= approvalsKind
+	}
+	if len(ballot.Scores) > 0 {
+		filled |= scoresKind
+	}
+	if ballot.List != "" {
+		filled |= listKind
+	}
+	if len(ballot.Votes) > 0 {
+		filled |= votesKind
+	}
+	return filled
+}
+
+// weights returns how much each ballot counts.
+func weights(ballots []Ballot) []float64 {
+	counted := make([]float64, len(ballots))
+	for i, ballot := range ballots {
+		counted[i] = float64(max(ballot.Weight, 1))
+	}
+	return counted
+}
+
+// weightOf returns the weight of the ballot at index i. Without weights every
+// ballot counts once.
+func weightOf(weights []float64, i int) float64 {
+	if weights == nil {
+		return 1
+	}
+	return weights[i]
+}
+
+// IsEmpty reports whether the ballot selects nothing at all.
+func (ballot Ballot) IsEmpty() bool {
+	return ballot.kinds() == 0
+}
+
+// validateSelection checks that every selected candidate is on the ballot
+// and selected at most once.
+func validateSelection(candidates []string, selection []string) error {
+	onBallot := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		onBallot[candidate] = true
+	}
+	selected := make(map[string]bool, len(selection))
+	for _, candidate := range selection {
+		if !onBallot[candidate] {
+			return fmt.Errorf("%w: %s", ErrInvalidCandidate, candidate)
+		}
+		if selected[candidate] {
+			return fmt.Errorf("%w: candidate %s is selected more than once", ErrInvalidBallot, candidate)
+		}
+		selected[candidate] = true
+	}
+	return nil
+}
+
+// mostVotes returns every candidate holding the highest positive count, in
+// candidate order.
+func mostVotes(candidates []string, counts map[string]float64) []string {
+	winners := []string{}
+	for _, candidate := range candidates {
+		votes := counts[candidate]
+		if votes <= 0 {
+			continue
+		}
+		if len(winners) == 0 || votes > counts[winners[0]] {
+			winners = []string{candidate}
+		} else if votes == counts[winners[0]] {
+			winners = append(winners, candidate)
+		}
+	}
+	return winners
+}
+
+// singleRound builds the result of a method that counts ballots once.
+func singleRound(candidates []string, counts map[string]float64) *Result {
+	round := Round{Number: 1, Counts: counts}
+	return &Result{Candidates: candidates, Rounds: []Round{round}, Winners: mostVotes(candidates, counts)}
+}
+
+func emptyCounts(candidates []string) map[string]float64 {
+	counts := make(map[string]float64, len(candidates))
+	for _, candidate := range candidates {
+		counts[candidate] = 0
+	}
+	return counts
+}
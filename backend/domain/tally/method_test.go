@@ -0,0 +1,192 @@
+package tally_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/tally"
+)
+
+func TestLookupShouldFindEveryElectionMethod(t *testing.T) {
+	for _, method := range []election.VotingMethod{election.Plurality, election.InstantRunoff, election.Approval, election.Borda, election.Score, election.Schulze, election.RankedPairs, election.SingleTransferableVote, election.DHondt, election.SainteLague, election.Hare, election.Quadratic, election.Cumulative} {
+		if !method.IsValid() {
+			t.Errorf("Expected %s to be a valid election method", method)
+		}
+		if _, err := tally.Lookup(string(method)); err != nil {
+			t.Errorf("Expected a tally method for %s but got %v", method, err)
+		}
+	}
+	if _, err := tally.Lookup("coin-toss"); !errors.Is(err, tally.ErrUnknownMethod) {
+		t.Errorf("Expected error: %v but got %v", tally.ErrUnknownMethod, err)
+	}
+}
+
+func TestMethodTally(t *testing.T) {
+	candidates := []string{"a", "b", "c"}
+	tests := []struct {
+		name string
+		method string
+		ballots []tally.Ballot
+		counts map[string]float64
+		winners []string
+	}{
+		{
+			"Plurality",
+			"plurality",
+			[]tally.Ballot{{Choice: "a"}, {Choice: "c"}, {Choice: "c"}},
+			map[string]float64{"a": 1, "b": 0, "c": 2},
+			[]string{"c"},
+		},
+		{
+			"Approval",
+			"approval",
+			[]tally.Ballot{{Approvals: []string{"a", "b"}}, {Approvals: []string{"b"}}, {Approvals: []string{"c", "a", "b"}}},
+			map[string]float64{"a": 2, "b": 3, "c": 1},
+			[]string{"b"},
+		},
+		{
+			"Approval tie",
+			"approval",
+			[]tally.Ballot{{Approvals: []string{"a", "c"}}, {Approvals: []string{"c", "a"}}},
+			map[string]float64{"a": 2, "b": 0, "c": 2},
+			[]string{"a", "c"},
+		},
+		{
+			"Borda",
+			"borda",
+			[]tally.Ballot{{Rankings: []string{"a", "b", "c"}}, {Rankings: []string{"b", "c", "a"}}, {Rankings: []string{"b", "a"}}},
+			map[string]float64{"a": 3, "b": 5, "c": 1},
+			[]string{"b"},
+		},
+		{
+			"Borda partial ranking only scores ranked candidates",
+			"borda",
+			[]tally.Ballot{{Rankings: []string{"c"}}, {Rankings: []string{"a", "b"}}},
+			map[string]float64{"a": 2, "b": 1, "c": 2},
+			[]string{"a", "c"},
+		},
+		{
+			"Score",
+			"score",
+			[]tally.Ballot{{Scores: map[string]int{"a": 10, "b": 4}}, {Scores: map[string]int{"b": 9, "c": 2}}},
+			map[string]float64{"a": 10, "b": 13, "c": 2},
+			[]string{"b"},
+		},
+		{
+			"Score with only zero scores",
+			"score",
+			[]tally.Ballot{{Scores: map[string]int{"a": 0}}},
+			map[string]float64{"a": 0, "b": 0, "c": 0},
+			[]string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			method, err := tally.Lookup(test.method)
+			if err != nil {
+				t.Fatal("Could not look up method", err.Error())
+			}
+			result := method.Tally(tally.Contest{Candidates: candidates, Seats: 1}, test.ballots)
+			if len(result.Rounds) != 1 {
+				t.Fatalf("Expected %d rounds but got %d", 1, len(result.Rounds))
+			}
+			for candidate, count := range test.counts {
+				if result.Rounds[0].Counts[candidate] != count {
+					t.Errorf("Expected %s to have %v but got %v", candidate, count, result.Rounds[0].Counts[candidate])
+				}
+			}
+			if !slices.Equal(result.Winners, test.winners) {
+				t.Errorf("Expected winners: %v but got %v", test.winners, result.Winners)
+			}
+		})
+	}
+}
+
+func TestMethodValidate(t *testing.T) {
+	candidates := []string{"a", "b", "c"}
+	tests := []struct {
+		name string
+		method string
+		ballot tally.Ballot
+		expected error
+	}{
+		{"Plurality choice", "plurality", tally.Ballot{Choice: "a"}, nil},
+		{"Plurality without a choice", "plurality", tally.Ballot{}, tally.ErrInvalidBallot},
+		{"Plurality unknown candidate", "plurality", tally.Ballot{Choice: "d"}, tally.ErrInvalidCandidate},
+		{"Ranking", "irv", tally.Ballot{Rankings: []string{"b", "a"}}, nil},
+		{"Ranking with a choice", "irv", tally.Ballot{Choice: "a", Rankings: []string{"a"}}, tally.ErrInvalidBallot},
+		{"Schulze ranking", "schulze", tally.Ballot{Rankings: []string{"c", "a"}}, nil},
+		{"Ranked pairs without rankings", "ranked_pairs", tally.Ballot{Choice: "a"}, tally.ErrInvalidBallot},
+		{"Ranking repeats a candidate", "borda", tally.Ballot{Rankings: []string{"a", "a"}}, tally.ErrInvalidBallot},
+		{"Approvals", "approval", tally.Ballot{Approvals: []string{"c"}}, nil},
+		{"No approvals", "approval", tally.Ballot{}, tally.ErrInvalidBallot},
+		{"Approvals as rankings", "approval", tally.Ballot{Rankings: []string{"c"}}, tally.ErrInvalidBallot},
+		{"Scores", "score", tally.Ballot{Scores: map[string]int{"a": 0, "b": tally.MaxScore}}, nil},
+		{"Score out of range", "score", tally.Ballot{Scores: map[string]int{"a": tally.MaxScore + 1}}, tally.ErrInvalidBallot},
+		{"Score for unknown candidate", "score", tally.Ballot{Scores: map[string]int{"d": 1}}, tally.ErrInvalidCandidate},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			method, err := tally.Lookup(test.method)
+			if err != nil {
+				t.Fatal("Could not look up method", err.Error())
+			}
+			err = method.Validate(tally.Contest{Candidates: candidates, Seats: 1}, test.ballot)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestMethodTallyShouldSumBallotWeights(t *testing.T) {
+	candidates := []string{"a", "b", "c"}
+	tests := []struct {
+		name string
+		method string
+		ballots []tally.Ballot
+		winners []string
+	}{
+		{
+			"Plurality",
+			"plurality",
+			[]tally.Ballot{{Choice: "a", Weight: 5}, {Choice: "b"}, {Choice: "b"}},
+			[]string{"a"},
+		},
+		{
+			"Instant runoff",
+			"irv",
+			[]tally.Ballot{{Rankings: []string{"a"}, Weight: 4}, {Rankings: []string{"b", "c"}, Weight: 3}, {Rankings: []string{"c", "b"}, Weight: 2}},
+			[]string{"b"},
+		},
+		{
+			"Approval",
+			"approval",
+			[]tally.Ballot{{Approvals: []string{"c"}, Weight: 3}, {Approvals: []string{"a", "b"}}, {Approvals: []string{"a"}}},
+			[]string{"c"},
+		},
+		{
+			"Schulze",
+			"schulze",
+			[]tally.Ballot{{Rankings: []string{"c", "b", "a"}, Weight: 10}, {Rankings: []string{"a", "b", "c"}, Weight: 3}},
+			[]string{"c"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			method, err := tally.Lookup(test.method)
+			if err != nil {
+				t.Fatal("Could not look up method", err.Error())
+			}
+			result := method.Tally(tally.Contest{Candidates: candidates, Seats: 1}, test.ballots)
+			if !slices.Equal(result.Winners, test.winners) {
+				t.Errorf("Expected winners: %v but got %v", test.winners, result.Winners)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package tally
+
+import (
+	"fmt"
+	"math"
+)
+
+// List is a party list and its candidates in the order they take up seats.
+type List struct {
+	ID string
+	Candidates []string
+}
+
+// Allocation shares seats between lists in proportion to their votes. Ties
+// go to the list with more votes, then to the list named first.
+type Allocation func(lists []string, votes map[string]float64, seats int) map[string]int
+
+// DHondt gives each seat to the list with the highest votes / (seats + 1).
+func DHondt(lists []string, votes map[string]float64, seats int) map[string]int {
+	return highestAverages(lists, votes, seats, func(won int) float64 {
+		return float64(won + 1)
+	})
+}
+
+// SainteLague gives each seat to the list with the highest
+// votes / (2 * seats + 1), which favours smaller lists more than D'Hondt.
+func SainteLague(lists []string, votes map[string]float64, seats int) map[string]int {
+	return highestAverages(lists, votes, seats, func(won int) float64 {
+		return float64(2 * won + 1)
+	})
+}
+
+// Hare gives each list a seat for every full Hare quota of votes, then hands
+// the remaining seats to the lists with the largest remainders.
+func Hare(lists []string, votes map[string]float64, seats int) map[string]int {
+	allocated := make(map[string]int, len(lists))
+	total := 0.0
+	for _, list := range lists {
+		allocated[list] = 0
+		total += votes[list]
+	}
+	if total == 0 || seats <= 0 {
+		return allocated
+	}
+	quota := total / float64(seats)
+	remainders := make(map[string]float64, len(lists))
+	remaining := seats
+	for _, list := range lists {
+		full := math.Floor(votes[list] / quota)
+		allocated[list] = int(full)
+		remainders[list] = max(votes[list] - full * quota, 0)
+		remaining -= int(full)
+	}
+	for ; remaining > 0; remaining-- {
+		best := ""
+		for _, list := range lists {
+			if remainders[list] < 0 {
+				continue
+			}
+			if best == "" || remainders[list] > remainders[best] ||
+				(remainders[list] == remainders[best] && votes[list] > votes[best]) {
+				best = list
+			}
+		}
+		if best == "" {
+			break
+		}
+		allocated[best]++
+		// Each list receives at most one remainder seat.
+		remainders[best] = -1
+	}
+	return allocated
+}
+
+func highestAverages(lists []string, votes map[string]float64, seats int, divisor func(won int) float64) map[string]int {
+	allocated := make(map[string]int, len(lists))
+	for _, list := range lists {
+		allocated[list] = 0
+	}
+	for range seats {
+		best, bestAverage := "", 0.0
+		for _, list := range lists {
+			average := votes[list] / divisor(allocated[list])
+			if average <= 0 {
+				continue
+			}
+			if best == "" || average > bestAverage || (average == bestAverage && votes[list] > votes[best]) {
+				best, bestAverage = list, average
+			}
+		}
+		if best == "" {
+			break
+		}
+		allocated[best]++
+	}
+	return allocated
+}
+
+// PartyList counts one vote per ballot for the chosen list. Lists below
+// contest.Threshold percent of the counted votes are excluded; the seats are
+// shared between the rest by allocate. Each list's seats go to its first
+// candidates, and seats beyond a list's candidates stay empty.
+func PartyList(contest Contest, choices []string, allocate Allocation) *Result {
+	return partyList(contest, choices, nil, allocate)
+}
+
+func partyList(contest Contest, choices []string, weights []float64, allocate Allocation) *Result {
+	lists := make([]string, len(contest.Lists))
+	for i, list := range contest.Lists {
+		lists[i] = list.ID
+	}
+	round := Round{Number: 1, Counts: emptyCounts(lists)}
+	total := 0.0
+	for i, choice := range choices {
+		if _, ok := round.Counts[choice]; ok {
+			round.Counts[choice] += weightOf(weights, i)
+			total += weightOf(weights, i)
+		}
+	}
+
+	var eligible []string
+	votes := make(map[string]float64, len(lists))
+	for _, list := range lists {
+		if total > 0 && round.Counts[list] * 100 / total >= contest.Threshold {
+			eligible = append(eligible, list)
+			votes[list] = round.Counts[list]
+		} else {
+			round.Excluded = append(round.Excluded, list)
+		}
+	}
+
+	seats := allocate(eligible, votes, max(contest.Seats, 1))
+	winners := []string{}
+	for _, list := range contest.Lists {
+		won := min(seats[list.ID], len(list.Candidates))
+		winners = append(winners, list.Candidates[:won]...)
+	}
+	return &Result{
+		Candidates: contest.Candidates,
+		Rounds: []Round{round},
+		Winners: winners,
+		Seats: seats,
+	}
+}
+
+type partyListMethod struct {
+	allocate Allocation
+}
+
+func (partyListMethod) Validate(contest Contest, ballot Ballot) error {
+	if ballot.kinds() != listKind {
+		return fmt.Errorf("%w: choose exactly one party list", ErrInvalidBallot)
+	}
+	for _, list := range contest.Lists {
+		if list.ID == ballot.List {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidList, ballot.List)
+}
+
+func (method partyListMethod) Tally(contest Contest, ballots []Ballot) *Result {
+	choices := make([]string, len(ballots))
+	for i, ballot := range ballots {
+		choices[i] = ballot.List
+	}
+	return partyList(contest, choices, weights(ballots), method.allocate)
+}
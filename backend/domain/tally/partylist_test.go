@@ -0,0 +1,116 @@
+package tally_test
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/tally"
+)
+
+func TestAllocations(t *testing.T) {
+	lists := []string{"a", "b", "c", "d"}
+	votes := map[string]float64{"a": 100000, "b": 80000, "c": 30000, "d": 20000}
+	tests := []struct {
+		name string
+		allocate tally.Allocation
+		expected map[string]int
+	}{
+		{"D'Hondt", tally.DHondt, map[string]int{"a": 4, "b": 3, "c": 1, "d": 0}},
+		{"Sainte-Lague", tally.SainteLague, map[string]int{"a": 3, "b": 3, "c": 1, "d": 1}},
+		{"Hare", tally.Hare, map[string]int{"a": 3, "b": 3, "c": 1, "d": 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			seats := test.allocate(lists, votes, 8)
+			if !maps.Equal(seats, test.expected) {
+				t.Errorf("Expected seats: %v but got %v", test.expected, seats)
+			}
+		})
+	}
+}
+
+func TestAllocationsShouldBreakTiesByVotesThenListOrder(t *testing.T) {
+	lists := []string{"a", "b", "c"}
+	votes := map[string]float64{"a": 10, "b": 20, "c": 10}
+
+	// b's second seat ties a and c at 10; a is named first.
+	seats := tally.DHondt(lists, votes, 2)
+	if !maps.Equal(seats, map[string]int{"a": 0, "b": 2, "c": 0}) {
+		t.Errorf("Expected b to take both seats but got %v", seats)
+	}
+	seats = tally.DHondt(lists, votes, 3)
+	if !maps.Equal(seats, map[string]int{"a": 1, "b": 2, "c": 0}) {
+		t.Errorf("Expected a to win the tie but got %v", seats)
+	}
+}
+
+func TestPartyList(t *testing.T) {
+	contest := tally.Contest{
+		Candidates: []string{"a1", "a2", "a3", "b1", "c1", "c2"},
+		Lists: []tally.List{
+			{ID: "a", Candidates: []string{"a1", "a2", "a3"}},
+			{ID: "b", Candidates: []string{"b1"}},
+			{ID: "c", Candidates: []string{"c1", "c2"}},
+		},
+		Seats: 4,
+	}
+	choices := slices.Concat(
+		slices.Repeat([]string{"a"}, 10),
+		slices.Repeat([]string{"b"}, 8),
+		slices.Repeat([]string{"c"}, 1),
+		[]string{"unknown"},
+	)
+
+	result := tally.PartyList(contest, choices, tally.DHondt)
+
+	if !maps.Equal(result.Seats, map[string]int{"a": 2, "b": 2, "c": 0}) {
+		t.Errorf("Unexpected seats: %v", result.Seats)
+	}
+	// b only has one candidate, so its second seat stays empty.
+	if !slices.Equal(result.Winners, []string{"a1", "a2", "b1"}) {
+		t.Errorf("Expected winners: %v but got %v", []string{"a1", "a2", "b1"}, result.Winners)
+	}
+	if result.Rounds[0].Counts["a"] != 10 || len(result.Rounds[0].Excluded) != 0 {
+		t.Errorf("Unexpected round: %+v", result.Rounds[0])
+	}
+
+	contest.Threshold = 10
+	result = tally.PartyList(contest, choices, tally.DHondt)
+
+	if !slices.Equal(result.Rounds[0].Excluded, []string{"c"}) {
+		t.Errorf("Expected c to fall below the threshold but got %v", result.Rounds[0].Excluded)
+	}
+	if result.Seats["c"] != 0 || result.Seats["a"] + result.Seats["b"] != 4 {
+		t.Errorf("Unexpected seats: %v", result.Seats)
+	}
+}
+
+func TestPartyListValidate(t *testing.T) {
+	contest := tally.Contest{Candidates: []string{"a1"}, Lists: []tally.List{{ID: "a", Candidates: []string{"a1"}}}, Seats: 2}
+	method, err := tally.Lookup("sainte_lague")
+	if err != nil {
+		t.Fatal("Could not look up method", err.Error())
+	}
+	tests := []struct {
+		name string
+		ballot tally.Ballot
+		expected error
+	}{
+		{"List vote", tally.Ballot{List: "a"}, nil},
+		{"Unknown list", tally.Ballot{List: "b"}, tally.ErrInvalidList},
+		{"Candidate vote", tally.Ballot{Choice: "a1"}, tally.ErrInvalidBallot},
+		{"List and candidate vote", tally.Ballot{Choice: "a1", List: "a"}, tally.ErrInvalidBallot},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := method.Validate(contest, test.ballot)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
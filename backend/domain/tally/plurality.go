@@ -0,0 +1,37 @@
+package tally
+
+import "fmt"
+
+// Plurality counts single choice ballots in one round. Every candidate with
+// the most votes wins, so a tie yields several winners. Choices for unknown
+// candidates are ignored and without any counted votes there is no winner.
+func Plurality(candidates []string, choices []string) *Result {
+	return plurality(candidates, choices, nil)
+}
+
+func plurality(candidates []string, choices []string, weights []float64) *Result {
+	counts := emptyCounts(candidates)
+	for i, choice := range choices {
+		if _, ok := counts[choice]; ok {
+			counts[choice] += weightOf(weights, i)
+		}
+	}
+	return singleRound(candidates, counts)
+}
+
+type pluralityMethod struct{}
+
+func (pluralityMethod) Validate(contest Contest, ballot Ballot) error {
+	if ballot.kinds() != choiceKind {
+		return fmt.Errorf("%w: choose exactly one candidate", ErrInvalidBallot)
+	}
+	return validateSelection(contest.Candidates, []string{ballot.Choice})
+}
+
+func (pluralityMethod) Tally(contest Contest, ballots []Ballot) *Result {
+	choices := make([]string, len(ballots))
+	for i, ballot := range ballots {
+		choices[i] = ballot.Choice
+	}
+	return plurality(contest.Candidates, choices, weights(ballots))
+}
@@ -0,0 +1,90 @@
+package tally
+
+import (
+	"fmt"
+	"math"
+)
+
+// Cost is the number of credits it takes to cast votes for one candidate
+// under quadratic voting.
+func Cost(votes int) int {
+	return votes * votes
+}
+
+// Quadratic sums the votes each ballot gives the candidates and the credits
+// spent on them. The candidates with the most votes win.
+func Quadratic(candidates []string, ballots []map[string]int) *Result {
+	return quadratic(candidates, ballots, nil)
+}
+
+// quadratic multiplies the votes and credits of each ballot by its weight.
+func quadratic(candidates []string, ballots []map[string]int, weights []float64) *Result {
+	counts := emptyCounts(candidates)
+	credits := make(map[string]int, len(candidates))
+	for _, candidate := range candidates {
+		credits[candidate] = 0
+	}
+	for i, votes := range ballots {
+		weight := weightOf(weights, i)
+		for candidate, k := range votes {
+			if _, ok := counts[candidate]; ok {
+				counts[candidate] += float64(k) * weight
+				credits[candidate] += Cost(k) * int(weight)
+			}
+		}
+	}
+	result := singleRound(candidates, counts)
+	result.Credits = credits
+	return result
+}
+
+type quadraticMethod struct{}
+
+// Validate rejects ballots that spend more than the credits of the contest.
+// Votes are checked against the most the credits can buy before they are
+// squared, so huge votes cannot overflow into a cheap ballot.
+func (quadraticMethod) Validate(contest Contest, ballot Ballot) error {
+	if ballot.kinds() != votesKind {
+		return fmt.Errorf("%w: give votes to one or more candidates", ErrInvalidBallot)
+	}
+	affordable := isqrt(contest.Credits)
+	spent := 0
+	voted := make([]string, 0, len(ballot.Votes))
+	for candidate, votes := range ballot.Votes {
+		if votes < 0 {
+			return fmt.Errorf("%w: votes cannot be negative", ErrInvalidBallot)
+		}
+		if votes > affordable {
+			return fmt.Errorf("%w: %d votes cost more than %d credits", ErrInvalidBallot, votes, contest.Credits)
+		}
+		spent += Cost(votes)
+		if spent > contest.Credits {
+			return fmt.Errorf("%w: ballot spends more than %d credits", ErrInvalidBallot, contest.Credits)
+		}
+		voted = append(voted, candidate)
+	}
+	return validateSelection(contest.Candidates, voted)
+}
+
+// isqrt is the largest number whose square is at most n.
+func isqrt(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	root := int(math.Sqrt(float64(n)))
+	for root * root > n {
+		root--
+	}
+	for (root + 1) * (root + 1) <= n {
+		root++
+	}
+	return root
+}
+
+func (quadraticMethod) Tally(contest Contest, ballots []Ballot) *Result {
+	votes := make([]map[string]int, len(ballots))
+	for i, ballot := range ballots {
+		votes[i] = ballot.Votes
+	}
+	return quadratic(contest.Candidates, votes, weights(ballots))
+}
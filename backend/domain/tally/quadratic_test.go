@@ -0,0 +1,64 @@
+package tally_test
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/tally"
+)
+
+func TestQuadratic(t *testing.T) {
+	candidates := []string{"parks", "roads", "library"}
+	result := tally.Quadratic(candidates, []map[string]int{
+		{"parks": 3, "roads": 1},
+		{"roads": 2, "library": 2},
+		{"parks": 1, "library": 3},
+		{"unknown": 4},
+	})
+
+	counts := map[string]float64{"parks": 4, "roads": 3, "library": 5}
+	if !maps.Equal(result.Rounds[0].Counts, counts) {
+		t.Errorf("Expected counts: %v but got %v", counts, result.Rounds[0].Counts)
+	}
+	credits := map[string]int{"parks": 10, "roads": 5, "library": 13}
+	if !maps.Equal(result.Credits, credits) {
+		t.Errorf("Expected credits spent: %v but got %v", credits, result.Credits)
+	}
+	if !slices.Equal(result.Winners, []string{"library"}) {
+		t.Errorf("Expected winners: %v but got %v", []string{"library"}, result.Winners)
+	}
+}
+
+func TestQuadraticValidate(t *testing.T) {
+	contest := tally.Contest{Candidates: []string{"a", "b", "c"}, Seats: 1, Credits: 10}
+	tests := []struct {
+		name string
+		ballot tally.Ballot
+		expected error
+	}{
+		{"Spends every credit", tally.Ballot{Votes: map[string]int{"a": 3, "b": 1}}, nil},
+		{"Spends some credits", tally.Ballot{Votes: map[string]int{"c": 2}}, nil},
+		{"Overspends", tally.Ballot{Votes: map[string]int{"a": 3, "b": 1, "c": 1}}, tally.ErrInvalidBallot},
+		{"Votes whose cost overflows", tally.Ballot{Votes: map[string]int{"a": 1 << 32}}, tally.ErrInvalidBallot},
+		{"More votes than the credits buy", tally.Ballot{Votes: map[string]int{"a": 4}}, tally.ErrInvalidBallot},
+		{"Negative votes", tally.Ballot{Votes: map[string]int{"a": -3}}, tally.ErrInvalidBallot},
+		{"Unknown candidate", tally.Ballot{Votes: map[string]int{"d": 1}}, tally.ErrInvalidCandidate},
+		{"No votes", tally.Ballot{}, tally.ErrInvalidBallot},
+		{"Scores instead of votes", tally.Ballot{Scores: map[string]int{"a": 1}}, tally.ErrInvalidBallot},
+	}
+
+	method, err := tally.Lookup("quadratic")
+	if err != nil {
+		t.Fatal("Could not look up method", err.Error())
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := method.Validate(contest, test.ballot)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package tally
+
+import "fmt"
+
+// Score sums the scores each ballot gives the candidates. Candidates a
+// ballot does not score count as zero. The candidates with the highest total
+// win.
+func Score(candidates []string, ballots []map[string]int) *Result {
+	return score(candidates, ballots, nil)
+}
+
+func score(candidates []string, ballots []map[string]int, weights []float64) *Result {
+	counts := emptyCounts(candidates)
+	for i, scores := range ballots {
+		for candidate, score := range scores {
+			if _, ok := counts[candidate]; ok {
+				counts[candidate] += float64(score) * weightOf(weights, i)
+			}
+		}
+	}
+	return singleRound(candidates, counts)
+}
+
+type scoreMethod struct{}
+
+func (scoreMethod) Validate(contest Contest, ballot Ballot) error {
+	if ballot.kinds() != scoresKind {
+		return fmt.Errorf("%w: score one or more candidates", ErrInvalidBallot)
+	}
+	scored := make([]string, 0, len(ballot.Scores))
+	for candidate, score := range ballot.Scores {
+		if score < 0 || score > MaxScore {
+			return fmt.Errorf("%w: scores must be between 0 and %d", ErrInvalidBallot, MaxScore)
+		}
+		scored = append(scored, candidate)
+	}
+	return validateSelection(contest.Candidates, scored)
+}
+
+func (scoreMethod) Tally(contest Contest, ballots []Ballot) *Result {
+	scores := make([]map[string]int, len(ballots))
+	for i, ballot := range ballots {
+		scores[i] = ballot.Scores
+	}
+	return score(contest.Candidates, scores, weights(ballots))
+}
@@ -0,0 +1,320 @@
+package tally
+
+import (
+	"math"
+	"sort"
+)
+
+// Surplus transfer rules for SingleTransferableVote.
+const (
+	Gregory = "gregory"
+	Meek = "meek"
+)
+
+const (
+	// meekTolerance is how close an elected candidate's votes must come to
+	// the quota before Meek keep values are considered settled.
+	meekTolerance = 1e-6
+	meekIterations = 1000
+)
+
+// SingleTransferableVote fills contest.Seats from ranked ballots. Candidates
+// reaching the quota are elected; their surplus is passed on to the next
+// preferences on their ballots, and when no surplus is left the candidate
+// with the fewest votes is eliminated, breaking ties as InstantRunoff does.
+// Once the remaining candidates would fill the remaining seats they are all
+// elected.
+//
+// With Gregory transfers the quota is the Droop quota of the ballots cast and
+// each round moves one surplus, transferring every ballot held by the elected
+// candidate at a fraction of its value. With Meek transfers every elected
+// candidate keeps only the share of each ballot reaching them that holds them
+// at the quota, passing the rest on; the quota shrinks as ballots exhaust.
+//
+// Every round after the first records the votes each candidate gained or
+// lost in Transfers.
+func SingleTransferableVote(contest Contest, ballots [][]string) *Result {
+	return singleTransferableVote(contest, ballots, nil)
+}
+
+func singleTransferableVote(contest Contest, ballots [][]string, weights []float64) *Result {
+	seats := max(contest.Seats, 1)
+	var result *Result
+	if contest.SurplusTransfer == Meek {
+		result = meekCount(contest.Candidates, seats, ballots, weights)
+	} else {
+		result = gregoryCount(contest.Candidates, seats, ballots, weights)
+	}
+	recordTransfers(result.Rounds)
+	return result
+}
+
+// stvBallot is a ballot held by the candidate at position in its ranking,
+// or exhausted once position passes the end of the ranking.
+type stvBallot struct {
+	ranking []string
+	position int
+	weight float64
+}
+
+func (ballot *stvBallot) advance(hopeful map[string]bool) {
+	for ballot.position < len(ballot.ranking) && !hopeful[ballot.ranking[ballot.position]] {
+		ballot.position++
+	}
+}
+
+func (ballot *stvBallot) holder() (string, bool) {
+	if ballot.position >= len(ballot.ranking) {
+		return "", false
+	}
+	return ballot.ranking[ballot.position], true
+}
+
+func gregoryCount(candidates []string, seats int, rankings [][]string, weights []float64) *Result {
+	result := &Result{Candidates: candidates, Rounds: []Round{}, Winners: []string{}}
+	hopeful := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		hopeful[candidate] = true
+	}
+	ballots := make([]*stvBallot, 0, len(rankings))
+	valid := 0.0
+	for i, ranking := range rankings {
+		ballot := &stvBallot{ranking: ranking, weight: weightOf(weights, i)}
+		ballot.advance(hopeful)
+		if _, ok := ballot.holder(); ok {
+			valid += ballot.weight
+		}
+		ballots = append(ballots, ballot)
+	}
+	if valid == 0 {
+		return result
+	}
+	quota := math.Floor(valid / float64(seats + 1)) + 1
+	// transferred holds elected candidates whose surplus has been passed on;
+	// they keep exactly the quota.
+	transferred := make(map[string]bool, seats)
+
+	for number := 1; ; number++ {
+		round := Round{Number: number, Counts: make(map[string]float64, len(candidates)), Quota: quota}
+		for _, candidate := range candidates {
+			if hopeful[candidate] {
+				round.Counts[candidate] = 0
+			}
+		}
+		for _, winner := range result.Winners {
+			round.Counts[winner] = 0
+			if transferred[winner] {
+				round.Counts[winner] = quota
+			}
+		}
+		for _, ballot := range ballots {
+			holder, ok := ballot.holder()
+			if !ok {
+				round.Exhausted += ballot.weight
+			} else if !transferred[holder] {
+				round.Counts[holder] += ballot.weight
+			}
+		}
+
+		round.Elected = elect(candidates, hopeful, round.Counts, quota, seats - len(result.Winners))
+		result.Winners = append(result.Winners, round.Elected...)
+		if finished(candidates, hopeful, &round, result, seats) {
+			return result
+		}
+
+		pending := ""
+		for _, winner := range result.Winners {
+			if !transferred[winner] && (pending == "" || round.Counts[winner] > round.Counts[pending]) {
+				pending = winner
+			}
+		}
+		if pending != "" && round.Counts[pending] > quota {
+			factor := (round.Counts[pending] - quota) / round.Counts[pending]
+			for _, ballot := range ballots {
+				if holder, ok := ballot.holder(); ok && holder == pending {
+					ballot.weight *= factor
+					ballot.advance(hopeful)
+				}
+			}
+			transferred[pending] = true
+			round.Surplus = pending
+		} else {
+			for _, winner := range result.Winners {
+				transferred[winner] = true
+			}
+			round.Eliminated = lowest(candidates, hopeful, round.Counts, result.Rounds)
+			delete(hopeful, round.Eliminated)
+			for _, ballot := range ballots {
+				if holder, ok := ballot.holder(); ok && holder == round.Eliminated {
+					ballot.advance(hopeful)
+				}
+			}
+		}
+		result.Rounds = append(result.Rounds, round)
+	}
+}
+
+func meekCount(candidates []string, seats int, rankings [][]string, weights []float64) *Result {
+	result := &Result{Candidates: candidates, Rounds: []Round{}, Winners: []string{}}
+	hopeful := make(map[string]bool, len(candidates))
+	keep := make(map[string]float64, len(candidates))
+	for _, candidate := range candidates {
+		hopeful[candidate] = true
+		keep[candidate] = 1
+	}
+	total := 0.0
+	for i := range rankings {
+		total += weightOf(weights, i)
+	}
+	valid := false
+	for _, ranking := range rankings {
+		if _, ok := firstContinuing(ranking, hopeful); ok {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return result
+	}
+
+	for number := 1; ; number++ {
+		var counts map[string]float64
+		var excess, quota float64
+		for range meekIterations {
+			counts, excess = meekDistribute(keep, rankings, weights)
+			quota = (total - excess) / float64(seats + 1)
+			settled := true
+			for _, winner := range result.Winners {
+				if math.Abs(counts[winner] - quota) > meekTolerance {
+					settled = false
+					keep[winner] *= quota / counts[winner]
+				}
+			}
+			if settled {
+				break
+			}
+		}
+
+		round := Round{Number: number, Counts: counts, Exhausted: excess, Quota: quota}
+		round.Elected = elect(candidates, hopeful, counts, quota - meekTolerance, seats - len(result.Winners))
+		result.Winners = append(result.Winners, round.Elected...)
+		if finished(candidates, hopeful, &round, result, seats) {
+			return result
+		}
+		if len(round.Elected) == 0 {
+			round.Eliminated = lowest(candidates, hopeful, counts, result.Rounds)
+			delete(hopeful, round.Eliminated)
+			keep[round.Eliminated] = 0
+		}
+		result.Rounds = append(result.Rounds, round)
+	}
+}
+
+// meekDistribute passes every ballot down its ranking, each candidate
+// keeping their keep value's share of what reaches them. Whatever passes the
+// last ranked candidate is excess.
+func meekDistribute(keep map[string]float64, rankings [][]string, weights []float64) (map[string]float64, float64) {
+	counts := make(map[string]float64, len(keep))
+	for candidate, value := range keep {
+		if value > 0 {
+			counts[candidate] = 0
+		}
+	}
+	excess := 0.0
+	for i, ranking := range rankings {
+		weight := weightOf(weights, i)
+		for _, candidate := range ranking {
+			value := keep[candidate]
+			if value == 0 {
+				continue
+			}
+			counts[candidate] += weight * value
+			weight *= 1 - value
+			if weight <= 0 {
+				break
+			}
+		}
+		excess += weight
+	}
+	return counts, excess
+}
+
+// elect moves the hopeful candidates holding at least the quota to elected,
+// most votes first, without filling more than seats.
+func elect(candidates []string, hopeful map[string]bool, counts map[string]float64, quota float64, seats int) []string {
+	var reached []string
+	for _, candidate := range candidates {
+		if hopeful[candidate] && counts[candidate] >= quota {
+			reached = append(reached, candidate)
+		}
+	}
+	sort.SliceStable(reached, func(i, j int) bool {
+		return counts[reached[i]] > counts[reached[j]]
+	})
+	if len(reached) > seats {
+		reached = reached[:seats]
+	}
+	for _, candidate := range reached {
+		delete(hopeful, candidate)
+	}
+	return reached
+}
+
+// finished records the last round once every seat is filled, electing the
+// remaining hopefuls when they are no more than the seats left.
+func finished(candidates []string, hopeful map[string]bool, round *Round, result *Result, seats int) bool {
+	if len(result.Winners) < seats && len(result.Winners) + len(hopeful) > seats {
+		return false
+	}
+	var remaining []string
+	for _, candidate := range candidates {
+		if hopeful[candidate] {
+			remaining = append(remaining, candidate)
+		}
+	}
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return round.Counts[remaining[i]] > round.Counts[remaining[j]]
+	})
+	for _, candidate := range remaining {
+		if len(result.Winners) == seats {
+			break
+		}
+		delete(hopeful, candidate)
+		round.Elected = append(round.Elected, candidate)
+		result.Winners = append(result.Winners, candidate)
+	}
+	result.Rounds = append(result.Rounds, *round)
+	return true
+}
+
+// recordTransfers sets the votes each candidate gained or lost since the
+// previous round.
+func recordTransfers(rounds []Round) {
+	for i := 1; i < len(rounds); i++ {
+		previous, current := rounds[i - 1].Counts, rounds[i].Counts
+		transfers := make(map[string]float64)
+		for candidate, votes := range current {
+			if change := votes - previous[candidate]; math.Abs(change) > meekTolerance {
+				transfers[candidate] = change
+			}
+		}
+		for candidate, votes := range previous {
+			if _, ok := current[candidate]; !ok && votes != 0 {
+				transfers[candidate] = -votes
+			}
+		}
+		if len(transfers) > 0 {
+			rounds[i].Transfers = transfers
+		}
+	}
+}
+
+type stvMethod struct{}
+
+func (stvMethod) Validate(contest Contest, ballot Ballot) error {
+	return validateRanking(contest.Candidates, ballot)
+}
+
+func (stvMethod) Tally(contest Contest, ballots []Ballot) *Result {
+	return singleTransferableVote(contest, rankings(ballots), weights(ballots))
+}
@@ -0,0 +1,129 @@
+package tally_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/tally"
+)
+
+// foodElection is the three seat example used to explain STV, won by
+// chocolate, orange and strawberry.
+func foodElection(transfer string) (tally.Contest, [][]string) {
+	contest := tally.Contest{
+		Candidates: []string{"orange", "pear", "chocolate", "strawberry", "sweets"},
+		Seats: 3,
+		SurplusTransfer: transfer,
+	}
+	ballots := join(
+		repeat([]string{"orange"}, 4),
+		repeat([]string{"pear", "orange"}, 2),
+		repeat([]string{"chocolate", "strawberry"}, 8),
+		repeat([]string{"chocolate", "sweets"}, 4),
+		repeat([]string{"strawberry"}, 1),
+		repeat([]string{"sweets"}, 1),
+	)
+	return contest, ballots
+}
+
+func TestSingleTransferableVoteGregory(t *testing.T) {
+	contest, ballots := foodElection(tally.Gregory)
+	result := tally.SingleTransferableVote(contest, ballots)
+
+	expectedWinners := []string{"chocolate", "orange", "strawberry"}
+	if !slices.Equal(result.Winners, expectedWinners) {
+		t.Errorf("Expected winners: %v but got %v", expectedWinners, result.Winners)
+	}
+	if len(result.Rounds) != 4 {
+		t.Fatalf("Expected %d rounds but got %d", 4, len(result.Rounds))
+	}
+	first, second, third, fourth := result.Rounds[0], result.Rounds[1], result.Rounds[2], result.Rounds[3]
+	if first.Quota != 6 {
+		t.Errorf("Expected Droop quota: %v but got %v", 6, first.Quota)
+	}
+	if !slices.Equal(first.Elected, []string{"chocolate"}) || first.Surplus != "chocolate" {
+		t.Errorf("Expected chocolate to be elected with a surplus in round 1 but got %+v", first)
+	}
+	expectedTransfers := map[string]float64{"chocolate": -6, "strawberry": 4, "sweets": 2}
+	for candidate, votes := range expectedTransfers {
+		if second.Transfers[candidate] != votes {
+			t.Errorf("Expected transfer to %s: %v but got %v", candidate, votes, second.Transfers[candidate])
+		}
+	}
+	if second.Eliminated != "pear" {
+		t.Errorf("Expected pear to be eliminated in round 2 but got %q", second.Eliminated)
+	}
+	if !slices.Equal(third.Elected, []string{"orange"}) || third.Eliminated != "sweets" {
+		t.Errorf("Expected orange elected and sweets eliminated in round 3 but got %+v", third)
+	}
+	if !slices.Equal(fourth.Elected, []string{"strawberry"}) || fourth.Exhausted != 3 {
+		t.Errorf("Expected strawberry elected with 3 exhausted votes in round 4 but got %+v", fourth)
+	}
+}
+
+func TestSingleTransferableVoteMeek(t *testing.T) {
+	contest, ballots := foodElection(tally.Meek)
+	result := tally.SingleTransferableVote(contest, ballots)
+
+	// Meek's smaller quota lets strawberry reach it on chocolate's surplus
+	// before orange does.
+	expectedWinners := []string{"chocolate", "strawberry", "orange"}
+	if !slices.Equal(result.Winners, expectedWinners) {
+		t.Errorf("Expected winners: %v but got %v", expectedWinners, result.Winners)
+	}
+	second := result.Rounds[1]
+	if math.Abs(second.Counts["chocolate"] - second.Quota) > 1e-3 {
+		t.Errorf("Expected chocolate to be kept at the quota %v but got %v", second.Quota, second.Counts["chocolate"])
+	}
+	if second.Transfers["strawberry"] <= 0 || second.Transfers["sweets"] <= 0 {
+		t.Errorf("Expected chocolate's surplus to be transferred but got %v", second.Transfers)
+	}
+}
+
+func TestSingleTransferableVote(t *testing.T) {
+	tests := []struct {
+		name string
+		contest tally.Contest
+		ballots [][]string
+		winners []string
+	}{
+		{
+			"No ballots",
+			tally.Contest{Candidates: []string{"a", "b"}, Seats: 1},
+			nil,
+			[]string{},
+		},
+		{
+			"Single seat matches instant runoff",
+			tally.Contest{Candidates: []string{"a", "b", "c"}, Seats: 1},
+			join(repeat([]string{"a"}, 4), repeat([]string{"b", "c"}, 3), repeat([]string{"c", "b"}, 2)),
+			[]string{"b"},
+		},
+		{
+			"Seats for every candidate",
+			tally.Contest{Candidates: []string{"a", "b", "c"}, Seats: 3},
+			[][]string{{"c"}},
+			[]string{"c", "a", "b"},
+		},
+		{
+			"Two seats",
+			tally.Contest{Candidates: []string{"a", "b", "c", "d"}, Seats: 2},
+			join(repeat([]string{"a", "b"}, 5), repeat([]string{"c"}, 3), repeat([]string{"d", "c"}, 2)),
+			[]string{"a", "c"},
+		},
+	}
+
+	for _, test := range tests {
+		for _, transfer := range []string{tally.Gregory, tally.Meek} {
+			t.Run(test.name + " " + transfer, func(t *testing.T) {
+				contest := test.contest
+				contest.SurplusTransfer = transfer
+				result := tally.SingleTransferableVote(contest, test.ballots)
+				if !slices.Equal(result.Winners, test.winners) {
+					t.Errorf("Expected winners: %v but got %v", test.winners, result.Winners)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type UserAPI struct {
+	service *UserService
+	log *zap.Logger
+}
+
+func NewUserAPI(service *UserService, logger *zap.Logger) *UserAPI {
+	return &UserAPI{service: service, log: logger}
+}
+
+func (api *UserAPI) RegisterRoutes(server *gin.Engine) {
+	server.GET("/users", Require(ManageUsers), api.getUsers)
+	server.GET("/users/:id", Require(ManageUsers), api.getUser)
+	server.POST("/users", Require(ManageUsers), api.createUser)
+	server.PATCH("/users/:id", Require(ManageUsers), api.updateUser)
+	server.POST("/users/:id/deactivate", Require(ManageUsers), api.setActive(false, "deactivated user"))
+	server.POST("/users/:id/reactivate", Require(ManageUsers), api.setActive(true, "reactivated user"))
+}
+
+// createUser adds a user without a password. They can sign in once an admin
+// sets one with PUT /users/:id/password.
+func (api *UserAPI) createUser(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var user User
+	err := ctx.ShouldBindJSON(&user)
+	if err != nil {
+		api.log.Error(err.Error())
+		api.log.Error("could not parse user", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse user"})
+		return
+	}
+	err = api.service.CreateUser(ctx, &user)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "created user", "id": user.ID})
+}
+
+func (api *UserAPI) getUsers(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	role := UserRole(ctx.Query("role"))
+	if role != "" && !role.IsValid() {
+		api.log.Error("role is invalid", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "role is invalid"})
+		return
+	}
+	var active *bool
+	if rawActive, ok := ctx.GetQuery("active"); ok {
+		value, err := strconv.ParseBool(rawActive)
+		if err != nil {
+			api.log.Error(err.Error())
+			api.log.Error("could not parse active filter", zap.String("request_id", requestId))
+			ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse active filter"})
+			return
+		}
+		active = &value
+	}
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		api.log.Error("could not parse page number", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse page number"})
+		return
+	}
+	pageSize, err := strconv.Atoi(ctx.DefaultQuery("size", "10"))
+	if err != nil || pageSize < 1 {
+		api.log.Error("could not parse page size", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse page size"})
+		return
+	}
+	queryParams := UserQueryParams{
+		Role: role,
+		Active: active,
+		Limit: pageSize,
+		Offset: (page - 1) * pageSize,
+	}
+	users, err := api.service.GetUsers(ctx, queryParams)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, users)
+}
+
+func (api *UserAPI) getUser(ctx *gin.Context) {
+	user, err := api.service.GetUser(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, user)
+}
+
+func (api *UserAPI) updateUser(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var updatedUser User
+	err := ctx.ShouldBindJSON(&updatedUser)
+	if err != nil {
+		api.log.Error(err.Error())
+		api.log.Error("could not parse update information", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse update information"})
+		return
+	}
+	err = api.service.UpdateUser(ctx, ctx.Param("id"), &updatedUser)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "updated user"})
+}
+
+func (api *UserAPI) setActive(active bool, message string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		err := api.service.SetActive(ctx, ctx.Param("id"), active)
+		if err != nil {
+			ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": message})
+	}
+}
+
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidRole):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrEmailTaken):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
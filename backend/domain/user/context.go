@@ -0,0 +1,19 @@
+package user
+
+import "context"
+
+// ContextKey is the key the signed in user is stored under in the request
+// context.
+const ContextKey = "user"
+
+// FromContext returns the signed in user of the request, if any.
+func FromContext(ctx context.Context) (*User, bool) {
+	u, ok := ctx.Value(ContextKey).(*User)
+	return u, ok && u != nil
+}
+
+// IsAdmin reports whether the request was made by an admin.
+func IsAdmin(ctx context.Context) bool {
+	u, ok := FromContext(ctx)
+	return ok && u.Role == Admin
+}
@@ -11,12 +11,16 @@ const (
 
 type User struct {
 	ID string
-	FirstName string
-	LastName string
-	MiddleName string
-	Email string
+	FirstName string `binding:"required,max=20"`
+	LastName string `binding:"required,max=20"`
+	MiddleName string `binding:"max=20"`
+	Email string `binding:"required,email,max=50"`
 	Role UserRole
+	// Active users can sign in and vote. Deactivated users keep their
+	// history but can do neither.
 	Active bool
+	// Groups are the teams, committees or other bodies the user belongs to.
+	Groups []string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -0,0 +1,64 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+var ErrForbidden = errors.New("User is not allowed to do this")
+
+// DeniedKey is the key the permission a request was refused for is stored
+// under in the request context, so the refusal can be audited.
+const DeniedKey = "deniedPermission"
+
+type Permission string
+
+const (
+	ReadElections Permission = "elections:read"
+	ManageElections Permission = "elections:manage"
+	CastVotes Permission = "votes:cast"
+	ManageUsers Permission = "users:manage"
+	ReadAudit Permission = "audit:read"
+)
+
+// rolePermissions lists what each role may do. Base users read elections and
+// vote; everything else is for admins.
+var rolePermissions = map[UserRole][]Permission{
+	Base: {ReadElections, CastVotes},
+	Admin: {ReadElections, CastVotes, ManageElections, ManageUsers, ReadAudit},
+}
+
+// Can reports whether users with the role have the permission.
+func (role UserRole) Can(permission Permission) bool {
+	return slices.Contains(rolePermissions[role], permission)
+}
+
+// Can reports whether the signed in user of the request has the permission.
+func Can(ctx context.Context, permission Permission) bool {
+	u, ok := FromContext(ctx)
+	return ok && u.Role.Can(permission)
+}
+
+// Require declares the permission a route needs. Requests that are not
+// signed in are rejected with 401 and those of users without the permission
+// with 403.
+func Require(permission Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		u, ok := FromContext(ctx)
+		if !ok {
+			ctx.Set(DeniedKey, permission)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Sign in required"})
+			return
+		}
+		if !u.Role.Can(permission) {
+			ctx.Set(DeniedKey, permission)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": ErrForbidden.Error()})
+			return
+		}
+		ctx.Next()
+	}
+}
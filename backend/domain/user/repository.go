@@ -0,0 +1,189 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"geraldaddo.com/live-voting-system/platform/models"
+	"github.com/lib/pq"
+)
+
+type UserQueryParams struct {
+	Role UserRole
+	// Active filters on whether users are active, or not at all when nil.
+	Active *bool
+	Limit int
+	Offset int
+}
+
+//go:generate mockgen -destination=../../mocks/mock_user_repo.go -package=mocks . UserRepository
+type UserRepository interface {
+	models.Repository[User]
+	GetAll(ctx context.Context) ([]User, error)
+	GetAllWithFilters(ctx context.Context, params UserQueryParams) ([]User, error)
+	SetActive(ctx context.Context, id string, active bool) error
+	// PromoteFirstAdmin makes the user with the email an admin only if there
+	// is no admin yet. It reports whether the user was promoted and whether
+	// an admin already existed.
+	PromoteFirstAdmin(ctx context.Context, email string) (bool, bool, error)
+}
+
+// uniqueViolation is the Postgres error code for a duplicate key.
+const uniqueViolation = "23505"
+
+const userColumns = `id, first_name, last_name, COALESCE(middle_name, ''), email, role, active, groups, created_at, updated_at`
+
+type UserRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewUserRepository(db *sql.DB) *UserRepositoryImpl {
+	return &UserRepositoryImpl{db: db}
+}
+
+func (repo *UserRepositoryImpl) Save(ctx context.Context, user *User) error {
+	insertStatement := `
+	INSERT INTO users(first_name, last_name, middle_name, email, role, active, groups)
+	VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7)
+	RETURNING id, created_at, updated_at`
+	err := repo.db.QueryRow(
+		insertStatement,
+		user.FirstName,
+		user.LastName,
+		user.MiddleName,
+		user.Email,
+		user.Role,
+		user.Active,
+		pq.Array(groups(user.Groups)),
+	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	return EmailTaken(err)
+}
+
+// groups stores users without groups as an empty array rather than NULL.
+func groups(names []string) []string {
+	if names == nil {
+		return []string{}
+	}
+	return names
+}
+
+// EmailTaken reports a second user with the same email as ErrEmailTaken, for
+// every repository that writes users.
+func EmailTaken(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return ErrEmailTaken
+	}
+	return err
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUser(row scanner) (*User, error) {
+	var u User
+	err := row.Scan(
+		&u.ID,
+		&u.FirstName,
+		&u.LastName,
+		&u.MiddleName,
+		&u.Email,
+		&u.Role,
+		&u.Active,
+		pq.Array(&u.Groups),
+		&u.CreatedAt,
+		&u.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func scanUsers(rows *sql.Rows) ([]User, error) {
+	defer rows.Close()
+	var users []User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *u)
+	}
+	return users, nil
+}
+
+func (repo *UserRepositoryImpl) GetById(ctx context.Context, id string) (*User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+	return scanUser(repo.db.QueryRow(query, id))
+}
+
+func (repo *UserRepositoryImpl) GetAll(ctx context.Context) ([]User, error) {
+	query := `SELECT ` + userColumns + ` FROM users ORDER BY created_at ASC`
+	rows, err := repo.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	return scanUsers(rows)
+}
+
+func (repo *UserRepositoryImpl) GetAllWithFilters(ctx context.Context, params UserQueryParams) ([]User, error) {
+	query := `
+	SELECT ` + userColumns + `
+	FROM users
+	WHERE ($1 = '' OR role = $1) AND ($2::BOOLEAN IS NULL OR active = $2)
+	ORDER BY last_name, first_name, created_at
+	LIMIT $3 OFFSET $4
+	`
+	var active sql.NullBool
+	if params.Active != nil {
+		active = sql.NullBool{Bool: *params.Active, Valid: true}
+	}
+	rows, err := repo.db.Query(query, params.Role, active, params.Limit, params.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return scanUsers(rows)
+}
+
+func (repo *UserRepositoryImpl) UpdateOne(ctx context.Context, id string, u *User) error {
+	updateStatement := `
+	UPDATE users
+	SET first_name = $1, last_name = $2, middle_name = NULLIF($3, ''), email = $4, role = $5, groups = $6,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $7
+	`
+	_, err := repo.db.Exec(
+		updateStatement,
+		u.FirstName,
+		u.LastName,
+		u.MiddleName,
+		u.Email,
+		u.Role,
+		pq.Array(groups(u.Groups)),
+		id,
+	)
+	return EmailTaken(err)
+}
+
+func (repo *UserRepositoryImpl) SetActive(ctx context.Context, id string, active bool) error {
+	_, err := repo.db.Exec(`UPDATE users SET active = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`, active, id)
+	return err
+}
+
+func (repo *UserRepositoryImpl) PromoteFirstAdmin(ctx context.Context, email string) (bool, bool, error) {
+	// The final SELECT sees the table as it was before the update, so it
+	// tells apart an existing admin from an unknown email.
+	query := `
+	WITH promoted AS (
+		UPDATE users SET role = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE email = $2 AND NOT EXISTS (SELECT 1 FROM users WHERE role = $1)
+		RETURNING id
+	)
+	SELECT EXISTS (SELECT 1 FROM promoted), EXISTS (SELECT 1 FROM users WHERE role = $1)`
+	var promoted, adminExists bool
+	err := repo.db.QueryRow(query, Admin, email).Scan(&promoted, &adminExists)
+	return promoted, adminExists, err
+}
@@ -0,0 +1,151 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrUserNotFound = errors.New("User does not exist")
+	ErrEmailTaken = errors.New("Another user already has this email address")
+	ErrInvalidRole = errors.New("User role is not supported")
+	ErrAdminExists = errors.New("An admin already exists")
+)
+
+type UserService struct {
+	repo UserRepository
+	log *zap.Logger
+}
+
+func NewUserService(repo UserRepository, logger *zap.Logger) *UserService {
+	return &UserService{repo: repo, log: logger}
+}
+
+// CreateUser adds an active user, with the base role unless another is given.
+func (service *UserService) CreateUser(ctx context.Context, user *User) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	if user.Role == "" {
+		user.Role = Base
+	}
+	if !user.Role.IsValid() {
+		service.log.Warn("Invalid role: " + string(user.Role), zap.String("request_id", requestId))
+		return fmt.Errorf("%w: %s", ErrInvalidRole, user.Role)
+	}
+	user.Active = true
+	err := service.repo.Save(ctx, user)
+	if errors.Is(err, ErrEmailTaken) {
+		service.log.Warn("Email is already taken", zap.String("request_id", requestId))
+		return ErrEmailTaken
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not create user", zap.String("request_id", requestId))
+		return errors.New("Could not create user")
+	}
+	service.log.Info("Created user: " + user.ID, zap.String("request_id", requestId))
+	return nil
+}
+
+func (service *UserService) GetUsers(ctx context.Context, params UserQueryParams) ([]User, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	users, err := service.repo.GetAllWithFilters(ctx, params)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Failed to get list of users", zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get users")
+	}
+	service.log.Info(fmt.Sprintf("Got users of length: %d", len(users)), zap.String("request_id", requestId))
+	return users, nil
+}
+
+func (service *UserService) GetUser(ctx context.Context, id string) (*User, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	user, err := service.repo.GetById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("User with id: " + id + " does not exist", zap.String("request_id", requestId))
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get user: " + id, zap.String("request_id", requestId))
+		return nil, errors.New("Could not get user: " + id)
+	}
+	return user, nil
+}
+
+// UpdateUser changes the details of a user. Users are only deactivated and
+// reactivated through SetActive.
+func (service *UserService) UpdateUser(ctx context.Context, id string, updatedUser *User) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	user, err := service.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	if updatedUser.Role == "" {
+		updatedUser.Role = user.Role
+	}
+	if !updatedUser.Role.IsValid() {
+		service.log.Warn("Invalid role: " + string(updatedUser.Role), zap.String("request_id", requestId))
+		return fmt.Errorf("%w: %s", ErrInvalidRole, updatedUser.Role)
+	}
+	if updatedUser.Groups == nil {
+		updatedUser.Groups = user.Groups
+	}
+	updatedUser.Active = user.Active
+	err = service.repo.UpdateOne(ctx, id, updatedUser)
+	if errors.Is(err, ErrEmailTaken) {
+		service.log.Warn("Email is already taken", zap.String("request_id", requestId))
+		return ErrEmailTaken
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not update user: " + id, zap.String("request_id", requestId))
+		return errors.New("Could not update user: " + id)
+	}
+	service.log.Info("Updated user: " + id, zap.String("request_id", requestId))
+	return nil
+}
+
+// SetActive deactivates or reactivates a user.
+func (service *UserService) SetActive(ctx context.Context, id string, active bool) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	_, err := service.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	err = service.repo.SetActive(ctx, id, active)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not change whether user: " + id + " is active", zap.String("request_id", requestId))
+		return errors.New("Could not update user: " + id)
+	}
+	service.log.Info(fmt.Sprintf("Set user: %s active: %t", id, active), zap.String("request_id", requestId))
+	return nil
+}
+
+// BootstrapAdmin makes the user with the email the first admin, who can then
+// promote others through the API. Emails are not verified, so it refuses once
+// any admin exists rather than letting whoever registers an address take over.
+func (service *UserService) BootstrapAdmin(ctx context.Context, email string) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	promoted, adminExists, err := service.repo.PromoteFirstAdmin(ctx, email)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not make user: " + email + " an admin", zap.String("request_id", requestId))
+		return errors.New("Could not make user: " + email + " an admin")
+	}
+	if adminExists {
+		service.log.Warn("Refused to make user: " + email + " an admin as an admin already exists", zap.String("request_id", requestId))
+		return ErrAdminExists
+	}
+	if !promoted {
+		service.log.Warn("No user has email: " + email + " to make an admin", zap.String("request_id", requestId))
+		return ErrUserNotFound
+	}
+	service.log.Info("Made user: " + email + " the first admin", zap.String("request_id", requestId))
+	return nil
+}
@@ -0,0 +1,163 @@
+package user_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/mocks"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestCreateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		role user.UserRole
+		saveErr error
+		expectedRole user.UserRole
+		expected error
+	}{
+		{"Default role", "", nil, user.Base, nil},
+		{"Admin role", user.Admin, nil, user.Admin, nil},
+		{"Invalid role", "superuser", nil, "", user.ErrInvalidRole},
+		{"Email taken", user.Base, user.ErrEmailTaken, user.Base, user.ErrEmailTaken},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockUserRepository := mocks.NewMockUserRepository(ctrl)
+			if test.expectedRole != "" {
+				mockUserRepository.
+					EXPECT().
+					Save(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, u *user.User) error {
+						if u.Role != test.expectedRole {
+							t.Errorf("Expected role: %s but got %s", test.expectedRole, u.Role)
+						}
+						if !u.Active {
+							t.Errorf("Expected new users to be active")
+						}
+						return test.saveErr
+					}).
+					Times(1)
+			}
+			service := user.NewUserService(mockUserRepository, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			err := service.CreateUser(ctx, &user.User{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com", Role: test.role})
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestUpdateUserShouldKeepActiveStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepository := mocks.NewMockUserRepository(ctrl)
+	existing := &user.User{ID: "test-user-id", Role: user.Admin, Active: false, Groups: []string{"board"}}
+	mockUserRepository.
+		EXPECT().
+		GetById(gomock.Any(), "test-user-id").
+		Return(existing, nil).
+		Times(1)
+	mockUserRepository.
+		EXPECT().
+		UpdateOne(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id string, u *user.User) error {
+			if u.Active {
+				t.Errorf("Expected update not to reactivate the user")
+			}
+			if u.Role != user.Admin || len(u.Groups) != 1 {
+				t.Errorf("Expected role and groups to be kept but got %s %v", u.Role, u.Groups)
+			}
+			return nil
+		}).
+		Times(1)
+	service := user.NewUserService(mockUserRepository, zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	err := service.UpdateUser(ctx, "test-user-id", &user.User{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com", Active: true})
+	if err != nil {
+		t.Errorf("Expected no error but got %v", err)
+	}
+}
+
+func TestSetActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		getErr error
+		active bool
+		expected error
+	}{
+		{"Deactivate", nil, false, nil},
+		{"Reactivate", nil, true, nil},
+		{"Missing user", sql.ErrNoRows, false, user.ErrUserNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockUserRepository := mocks.NewMockUserRepository(ctrl)
+			mockUserRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-user-id").
+				Return(&user.User{ID: "test-user-id"}, test.getErr).
+				Times(1)
+			if test.getErr == nil {
+				mockUserRepository.
+					EXPECT().
+					SetActive(gomock.Any(), "test-user-id", test.active).
+					Return(nil).
+					Times(1)
+			}
+			service := user.NewUserService(mockUserRepository, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			err := service.SetActive(ctx, "test-user-id", test.active)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestBootstrapAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		promoted bool
+		adminExists bool
+		expected error
+	}{
+		{"Registered user without admins", true, false, nil},
+		{"Unregistered email", false, false, user.ErrUserNotFound},
+		{"Admin already exists", false, true, user.ErrAdminExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockUserRepository := mocks.NewMockUserRepository(ctrl)
+			mockUserRepository.
+				EXPECT().
+				PromoteFirstAdmin(gomock.Any(), "admin@example.com").
+				Return(test.promoted, test.adminExists, nil).
+				Times(1)
+			service := user.NewUserService(mockUserRepository, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			err := service.BootstrapAdmin(ctx, "admin@example.com")
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
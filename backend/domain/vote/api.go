@@ -0,0 +1,78 @@
+package vote
+
+import (
+	"errors"
+	"net/http"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type VoteAPI struct {
+	service *VoteService
+	log *zap.Logger
+}
+
+func NewVoteAPI(service *VoteService, logger *zap.Logger) *VoteAPI {
+	return &VoteAPI{service: service, log: logger}
+}
+
+func (api *VoteAPI) RegisterRoutes(server *gin.Engine) {
+	server.POST("/elections/:id/votes", user.Require(user.CastVotes), api.castVote)
+	server.POST("/elections/:id/ballots", user.Require(user.CastVotes), api.castBallot)
+}
+
+func (api *VoteAPI) castVote(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	electionId := ctx.Param("id")
+	var vote Vote
+	err := ctx.ShouldBindJSON(&vote)
+	if err != nil {
+		api.log.Error(err.Error())
+		api.log.Error("could not parse vote", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse vote"})
+		return
+	}
+	err = api.service.CastVote(ctx, electionId, &vote)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "cast vote"})
+}
+
+func (api *VoteAPI) castBallot(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	electionId := ctx.Param("id")
+	var ballot Ballot
+	err := ctx.ShouldBindJSON(&ballot)
+	if err != nil {
+		api.log.Error(err.Error())
+		api.log.Error("could not parse ballot", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse ballot"})
+		return
+	}
+	err = api.service.CastBallot(ctx, electionId, &ballot)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "cast ballot"})
+}
+
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrElectionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidCandidate), errors.Is(err, ErrInvalidBallot), errors.Is(err, ErrInvalidList),
+		errors.Is(err, ErrInvalidQuestion), errors.Is(err, ErrBallotRequired):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrAlreadyVoted), errors.Is(err, ErrElectionNotActive), errors.Is(err, ErrOutsideVotingWindow):
+		return http.StatusConflict
+	case errors.Is(err, ErrNotOnRoll), errors.Is(err, ErrVoterInactive), errors.Is(err, ErrNotEligible),
+		errors.Is(err, ErrVoteOnBehalf):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,142 @@
+package vote_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/candidate"
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/domain/vote"
+	"geraldaddo.com/live-voting-system/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func SetupServer() *gin.Engine {
+	server := gin.Default()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("requestId", uuid.New().String())
+		ctx.Set(user.ContextKey, &user.User{ID: "test-user", Role: user.Base, Active: true})
+	})
+	return server
+}
+
+type testRepositories struct {
+	votes *mocks.MockVoteRepository
+	elections *mocks.MockElectionRepository
+	candidates *mocks.MockCandidateRepository
+	lists *mocks.MockPartyListRepository
+	questions *mocks.MockQuestionRepository
+	weights *mocks.MockWeightRepository
+	roll *mocks.MockRollRepository
+	publisher *mocks.MockPublisher
+}
+
+func SetupTestAPI(ctrl *gomock.Controller) (*vote.VoteAPI, testRepositories) {
+	repos := testRepositories{
+		votes: mocks.NewMockVoteRepository(ctrl),
+		elections: mocks.NewMockElectionRepository(ctrl),
+		candidates: mocks.NewMockCandidateRepository(ctrl),
+		lists: mocks.NewMockPartyListRepository(ctrl),
+		questions: noQuestions(ctrl),
+		weights: unweighted(ctrl),
+		roll: enrolled(ctrl),
+		publisher: mocks.NewMockPublisher(ctrl),
+	}
+	service := vote.NewVoteService(repos.votes, repos.elections, repos.candidates, repos.lists, repos.questions, repos.weights, repos.roll, mocks.NewMockUserRepository(ctrl), repos.publisher, zap.NewNop())
+	return vote.NewVoteAPI(service, zap.NewNop()), repos
+}
+
+func TestCastVoteAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := SetupServer()
+	api, repos := SetupTestAPI(ctrl)
+	api.RegisterRoutes(server)
+
+	now := time.Now()
+	activeElection := &election.Election{
+		Status: election.Active,
+		Method: election.Plurality,
+		StartTime: now.Add(-1 * time.Hour),
+		EndTime: now.Add(time.Hour),
+	}
+	draftElection := &election.Election{
+		Status: election.Draft,
+		StartTime: now.Add(-1 * time.Hour),
+		EndTime: now.Add(time.Hour),
+	}
+
+	tests := []struct {
+		name string
+		body string
+		election *election.Election
+		lookupErr error
+		hasVoted bool
+		saves bool
+		status int
+		output string
+	}{
+		{"Fail to parse vote", `{"CandidateId": 1}`, nil, nil, false, false, 400, "could not parse vote"},
+		{"Election does not exist", `{"UserId": "test-user", "CandidateId": "test-candidate"}`, nil, sql.ErrNoRows, false, false, 404, vote.ErrElectionNotFound.Error()},
+		{"Election is not active", `{"UserId": "test-user", "CandidateId": "test-candidate"}`, draftElection, nil, false, false, 409, vote.ErrElectionNotActive.Error()},
+		{"User has already voted", `{"UserId": "test-user", "CandidateId": "test-candidate"}`, activeElection, nil, true, false, 409, vote.ErrAlreadyVoted.Error()},
+		{"Successfully cast vote", `{"UserId": "test-user", "CandidateId": "test-candidate"}`, activeElection, nil, false, true, 200, "cast vote"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.election != nil || test.lookupErr != nil {
+				repos.elections.
+					EXPECT().
+					GetById(gomock.Any(), "test-election-id").
+					Return(test.election, test.lookupErr).
+					Times(1)
+			}
+			if test.election != nil && test.election.Status == election.Active {
+				repos.votes.
+					EXPECT().
+					HasVoted(gomock.Any(), "test-election-id", "test-user").
+					Return(test.hasVoted, nil).
+					Times(1)
+			}
+			if test.saves {
+				repos.candidates.
+					EXPECT().
+					GetAllByElection(gomock.Any(), "test-election-id").
+					Return([]candidate.Candidate{{ID: "test-candidate", ElectionId: "test-election-id"}}, nil).
+					Times(1)
+				repos.votes.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				repos.publisher.EXPECT().PublishVote(gomock.Any(), gomock.Any()).Times(1)
+			}
+			recorder := httptest.NewRecorder()
+			request, _ := http.NewRequest("POST", "/elections/test-election-id/votes", strings.NewReader(test.body))
+			server.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Errorf("Expected status code: %d but got %d", test.status, recorder.Code)
+			}
+			var response map[string]string
+			err := json.Unmarshal(recorder.Body.Bytes(), &response)
+			if err != nil {
+				t.Fatal("Request did not return valid JSON")
+			}
+			message, exists := response["message"]
+			if !exists {
+				t.Fatal("JSON does not contain message key")
+			}
+			if message != test.output {
+				t.Errorf("Expected message: %s but got %s", test.output, message)
+			}
+		})
+	}
+}
@@ -1,11 +1,101 @@
 package vote
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/tally"
+)
 
 type Vote struct {
 	ID string
 	ElectionId string
+	// UserId is filled in with the signed in user.
 	UserId string
+	// QuestionId is the question the vote answers, or empty for a vote in
+	// the election itself.
+	QuestionId string
+	CandidateId string
+	// Which of CandidateId, Rankings, Approvals, Scores, ListId and Votes is
+	// filled in depends on the voting method of the election.
+	Rankings []string
+	Approvals []string
+	Scores map[string]int
+	ListId string
+	// Votes holds the votes given to each candidate on a quadratic or
+	// cumulative ballot.
+	Votes map[string]int
+	// Weight is the weight of the voter when the ballot was cast.
+	Weight int
+	// Blank marks a ballot cast without choosing any option, counted towards
+	// turnout but not for any option.
+	Blank bool
 	CreatedAt time.Time
 	UpdatedAt time.Time
-}
\ No newline at end of file
+}
+
+func (vote *Vote) Ballot() tally.Ballot {
+	return tally.Ballot{
+		Choice: vote.CandidateId,
+		Rankings: vote.Rankings,
+		Approvals: vote.Approvals,
+		Scores: vote.Scores,
+		List: vote.ListId,
+		Votes: vote.Votes,
+		Weight: vote.Weight,
+	}
+}
+
+// HashBallots fingerprints a set of ballots with SHA-256. Each ballot is
+// hashed on its own and the sorted hashes hashed together, so the order the
+// ballots are read in does not matter. Ids and voters are left out so the hash
+// does not tie anyone to their ballot.
+func HashBallots(votes []Vote) string {
+	hashes := make([]string, len(votes))
+	for i, v := range votes {
+		encoded, _ := json.Marshal(struct {
+			QuestionId string
+			CandidateId string
+			Rankings []string
+			Approvals []string
+			Scores map[string]int
+			ListId string
+			Votes map[string]int
+			Weight int
+			Blank bool
+		}{v.QuestionId, v.CandidateId, v.Rankings, v.Approvals, v.Scores, v.ListId, v.Votes, max(v.Weight, 1), v.Blank})
+		sum := sha256.Sum256(encoded)
+		hashes[i] = hex.EncodeToString(sum[:])
+	}
+	slices.Sort(hashes)
+	sum := sha256.Sum256([]byte(strings.Join(hashes, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Ballot answers every question of an election at once. Answers holds one
+// vote per question, plus one for the election itself when it has candidates
+// of its own.
+type Ballot struct {
+	// UserId is filled in with the signed in user.
+	UserId string
+	Answers []Vote `binding:"required"`
+}
+
+// CandidateTally sums the weights of the ballots supporting a candidate.
+type CandidateTally struct {
+	CandidateId string
+	Votes int
+}
+
+// Tally is the live count of an election. TotalVotes is the number of voters
+// who have cast a ballot, unweighted, however many candidates each ballot
+// supports.
+type Tally struct {
+	ElectionId string
+	TotalVotes int
+	Candidates []CandidateTally
+}
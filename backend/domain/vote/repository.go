@@ -0,0 +1,258 @@
+package vote
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+
+	"geraldaddo.com/live-voting-system/platform/models"
+	"github.com/lib/pq"
+)
+
+//go:generate mockgen -destination=../../mocks/mock_vote_repo.go -package=mocks . VoteRepository
+type VoteRepository interface {
+	models.Repository[Vote]
+	// SaveBallot stores the answers of a ballot in one transaction, storing
+	// none of them if the voter has already answered any.
+	SaveBallot(ctx context.Context, votes []Vote) error
+	HasVoted(ctx context.Context, electionId string, userId string) (bool, error)
+	CountByCandidate(ctx context.Context, electionId string) ([]CandidateTally, error)
+	CountVoters(ctx context.Context, electionId string) (int, error)
+	GetAllByElection(ctx context.Context, electionId string) ([]Vote, error)
+}
+
+const voteColumns = `id, election_id, user_id, COALESCE(question_id::TEXT, ''), COALESCE(candidate_id::TEXT, ''), rankings, approvals, scores,
+	COALESCE(list_id::TEXT, ''), vote_counts, weight, blank, created_at, updated_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanVote(row scanner) (*Vote, error) {
+	var v Vote
+	err := row.Scan(
+		&v.ID,
+		&v.ElectionId,
+		&v.UserId,
+		&v.QuestionId,
+		&v.CandidateId,
+		pq.Array(&v.Rankings),
+		pq.Array(&v.Approvals),
+		jsonScores{&v.Scores},
+		&v.ListId,
+		jsonScores{&v.Votes},
+		&v.Weight,
+		&v.Blank,
+		&v.CreatedAt,
+		&v.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// jsonScores stores the scores or votes of a ballot in a JSONB column, with
+// NULL for ballots that give nobody any.
+type jsonScores struct {
+	scores *map[string]int
+}
+
+func (j jsonScores) Value() (driver.Value, error) {
+	if len(*j.scores) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(*j.scores)
+}
+
+func (j jsonScores) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*j.scores = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(src, j.scores)
+	case string:
+		return json.Unmarshal([]byte(src), j.scores)
+	}
+	return errors.New("could not scan ballot scores")
+}
+
+type VoteRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewVoteRepository(db *sql.DB) *VoteRepositoryImpl {
+	return &VoteRepositoryImpl{db: db}
+}
+
+const insertStatement = `
+	INSERT INTO votes(election_id, user_id, question_id, candidate_id, rankings, approvals, scores, list_id, vote_counts,
+		weight, blank)
+	VALUES ($1, $2, NULLIF($3, '')::UUID, NULLIF($4, '')::UUID, $5, $6, $7, NULLIF($8, '')::UUID, $9, $10, $11)
+	ON CONFLICT DO NOTHING`
+
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// insertVote reports ErrAlreadyVoted when the voter has already answered the
+// election or question.
+func insertVote(db execer, vote *Vote) error {
+	result, err := db.Exec(
+		insertStatement,
+		vote.ElectionId,
+		vote.UserId,
+		vote.QuestionId,
+		vote.CandidateId,
+		pq.Array(vote.Rankings),
+		pq.Array(vote.Approvals),
+		jsonScores{&vote.Scores},
+		vote.ListId,
+		jsonScores{&vote.Votes},
+		max(vote.Weight, 1),
+		vote.Blank,
+	)
+	if err != nil {
+		return err
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if inserted == 0 {
+		return ErrAlreadyVoted
+	}
+	return nil
+}
+
+// Save relies on the unique (election_id, user_id, question_id) index rather
+// than a prior read, so concurrent ballots from the same voter cannot both be
+// stored.
+func (repo *VoteRepositoryImpl) Save(ctx context.Context, vote *Vote) error {
+	return insertVote(repo.db, vote)
+}
+
+func (repo *VoteRepositoryImpl) SaveBallot(ctx context.Context, votes []Vote) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for i := range votes {
+		err := insertVote(tx, &votes[i])
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (repo *VoteRepositoryImpl) GetById(ctx context.Context, id string) (*Vote, error) {
+	query := `SELECT ` + voteColumns + ` FROM votes WHERE id = $1`
+	row := repo.db.QueryRow(query, id)
+	return scanVote(row)
+}
+
+// GetAllByElection returns every ballot cast in the election, oldest first.
+func (repo *VoteRepositoryImpl) GetAllByElection(ctx context.Context, electionId string) ([]Vote, error) {
+	query := `SELECT ` + voteColumns + ` FROM votes WHERE election_id = $1 ORDER BY created_at ASC`
+	rows, err := repo.db.Query(query, electionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var votes []Vote
+	for rows.Next() {
+		v, err := scanVote(rows)
+		if err != nil {
+			return nil, err
+		}
+		votes = append(votes, *v)
+	}
+	return votes, rows.Err()
+}
+
+func (repo *VoteRepositoryImpl) HasVoted(ctx context.Context, electionId string, userId string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM votes WHERE election_id = $1 AND user_id = $2)`
+	var voted bool
+	err := repo.db.QueryRow(query, electionId, userId).Scan(&voted)
+	return voted, err
+}
+
+// CountByCandidate sums the weights of the ballots supporting each
+// candidate: a single choice, a first preference, an approval, a positive
+// score, a vote for the candidate's party list or quadratic and cumulative
+// votes. It is a live indication only; results are counted by the election's
+// voting method.
+func (repo *VoteRepositoryImpl) CountByCandidate(ctx context.Context, electionId string) ([]CandidateTally, error) {
+	query := `
+	SELECT c.id, COALESCE(SUM(v.weight), 0)
+	FROM candidates c
+	LEFT JOIN votes v ON v.election_id = c.election_id AND (
+		v.candidate_id = c.id
+		OR v.rankings[1] = c.id
+		OR c.id = ANY(v.approvals)
+		OR (v.scores ->> c.id::TEXT)::INT > 0
+		OR v.list_id = c.list_id
+		OR (v.vote_counts ->> c.id::TEXT)::INT > 0
+	)
+	WHERE c.election_id = $1
+	GROUP BY c.id, c.created_at
+	ORDER BY c.created_at ASC
+	`
+	rows, err := repo.db.Query(query, electionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tallies []CandidateTally
+	for rows.Next() {
+		var t CandidateTally
+		err := rows.Scan(&t.CandidateId, &t.Votes)
+		if err != nil {
+			return nil, err
+		}
+		tallies = append(tallies, t)
+	}
+	return tallies, nil
+}
+
+// CountVoters counts the voters who have cast a ballot. Every answer of a
+// ballot is stored at once, so the count never moves while a ballot is only
+// partly stored.
+func (repo *VoteRepositoryImpl) CountVoters(ctx context.Context, electionId string) (int, error) {
+	var voters int
+	err := repo.db.QueryRow(`SELECT COUNT(DISTINCT user_id) FROM votes WHERE election_id = $1`, electionId).Scan(&voters)
+	return voters, err
+}
+
+func (repo *VoteRepositoryImpl) UpdateOne(ctx context.Context, id string, v *Vote) error {
+	updateStatement := `
+	UPDATE votes
+	SET election_id = $1, user_id = $2, candidate_id = NULLIF($3, '')::UUID, rankings = $4, approvals = $5, scores = $6,
+		list_id = NULLIF($7, '')::UUID, vote_counts = $8, weight = $9, question_id = NULLIF($10, '')::UUID, blank = $11,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $12
+	`
+	_, err := repo.db.Exec(
+		updateStatement,
+		v.ElectionId,
+		v.UserId,
+		v.CandidateId,
+		pq.Array(v.Rankings),
+		pq.Array(v.Approvals),
+		jsonScores{&v.Scores},
+		v.ListId,
+		jsonScores{&v.Votes},
+		max(v.Weight, 1),
+		v.QuestionId,
+		v.Blank,
+		id,
+	)
+	return err
+}
@@ -0,0 +1,460 @@
+package vote
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/candidate"
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/eligibility"
+	"geraldaddo.com/live-voting-system/domain/partylist"
+	"geraldaddo.com/live-voting-system/domain/question"
+	"geraldaddo.com/live-voting-system/domain/roll"
+	"geraldaddo.com/live-voting-system/domain/tally"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/domain/weight"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrElectionNotFound = errors.New("Election does not exist")
+	ErrElectionNotActive = errors.New("Election is not active")
+	ErrOutsideVotingWindow = errors.New("Election is not accepting votes at this time")
+	ErrInvalidCandidate = tally.ErrInvalidCandidate
+	ErrAlreadyVoted = errors.New("User has already voted in this election")
+	ErrInvalidBallot = tally.ErrInvalidBallot
+	ErrInvalidList = tally.ErrInvalidList
+	ErrInvalidQuestion = errors.New("Question is not part of this election")
+	ErrBallotRequired = errors.New("Election has several questions; cast a ballot answering all of them")
+	ErrNotOnRoll = errors.New("User is not on the electoral roll of this election")
+	ErrVoterInactive = errors.New("User account is not active")
+	ErrNotEligible = errors.New("User is not eligible to vote in this election")
+	ErrVoteOnBehalf = errors.New("Users can only cast their own vote")
+	ErrResultsHidden = errors.New("Results are only shown to admins until the election is certified")
+)
+
+// Publisher is told about every stored vote so live result feeds can refresh.
+//
+//go:generate mockgen -destination=../../mocks/mock_vote_publisher.go -package=mocks . Publisher
+type Publisher interface {
+	PublishVote(ctx context.Context, vote *Vote)
+}
+
+type VoteService struct {
+	repo VoteRepository
+	elections election.ElectionRepository
+	candidates candidate.CandidateRepository
+	lists partylist.PartyListRepository
+	questions question.QuestionRepository
+	weights weight.WeightRepository
+	roll roll.RollRepository
+	users user.UserRepository
+	publisher Publisher
+	log *zap.Logger
+}
+
+func NewVoteService(
+	repo VoteRepository,
+	elections election.ElectionRepository,
+	candidates candidate.CandidateRepository,
+	lists partylist.PartyListRepository,
+	questions question.QuestionRepository,
+	weights weight.WeightRepository,
+	roll roll.RollRepository,
+	users user.UserRepository,
+	publisher Publisher,
+	logger *zap.Logger,
+) *VoteService {
+	return &VoteService{
+		repo: repo,
+		elections: elections,
+		candidates: candidates,
+		lists: lists,
+		questions: questions,
+		weights: weights,
+		roll: roll,
+		users: users,
+		publisher: publisher,
+		log: logger,
+	}
+}
+
+// voter returns who is casting a vote. Only signed in users vote, always as
+// themselves, and cannot cast a vote for anyone else.
+func (service *VoteService) voter(ctx context.Context, userId string) (string, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	u, ok := user.FromContext(ctx)
+	if !ok {
+		service.log.Warn("Vote cast without a signed in voter", zap.String("request_id", requestId))
+		return "", ErrVoteOnBehalf
+	}
+	if userId != "" && userId != u.ID {
+		service.log.Warn("User: " + u.ID + " tried to vote for user: " + userId, zap.String("request_id", requestId))
+		return "", ErrVoteOnBehalf
+	}
+	return u.ID, nil
+}
+
+func (service *VoteService) CastVote(ctx context.Context, electionId string, vote *Vote) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	var err error
+	vote.UserId, err = service.voter(ctx, vote.UserId)
+	if err != nil {
+		return err
+	}
+	e, err := service.openElection(ctx, electionId, vote.UserId)
+	if err != nil {
+		return err
+	}
+	questions, err := service.questions.GetAllByElection(ctx, electionId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get questions for election: " + electionId, zap.String("request_id", requestId))
+		return errors.New("Could not cast vote")
+	}
+	if len(questions) > 0 {
+		service.log.Warn("Election: " + electionId + " needs a ballot answering every question", zap.String("request_id", requestId))
+		return ErrBallotRequired
+	}
+	vote.Weight, err = service.voterWeight(ctx, electionId, vote.UserId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get weight of voter: " + vote.UserId, zap.String("request_id", requestId))
+		return errors.New("Could not cast vote")
+	}
+	err = service.validateBallot(ctx, e, electionId, vote)
+	if isInvalidBallot(err) {
+		service.log.Warn(err.Error() + " in election: " + electionId, zap.String("request_id", requestId))
+		return err
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not validate ballot for election: " + electionId, zap.String("request_id", requestId))
+		return errors.New("Could not cast vote")
+	}
+	vote.ElectionId = electionId
+	err = service.repo.Save(ctx, vote)
+	if errors.Is(err, ErrAlreadyVoted) {
+		service.log.Warn("User: " + vote.UserId + " has already voted in election: " + electionId, zap.String("request_id", requestId))
+		return ErrAlreadyVoted
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not save vote for election: " + electionId, zap.String("request_id", requestId))
+		return errors.New("Could not cast vote")
+	}
+	service.publisher.PublishVote(ctx, vote)
+	service.log.Info("Cast vote in election: " + electionId, zap.String("request_id", requestId))
+	return nil
+}
+
+// CastBallot stores the answers to every question of the election together:
+// either all of them are stored or none are.
+func (service *VoteService) CastBallot(ctx context.Context, electionId string, ballot *Ballot) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	var err error
+	ballot.UserId, err = service.voter(ctx, ballot.UserId)
+	if err != nil {
+		return err
+	}
+	e, err := service.openElection(ctx, electionId, ballot.UserId)
+	if err != nil {
+		return err
+	}
+	weight, err := service.voterWeight(ctx, electionId, ballot.UserId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get weight of voter: " + ballot.UserId, zap.String("request_id", requestId))
+		return errors.New("Could not cast ballot")
+	}
+	for i := range ballot.Answers {
+		ballot.Answers[i].ElectionId = electionId
+		ballot.Answers[i].UserId = ballot.UserId
+		ballot.Answers[i].Weight = weight
+	}
+	err = service.validateAnswers(ctx, e, electionId, ballot.Answers)
+	if isInvalidBallot(err) {
+		service.log.Warn(err.Error() + " in election: " + electionId, zap.String("request_id", requestId))
+		return err
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not validate ballot for election: " + electionId, zap.String("request_id", requestId))
+		return errors.New("Could not cast ballot")
+	}
+	err = service.repo.SaveBallot(ctx, ballot.Answers)
+	if errors.Is(err, ErrAlreadyVoted) {
+		service.log.Warn("User: " + ballot.UserId + " has already voted in election: " + electionId, zap.String("request_id", requestId))
+		return ErrAlreadyVoted
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not save ballot for election: " + electionId, zap.String("request_id", requestId))
+		return errors.New("Could not cast ballot")
+	}
+	for i := range ballot.Answers {
+		service.publisher.PublishVote(ctx, &ballot.Answers[i])
+	}
+	service.log.Info("Cast ballot in election: " + electionId, zap.String("request_id", requestId))
+	return nil
+}
+
+// openElection returns the election if it is accepting votes and the user
+// has not voted in it yet.
+func (service *VoteService) openElection(ctx context.Context, electionId string, userId string) (*election.Election, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.elections.GetById(ctx, electionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("Election with id: " + electionId + " does not exist", zap.String("request_id", requestId))
+		return nil, ErrElectionNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not cast vote")
+	}
+	if e.Status != election.Active {
+		service.log.Warn("Election is not active: " + electionId, zap.String("request_id", requestId))
+		return nil, ErrElectionNotActive
+	}
+	now := time.Now()
+	if now.Before(e.StartTime) || !now.Before(e.EndTime) {
+		service.log.Warn("Election is outside its voting window: " + electionId, zap.String("request_id", requestId))
+		return nil, ErrOutsideVotingWindow
+	}
+	err = service.checkEligibility(ctx, e, userId)
+	if err != nil {
+		return nil, err
+	}
+	voted, err := service.repo.HasVoted(ctx, electionId, userId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not check for an existing vote in election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not cast vote")
+	}
+	if voted {
+		service.log.Warn("User: " + userId + " has already voted in election: " + electionId, zap.String("request_id", requestId))
+		return nil, ErrAlreadyVoted
+	}
+	return e, nil
+}
+
+// checkEligibility admits active users who are on the electoral roll or
+// match the eligibility rule of the election.
+func (service *VoteService) checkEligibility(ctx context.Context, e *election.Election, userId string) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	voter, err := service.roll.GetVoter(ctx, e.ID, userId)
+	if err == nil {
+		if !voter.Active {
+			service.log.Warn("User: " + userId + " is not active", zap.String("request_id", requestId))
+			return ErrVoterInactive
+		}
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		service.log.Error(err.Error())
+		service.log.Error("Could not check the electoral roll of election: " + e.ID, zap.String("request_id", requestId))
+		return errors.New("Could not cast vote")
+	}
+	if e.Eligibility == "" {
+		service.log.Warn("User: " + userId + " is not on the roll of election: " + e.ID, zap.String("request_id", requestId))
+		return ErrNotOnRoll
+	}
+	rule, err := eligibility.Parse(e.Eligibility)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not parse eligibility rule of election: " + e.ID, zap.String("request_id", requestId))
+		return errors.New("Could not cast vote")
+	}
+	u, err := service.users.GetById(ctx, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("User: " + userId + " does not exist", zap.String("request_id", requestId))
+		return ErrNotEligible
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get user: " + userId, zap.String("request_id", requestId))
+		return errors.New("Could not cast vote")
+	}
+	if !rule.Matches(u, time.Now()) {
+		service.log.Warn("User: " + userId + " does not match the eligibility rule of election: " + e.ID, zap.String("request_id", requestId))
+		return ErrNotEligible
+	}
+	if !u.Active {
+		service.log.Warn("User: " + userId + " is not active", zap.String("request_id", requestId))
+		return ErrVoterInactive
+	}
+	return nil
+}
+
+func isInvalidBallot(err error) bool {
+	return errors.Is(err, ErrInvalidBallot) || errors.Is(err, ErrInvalidCandidate) || errors.Is(err, ErrInvalidList) ||
+		errors.Is(err, ErrInvalidQuestion)
+}
+
+// voterWeight returns the weight the voter's ballot carries, one unless a
+// weight was set for the election.
+func (service *VoteService) voterWeight(ctx context.Context, electionId string, userId string) (int, error) {
+	w, err := service.weights.GetByVoter(ctx, electionId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return w.Weight, nil
+}
+
+// validateBallot checks the ballot against the voting method of the
+// election and the candidates and party lists on its ballot.
+func (service *VoteService) validateBallot(ctx context.Context, e *election.Election, electionId string, vote *Vote) error {
+	m, err := tally.Lookup(string(e.Method))
+	if err != nil {
+		return err
+	}
+	candidates, err := service.candidates.GetAllByElection(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	contest, err := service.electionContest(ctx, e, electionId, candidates)
+	if err != nil {
+		return err
+	}
+	return validateVote(m, contest, vote)
+}
+
+// validateVote checks the vote against the voting method. Blank votes must
+// not select anything.
+func validateVote(m tally.Method, contest tally.Contest, vote *Vote) error {
+	if vote.Blank {
+		if !vote.Ballot().IsEmpty() {
+			return fmt.Errorf("%w: a blank ballot cannot select any option", ErrInvalidBallot)
+		}
+		return nil
+	}
+	return m.Validate(contest, vote.Ballot())
+}
+
+// validateAnswers checks that the answers cover every question of the
+// election exactly once, along with the election itself when it has
+// candidates of its own, and that each answer suits its voting method.
+func (service *VoteService) validateAnswers(ctx context.Context, e *election.Election, electionId string, answers []Vote) error {
+	questions, err := service.questions.GetAllByElection(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	candidates, err := service.candidates.GetAllByElection(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	contests := make(map[string]tally.Contest, len(questions) + 1)
+	methods := make(map[string]election.VotingMethod, len(questions) + 1)
+	if len(candidate.ForQuestion(candidates, "")) > 0 {
+		contests[""], err = service.electionContest(ctx, e, electionId, candidates)
+		if err != nil {
+			return err
+		}
+		methods[""] = e.Method
+	}
+	for _, q := range questions {
+		contests[q.ID] = q.Contest(candidate.Ids(candidate.ForQuestion(candidates, q.ID)))
+		methods[q.ID] = q.Method
+	}
+
+	answered := make(map[string]bool, len(answers))
+	for i := range answers {
+		answer := &answers[i]
+		contest, ok := contests[answer.QuestionId]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidQuestion, answer.QuestionId)
+		}
+		if answered[answer.QuestionId] {
+			return fmt.Errorf("%w: question %s is answered more than once", ErrInvalidBallot, answer.QuestionId)
+		}
+		answered[answer.QuestionId] = true
+		m, err := tally.Lookup(string(methods[answer.QuestionId]))
+		if err != nil {
+			return err
+		}
+		err = validateVote(m, contest, answer)
+		if err != nil {
+			return err
+		}
+	}
+	if len(answered) < len(contests) {
+		return fmt.Errorf("%w: every question must be answered", ErrInvalidBallot)
+	}
+	return nil
+}
+
+// electionContest describes the election itself, without the options of its
+// questions, to its voting method.
+func (service *VoteService) electionContest(ctx context.Context, e *election.Election, electionId string, candidates []candidate.Candidate) (tally.Contest, error) {
+	own := candidate.ForQuestion(candidates, "")
+	var lists []tally.List
+	if e.Method.IsPartyList() {
+		partyLists, err := service.lists.GetAllByElection(ctx, electionId)
+		if err != nil {
+			return tally.Contest{}, err
+		}
+		lists = candidate.GroupByList(partyLists, own)
+	}
+	return e.Contest(candidate.Ids(own), lists), nil
+}
+
+func (service *VoteService) GetTally(ctx context.Context, electionId string) (*Tally, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.elections.GetById(ctx, electionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("Election with id: " + electionId + " does not exist", zap.String("request_id", requestId))
+		return nil, ErrElectionNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not get tally")
+	}
+	hidden, err := service.embargoed(ctx, e)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not check certification of election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not get tally")
+	}
+	if hidden {
+		service.log.Warn("Tally of uncertified election: " + electionId + " requested by a non-admin", zap.String("request_id", requestId))
+		return nil, ErrResultsHidden
+	}
+	candidates, err := service.repo.CountByCandidate(ctx, electionId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not count votes for election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not get tally")
+	}
+	voters, err := service.repo.CountVoters(ctx, electionId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not count voters for election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Could not get tally")
+	}
+	return &Tally{ElectionId: electionId, TotalVotes: voters, Candidates: candidates}, nil
+}
+
+// embargoed reports whether the tally of an election is kept from the user of
+// the request, the same way results are: only admins see the count of an
+// election that was never certified.
+func (service *VoteService) embargoed(ctx context.Context, e *election.Election) (bool, error) {
+	if user.IsAdmin(ctx) {
+		return false, nil
+	}
+	switch e.Status {
+	case election.Active, election.Closed:
+		return true, nil
+	case election.Archived:
+		certified, err := service.elections.IsCertified(ctx, e.ID)
+		return !certified, err
+	}
+	return false, nil
+}
@@ -0,0 +1,1026 @@
+package vote_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"geraldaddo.com/live-voting-system/domain/candidate"
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/partylist"
+	"geraldaddo.com/live-voting-system/domain/question"
+	"geraldaddo.com/live-voting-system/domain/roll"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/domain/vote"
+	"geraldaddo.com/live-voting-system/domain/weight"
+	"geraldaddo.com/live-voting-system/mocks"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestCastVote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+	mockPublisher := mocks.NewMockPublisher(ctrl)
+
+	electionId := "test-election-id"
+	now := time.Now()
+	activeElection := &election.Election{
+		ID: electionId,
+		StartTime: now.Add(-1 * time.Hour),
+		EndTime: now.Add(time.Hour),
+		Status: election.Active,
+		Method: election.Plurality,
+	}
+	input := &vote.Vote{UserId: "test-user-id", CandidateId: "test-candidate-id"}
+
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), electionId).
+		Return(activeElection, nil).
+		Times(1)
+	mockVoteRepository.
+		EXPECT().
+		HasVoted(gomock.Any(), electionId, "test-user-id").
+		Return(false, nil).
+		Times(1)
+	mockCandidateRepository.
+		EXPECT().
+		GetAllByElection(gomock.Any(), electionId).
+		Return([]candidate.Candidate{{ID: "test-candidate-id", ElectionId: electionId}}, nil).
+		Times(1)
+	mockVoteRepository.
+		EXPECT().
+		Save(gomock.Any(), input).
+		Return(nil).
+		Times(1)
+	mockPublisher.
+		EXPECT().
+		PublishVote(gomock.Any(), input).
+		Times(1)
+
+	service := vote.NewVoteService(mockVoteRepository, mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), unweighted(ctrl), enrolled(ctrl), mocks.NewMockUserRepository(ctrl), mockPublisher, zap.NewNop())
+	ctx := voterContext()
+	err := service.CastVote(ctx, electionId, input)
+
+	if err != nil {
+		t.Error("Cast vote returned an error", err.Error())
+	}
+	if input.ElectionId != electionId {
+		t.Errorf("Expected vote election id: %s but got %s", electionId, input.ElectionId)
+	}
+}
+
+func TestCastVoteShouldOnlyAcceptSignedInUsersOwnVote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	signedIn := context.WithValue(context.Background(), "requestId", "test-request-id")
+	signedIn = context.WithValue(signedIn, user.ContextKey, &user.User{ID: "test-user-id", Role: user.Base, Active: true})
+	tests := []struct {
+		name string
+		ctx context.Context
+		userId string
+	}{
+		{"Vote for another user", signedIn, "other-user-id"},
+		{"No voter", context.WithValue(context.Background(), "requestId", "test-request-id"), ""},
+		{"Voter named in the body but not signed in", context.WithValue(context.Background(), "requestId", "test-request-id"), "test-user-id"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// No repository is consulted before the voter is known.
+			service := vote.NewVoteService(mocks.NewMockVoteRepository(ctrl), mocks.NewMockElectionRepository(ctrl), mocks.NewMockCandidateRepository(ctrl), mocks.NewMockPartyListRepository(ctrl), mocks.NewMockQuestionRepository(ctrl), mocks.NewMockWeightRepository(ctrl), mocks.NewMockRollRepository(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockPublisher(ctrl), zap.NewNop())
+			err := service.CastVote(test.ctx, "test-election-id", &vote.Vote{UserId: test.userId, CandidateId: "test-candidate-id"})
+			if !errors.Is(err, vote.ErrVoteOnBehalf) {
+				t.Errorf("Expected error: %v but got %v", vote.ErrVoteOnBehalf, err)
+			}
+		})
+	}
+}
+
+func TestCastVoteShouldFailIfElectionIsNotAcceptingVotes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	tests := []struct {
+		name string
+		election *election.Election
+		lookupErr error
+		expected error
+	}{
+		{"Missing election", nil, sql.ErrNoRows, vote.ErrElectionNotFound},
+		{"Draft election", &election.Election{Status: election.Draft, StartTime: now.Add(-1 * time.Hour), EndTime: now.Add(time.Hour)}, nil, vote.ErrElectionNotActive},
+		{"Closed election", &election.Election{Status: election.Closed, StartTime: now.Add(-1 * time.Hour), EndTime: now.Add(time.Hour)}, nil, vote.ErrElectionNotActive},
+		{"Active election before start time", &election.Election{Status: election.Active, StartTime: now.Add(time.Hour), EndTime: now.Add(2 * time.Hour)}, nil, vote.ErrOutsideVotingWindow},
+		{"Active election after end time", &election.Election{Status: election.Active, StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-1 * time.Hour)}, nil, vote.ErrOutsideVotingWindow},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockPublisher := mocks.NewMockPublisher(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), gomock.Any()).
+				Return(test.election, test.lookupErr).
+				Times(1)
+			service := vote.NewVoteService(mockVoteRepository, mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), unweighted(ctrl), enrolled(ctrl), mocks.NewMockUserRepository(ctrl), mockPublisher, zap.NewNop())
+			ctx := voterContext()
+			err := service.CastVote(ctx, "test-id", &vote.Vote{UserId: "test-user-id", CandidateId: "test-candidate-id"})
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestCastVoteShouldFailIfCandidateIsNotOnBallot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	activeElection := &election.Election{
+		ID: "test-election-id",
+		StartTime: now.Add(-1 * time.Hour),
+		EndTime: now.Add(time.Hour),
+		Status: election.Active,
+		Method: election.Plurality,
+	}
+	tests := []struct {
+		name string
+		candidates []candidate.Candidate
+	}{
+		{"No candidates on the ballot", nil},
+		{"Candidate missing from the ballot", []candidate.Candidate{{ID: "other-candidate-id", ElectionId: "test-election-id"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockPublisher := mocks.NewMockPublisher(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), gomock.Any()).
+				Return(activeElection, nil).
+				Times(1)
+			mockVoteRepository.
+				EXPECT().
+				HasVoted(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(false, nil).
+				Times(1)
+			mockCandidateRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return(test.candidates, nil).
+				Times(1)
+			service := vote.NewVoteService(mockVoteRepository, mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), unweighted(ctrl), enrolled(ctrl), mocks.NewMockUserRepository(ctrl), mockPublisher, zap.NewNop())
+			ctx := voterContext()
+			err := service.CastVote(ctx, "test-election-id", &vote.Vote{UserId: "test-user-id", CandidateId: "test-candidate-id"})
+			if !errors.Is(err, vote.ErrInvalidCandidate) {
+				t.Errorf("Expected error: %v but got %v", vote.ErrInvalidCandidate, err)
+			}
+		})
+	}
+}
+
+func TestCastVoteShouldValidateBallotForVotingMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	ballot := []candidate.Candidate{
+		{ID: "candidate-1", ElectionId: "test-election-id"},
+		{ID: "candidate-2", ElectionId: "test-election-id"},
+		{ID: "candidate-3", ElectionId: "test-election-id"},
+	}
+	tests := []struct {
+		name string
+		method election.VotingMethod
+		vote *vote.Vote
+		expected error
+	}{
+		{"Plurality choice", election.Plurality, &vote.Vote{UserId: "test-user-id", CandidateId: "candidate-1"}, nil},
+		{"Plurality with rankings", election.Plurality, &vote.Vote{UserId: "test-user-id", CandidateId: "candidate-1", Rankings: []string{"candidate-1"}}, vote.ErrInvalidBallot},
+		{"Full ranking", election.InstantRunoff, &vote.Vote{UserId: "test-user-id", Rankings: []string{"candidate-2", "candidate-3", "candidate-1"}}, nil},
+		{"Partial ranking", election.InstantRunoff, &vote.Vote{UserId: "test-user-id", Rankings: []string{"candidate-3"}}, nil},
+		{"No rankings", election.InstantRunoff, &vote.Vote{UserId: "test-user-id"}, vote.ErrInvalidBallot},
+		{"Single choice on a ranked ballot", election.InstantRunoff, &vote.Vote{UserId: "test-user-id", CandidateId: "candidate-1"}, vote.ErrInvalidBallot},
+		{"Candidate ranked twice", election.InstantRunoff, &vote.Vote{UserId: "test-user-id", Rankings: []string{"candidate-1", "candidate-2", "candidate-1"}}, vote.ErrInvalidBallot},
+		{"Candidate not on the ballot", election.InstantRunoff, &vote.Vote{UserId: "test-user-id", Rankings: []string{"candidate-1", "candidate-4"}}, vote.ErrInvalidCandidate},
+		{"Borda ranking", election.Borda, &vote.Vote{UserId: "test-user-id", Rankings: []string{"candidate-1", "candidate-2"}}, nil},
+		{"Borda without rankings", election.Borda, &vote.Vote{UserId: "test-user-id", Approvals: []string{"candidate-1"}}, vote.ErrInvalidBallot},
+		{"Approvals", election.Approval, &vote.Vote{UserId: "test-user-id", Approvals: []string{"candidate-1", "candidate-3"}}, nil},
+		{"Candidate approved twice", election.Approval, &vote.Vote{UserId: "test-user-id", Approvals: []string{"candidate-1", "candidate-1"}}, vote.ErrInvalidBallot},
+		{"Approval of unknown candidate", election.Approval, &vote.Vote{UserId: "test-user-id", Approvals: []string{"candidate-4"}}, vote.ErrInvalidCandidate},
+		{"Scores", election.Score, &vote.Vote{UserId: "test-user-id", Scores: map[string]int{"candidate-1": 10, "candidate-2": 0}}, nil},
+		{"Score above the maximum", election.Score, &vote.Vote{UserId: "test-user-id", Scores: map[string]int{"candidate-1": 11}}, vote.ErrInvalidBallot},
+		{"Negative score", election.Score, &vote.Vote{UserId: "test-user-id", Scores: map[string]int{"candidate-1": -1}}, vote.ErrInvalidBallot},
+		{"Score for unknown candidate", election.Score, &vote.Vote{UserId: "test-user-id", Scores: map[string]int{"candidate-4": 5}}, vote.ErrInvalidCandidate},
+		{"Quadratic votes within budget", election.Quadratic, &vote.Vote{UserId: "test-user-id", Votes: map[string]int{"candidate-1": 3, "candidate-2": 1}}, nil},
+		{"Quadratic votes over budget", election.Quadratic, &vote.Vote{UserId: "test-user-id", Votes: map[string]int{"candidate-1": 4}}, vote.ErrInvalidBallot},
+		{"Scores on a quadratic ballot", election.Quadratic, &vote.Vote{UserId: "test-user-id", Scores: map[string]int{"candidate-1": 1}}, vote.ErrInvalidBallot},
+		{"Blank ballot", election.InstantRunoff, &vote.Vote{UserId: "test-user-id", Blank: true}, nil},
+		{"Blank ballot with a choice", election.Plurality, &vote.Vote{UserId: "test-user-id", CandidateId: "candidate-1", Blank: true}, vote.ErrInvalidBallot},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockPublisher := mocks.NewMockPublisher(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&election.Election{
+					ID: "test-election-id",
+					StartTime: now.Add(-1 * time.Hour),
+					EndTime: now.Add(time.Hour),
+					Status: election.Active,
+					Method: test.method,
+					Credits: 10,
+				}, nil).
+				Times(1)
+			mockVoteRepository.
+				EXPECT().
+				HasVoted(gomock.Any(), "test-election-id", "test-user-id").
+				Return(false, nil).
+				Times(1)
+			mockCandidateRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return(ballot, nil).
+				AnyTimes()
+			if test.expected == nil {
+				mockVoteRepository.
+					EXPECT().
+					Save(gomock.Any(), test.vote).
+					Return(nil).
+					Times(1)
+				mockPublisher.
+					EXPECT().
+					PublishVote(gomock.Any(), test.vote).
+					Times(1)
+			}
+			service := vote.NewVoteService(mockVoteRepository, mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), unweighted(ctrl), enrolled(ctrl), mocks.NewMockUserRepository(ctrl), mockPublisher, zap.NewNop())
+			ctx := voterContext()
+			err := service.CastVote(ctx, "test-election-id", test.vote)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestCastVoteForPartyList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	partyListElection := &election.Election{
+		ID: "test-election-id",
+		StartTime: now.Add(-1 * time.Hour),
+		EndTime: now.Add(time.Hour),
+		Status: election.Active,
+		Method: election.DHondt,
+		Seats: 2,
+	}
+	tests := []struct {
+		name string
+		vote *vote.Vote
+		expected error
+	}{
+		{"Vote for a list", &vote.Vote{UserId: "test-user-id", ListId: "list-1"}, nil},
+		{"Vote for a list from another election", &vote.Vote{UserId: "test-user-id", ListId: "list-2"}, vote.ErrInvalidList},
+		{"Vote for a candidate", &vote.Vote{UserId: "test-user-id", CandidateId: "candidate-1"}, vote.ErrInvalidBallot},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockPartyListRepository := mocks.NewMockPartyListRepository(ctrl)
+			mockPublisher := mocks.NewMockPublisher(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(partyListElection, nil).
+				Times(1)
+			mockVoteRepository.
+				EXPECT().
+				HasVoted(gomock.Any(), "test-election-id", "test-user-id").
+				Return(false, nil).
+				Times(1)
+			mockCandidateRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return([]candidate.Candidate{{ID: "candidate-1", ElectionId: "test-election-id", ListId: "list-1"}}, nil).
+				Times(1)
+			mockPartyListRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return([]partylist.PartyList{{ID: "list-1", ElectionId: "test-election-id"}}, nil).
+				Times(1)
+			if test.expected == nil {
+				mockVoteRepository.EXPECT().Save(gomock.Any(), test.vote).Return(nil).Times(1)
+				mockPublisher.EXPECT().PublishVote(gomock.Any(), test.vote).Times(1)
+			}
+			service := vote.NewVoteService(mockVoteRepository, mockElectionRepository, mockCandidateRepository, mockPartyListRepository, noQuestions(ctrl), unweighted(ctrl), enrolled(ctrl), mocks.NewMockUserRepository(ctrl), mockPublisher, zap.NewNop())
+			ctx := voterContext()
+			err := service.CastVote(ctx, "test-election-id", test.vote)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestCastVoteShouldRecordVoterWeight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	cumulativeElection := &election.Election{
+		ID: "test-election-id",
+		StartTime: now.Add(-1 * time.Hour),
+		EndTime: now.Add(time.Hour),
+		Status: election.Active,
+		Method: election.Cumulative,
+		Seats: 3,
+	}
+	tests := []struct {
+		name string
+		weight *weight.VoterWeight
+		votes map[string]int
+		recorded int
+		expected error
+	}{
+		{"Shareholder spreads shares times seats", &weight.VoterWeight{Weight: 100}, map[string]int{"candidate-1": 200, "candidate-2": 100}, 100, nil},
+		{"Shareholder spreads too many votes", &weight.VoterWeight{Weight: 100}, map[string]int{"candidate-1": 301}, 100, vote.ErrInvalidBallot},
+		{"Voter without weight", nil, map[string]int{"candidate-1": 3}, 1, nil},
+		{"Voter without weight spreads too many votes", nil, map[string]int{"candidate-1": 4}, 1, vote.ErrInvalidBallot},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockWeightRepository := mocks.NewMockWeightRepository(ctrl)
+			mockPublisher := mocks.NewMockPublisher(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(cumulativeElection, nil).
+				Times(1)
+			mockVoteRepository.
+				EXPECT().
+				HasVoted(gomock.Any(), "test-election-id", "test-user-id").
+				Return(false, nil).
+				Times(1)
+			if test.weight != nil {
+				mockWeightRepository.EXPECT().GetByVoter(gomock.Any(), "test-election-id", "test-user-id").Return(test.weight, nil).Times(1)
+			} else {
+				mockWeightRepository.EXPECT().GetByVoter(gomock.Any(), "test-election-id", "test-user-id").Return(nil, sql.ErrNoRows).Times(1)
+			}
+			mockCandidateRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return([]candidate.Candidate{{ID: "candidate-1"}, {ID: "candidate-2"}, {ID: "candidate-3"}}, nil).
+				Times(1)
+			input := &vote.Vote{UserId: "test-user-id", Votes: test.votes}
+			if test.expected == nil {
+				mockVoteRepository.EXPECT().Save(gomock.Any(), input).Return(nil).Times(1)
+				mockPublisher.EXPECT().PublishVote(gomock.Any(), input).Times(1)
+			}
+			service := vote.NewVoteService(mockVoteRepository, mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), mockWeightRepository, enrolled(ctrl), mocks.NewMockUserRepository(ctrl), mockPublisher, zap.NewNop())
+			ctx := voterContext()
+			err := service.CastVote(ctx, "test-election-id", input)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+			if input.Weight != test.recorded {
+				t.Errorf("Expected weight: %d but got %d", test.recorded, input.Weight)
+			}
+		})
+	}
+}
+
+func TestCastVoteShouldFailIfUserHasAlreadyVoted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	activeElection := &election.Election{
+		ID: "test-election-id",
+		StartTime: now.Add(-1 * time.Hour),
+		EndTime: now.Add(time.Hour),
+		Status: election.Active,
+		Method: election.Plurality,
+	}
+	tests := []struct {
+		name string
+		hasVoted bool
+		saveErr error
+	}{
+		{"Existing vote found before saving", true, nil},
+		{"Concurrent vote rejected while saving", false, vote.ErrAlreadyVoted},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockPublisher := mocks.NewMockPublisher(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), gomock.Any()).
+				Return(activeElection, nil).
+				Times(1)
+			mockVoteRepository.
+				EXPECT().
+				HasVoted(gomock.Any(), "test-election-id", "test-user-id").
+				Return(test.hasVoted, nil).
+				Times(1)
+			if !test.hasVoted {
+				mockCandidateRepository.
+					EXPECT().
+					GetAllByElection(gomock.Any(), gomock.Any()).
+					Return([]candidate.Candidate{{ID: "test-candidate-id", ElectionId: "test-election-id"}}, nil).
+					Times(1)
+				mockVoteRepository.
+					EXPECT().
+					Save(gomock.Any(), gomock.Any()).
+					Return(test.saveErr).
+					Times(1)
+			}
+			service := vote.NewVoteService(mockVoteRepository, mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), unweighted(ctrl), enrolled(ctrl), mocks.NewMockUserRepository(ctrl), mockPublisher, zap.NewNop())
+			ctx := voterContext()
+			err := service.CastVote(ctx, "test-election-id", &vote.Vote{UserId: "test-user-id", CandidateId: "test-candidate-id"})
+			if !errors.Is(err, vote.ErrAlreadyVoted) {
+				t.Errorf("Expected error: %v but got %v", vote.ErrAlreadyVoted, err)
+			}
+		})
+	}
+}
+
+// uniqueVoteRepository mimics the unique (election_id, user_id, question_id)
+// index so the service can be exercised under real concurrency.
+type uniqueVoteRepository struct {
+	mu sync.Mutex
+	votes map[string]bool
+}
+
+func (repo *uniqueVoteRepository) Save(ctx context.Context, v *vote.Vote) error {
+	return repo.SaveBallot(ctx, []vote.Vote{*v})
+}
+
+func (repo *uniqueVoteRepository) SaveBallot(ctx context.Context, votes []vote.Vote) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	for _, v := range votes {
+		if repo.votes[v.ElectionId + "/" + v.UserId + "/" + v.QuestionId] {
+			return vote.ErrAlreadyVoted
+		}
+	}
+	for _, v := range votes {
+		repo.votes[v.ElectionId + "/" + v.UserId + "/" + v.QuestionId] = true
+		repo.votes[v.ElectionId + "/" + v.UserId] = true
+	}
+	return nil
+}
+
+func (repo *uniqueVoteRepository) GetById(ctx context.Context, id string) (*vote.Vote, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (repo *uniqueVoteRepository) UpdateOne(ctx context.Context, id string, v *vote.Vote) error {
+	return nil
+}
+
+func (repo *uniqueVoteRepository) CountByCandidate(ctx context.Context, electionId string) ([]vote.CandidateTally, error) {
+	return nil, nil
+}
+
+func (repo *uniqueVoteRepository) CountVoters(ctx context.Context, electionId string) (int, error) {
+	return 0, nil
+}
+
+func (repo *uniqueVoteRepository) GetAllByElection(ctx context.Context, electionId string) ([]vote.Vote, error) {
+	return nil, nil
+}
+
+func (repo *uniqueVoteRepository) HasVoted(ctx context.Context, electionId string, userId string) (bool, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	return repo.votes[electionId + "/" + userId], nil
+}
+
+func TestCastVoteShouldAcceptOneOfManyConcurrentVotes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+	mockPublisher := mocks.NewMockPublisher(ctrl)
+
+	now := time.Now()
+	activeElection := &election.Election{
+		ID: "test-election-id",
+		StartTime: now.Add(-1 * time.Hour),
+		EndTime: now.Add(time.Hour),
+		Status: election.Active,
+		Method: election.Plurality,
+	}
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), gomock.Any()).
+		Return(activeElection, nil).
+		AnyTimes()
+	mockCandidateRepository.
+		EXPECT().
+		GetAllByElection(gomock.Any(), gomock.Any()).
+		Return([]candidate.Candidate{{ID: "test-candidate-id", ElectionId: "test-election-id"}}, nil).
+		AnyTimes()
+
+	mockPublisher.
+		EXPECT().
+		PublishVote(gomock.Any(), gomock.Any()).
+		Times(1)
+
+	repo := &uniqueVoteRepository{votes: map[string]bool{}}
+	service := vote.NewVoteService(repo, mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), unweighted(ctrl), enrolled(ctrl), mocks.NewMockUserRepository(ctrl), mockPublisher, zap.NewNop())
+	ctx := voterContext()
+
+	const requests = 200
+	errs := make(chan error, requests)
+	var wg sync.WaitGroup
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- service.CastVote(ctx, "test-election-id", &vote.Vote{UserId: "test-user-id", CandidateId: "test-candidate-id"})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	accepted := 0
+	for err := range errs {
+		if err == nil {
+			accepted++
+		} else if !errors.Is(err, vote.ErrAlreadyVoted) {
+			t.Errorf("Expected error: %v but got %v", vote.ErrAlreadyVoted, err)
+		}
+	}
+	if accepted != 1 {
+		t.Errorf("Expected exactly one accepted vote but got %d", accepted)
+	}
+}
+
+func TestGetTally(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+	mockPublisher := mocks.NewMockPublisher(ctrl)
+
+	counts := []vote.CandidateTally{
+		{CandidateId: "candidate-1", Votes: 3},
+		{CandidateId: "candidate-2", Votes: 0},
+		{CandidateId: "candidate-3", Votes: 4},
+	}
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), "test-election-id").
+		Return(&election.Election{ID: "test-election-id"}, nil).
+		Times(1)
+	mockVoteRepository.
+		EXPECT().
+		CountByCandidate(gomock.Any(), "test-election-id").
+		Return(counts, nil).
+		Times(1)
+	// Approval ballots support several candidates, so fewer voters cast the
+	// ballots than the candidate counts add up to.
+	mockVoteRepository.
+		EXPECT().
+		CountVoters(gomock.Any(), "test-election-id").
+		Return(5, nil).
+		Times(1)
+
+	service := vote.NewVoteService(mockVoteRepository, mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), unweighted(ctrl), enrolled(ctrl), mocks.NewMockUserRepository(ctrl), mockPublisher, zap.NewNop())
+	ctx := voterContext()
+	tally, err := service.GetTally(ctx, "test-election-id")
+
+	if err != nil {
+		t.Fatal("Could not get tally", err.Error())
+	}
+	if tally.TotalVotes != 5 {
+		t.Errorf("Expected total votes: %d but got %d", 5, tally.TotalVotes)
+	}
+	if len(tally.Candidates) != len(counts) {
+		t.Errorf("Expected %d candidate tallies but got %d", len(counts), len(tally.Candidates))
+	}
+}
+
+func TestGetTallyShouldOnlyShowUncertifiedElectionsToAdmins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	base := context.WithValue(context.Background(), "requestId", "test-request-id")
+	voter := context.WithValue(base, user.ContextKey, &user.User{ID: "test-user-id", Role: user.Base, Active: true})
+	admin := context.WithValue(base, user.ContextKey, &user.User{ID: "test-admin-id", Role: user.Admin, Active: true})
+	tests := []struct {
+		name string
+		ctx context.Context
+		status election.ElectionStatus
+		certified bool
+		hidden bool
+	}{
+		{"Active election to voter", voter, election.Active, false, true},
+		{"Closed election to anonymous", base, election.Closed, false, true},
+		{"Uncertified archive to voter", voter, election.Archived, false, true},
+		{"Certified archive to voter", voter, election.Archived, true, false},
+		{"Certified election to voter", voter, election.Certified, true, false},
+		{"Active election to admin", admin, election.Active, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&election.Election{ID: "test-election-id", Status: test.status}, nil).
+				Times(1)
+			mockElectionRepository.
+				EXPECT().
+				IsCertified(gomock.Any(), "test-election-id").
+				Return(test.certified, nil).
+				AnyTimes()
+			mockVoteRepository.
+				EXPECT().
+				CountByCandidate(gomock.Any(), "test-election-id").
+				Return([]vote.CandidateTally{}, nil).
+				AnyTimes()
+			mockVoteRepository.
+				EXPECT().
+				CountVoters(gomock.Any(), "test-election-id").
+				Return(0, nil).
+				AnyTimes()
+
+			service := vote.NewVoteService(mockVoteRepository, mockElectionRepository, mocks.NewMockCandidateRepository(ctrl), mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), unweighted(ctrl), enrolled(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockPublisher(ctrl), zap.NewNop())
+			_, err := service.GetTally(test.ctx, "test-election-id")
+
+			if test.hidden && !errors.Is(err, vote.ErrResultsHidden) {
+				t.Errorf("Expected error: %v but got %v", vote.ErrResultsHidden, err)
+			}
+			if !test.hidden && err != nil {
+				t.Error("Could not get tally", err.Error())
+			}
+		})
+	}
+}
+
+// voterContext is a request signed in as the voter the tests cast votes for.
+func voterContext() context.Context {
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	return context.WithValue(ctx, user.ContextKey, &user.User{ID: "test-user-id", Role: user.Base, Active: true})
+}
+
+// unweighted returns a weight repository in which no voter has a weight.
+func unweighted(ctrl *gomock.Controller) *mocks.MockWeightRepository {
+	weights := mocks.NewMockWeightRepository(ctrl)
+	weights.
+		EXPECT().
+		GetByVoter(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, sql.ErrNoRows).
+		AnyTimes()
+	return weights
+}
+
+// enrolled returns an electoral roll on which every voter is active.
+func enrolled(ctrl *gomock.Controller) *mocks.MockRollRepository {
+	voters := mocks.NewMockRollRepository(ctrl)
+	voters.
+		EXPECT().
+		GetVoter(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, electionId string, userId string) (*roll.Voter, error) {
+			return &roll.Voter{ElectionId: electionId, UserId: userId, Active: true}, nil
+		}).
+		AnyTimes()
+	return voters
+}
+
+// noQuestions returns a question repository for elections without questions.
+func noQuestions(ctrl *gomock.Controller) *mocks.MockQuestionRepository {
+	questions := mocks.NewMockQuestionRepository(ctrl)
+	questions.
+		EXPECT().
+		GetAllByElection(gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+	return questions
+}
+
+func TestCastBallot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	meeting := &election.Election{
+		ID: "test-election-id",
+		StartTime: now.Add(-1 * time.Hour),
+		EndTime: now.Add(time.Hour),
+		Status: election.Active,
+		Method: election.Plurality,
+	}
+	questions := []question.Question{
+		{ID: "motion-1", ElectionId: "test-election-id", Method: election.Plurality, Seats: 1},
+		{ID: "motion-2", ElectionId: "test-election-id", Method: election.InstantRunoff, Seats: 1},
+	}
+	options := []candidate.Candidate{
+		{ID: "for", QuestionId: "motion-1"},
+		{ID: "against", QuestionId: "motion-1"},
+		{ID: "plan-a", QuestionId: "motion-2"},
+		{ID: "plan-b", QuestionId: "motion-2"},
+	}
+	tests := []struct {
+		name string
+		answers []vote.Vote
+		expected error
+	}{
+		{
+			"Answers every question",
+			[]vote.Vote{{QuestionId: "motion-1", CandidateId: "for"}, {QuestionId: "motion-2", Rankings: []string{"plan-b", "plan-a"}}},
+			nil,
+		},
+		{
+			"Leaves a question unanswered",
+			[]vote.Vote{{QuestionId: "motion-1", CandidateId: "for"}},
+			vote.ErrInvalidBallot,
+		},
+		{
+			"Answers a question twice",
+			[]vote.Vote{{QuestionId: "motion-1", CandidateId: "for"}, {QuestionId: "motion-1", CandidateId: "against"}},
+			vote.ErrInvalidBallot,
+		},
+		{
+			"Answers a question of another election",
+			[]vote.Vote{{QuestionId: "motion-1", CandidateId: "for"}, {QuestionId: "motion-3", CandidateId: "for"}},
+			vote.ErrInvalidQuestion,
+		},
+		{
+			"Chooses an option of another question",
+			[]vote.Vote{{QuestionId: "motion-1", CandidateId: "plan-a"}, {QuestionId: "motion-2", Rankings: []string{"plan-a"}}},
+			vote.ErrInvalidCandidate,
+		},
+		{
+			"Answer does not match the question's voting method",
+			[]vote.Vote{{QuestionId: "motion-1", CandidateId: "for"}, {QuestionId: "motion-2", CandidateId: "plan-a"}},
+			vote.ErrInvalidBallot,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockQuestionRepository := mocks.NewMockQuestionRepository(ctrl)
+			mockPublisher := mocks.NewMockPublisher(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(meeting, nil).
+				Times(1)
+			mockVoteRepository.
+				EXPECT().
+				HasVoted(gomock.Any(), "test-election-id", "test-user-id").
+				Return(false, nil).
+				Times(1)
+			mockQuestionRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return(questions, nil).
+				Times(1)
+			mockCandidateRepository.
+				EXPECT().
+				GetAllByElection(gomock.Any(), "test-election-id").
+				Return(options, nil).
+				Times(1)
+			ballot := &vote.Ballot{UserId: "test-user-id", Answers: test.answers}
+			if test.expected == nil {
+				mockVoteRepository.
+					EXPECT().
+					SaveBallot(gomock.Any(), test.answers).
+					Return(nil).
+					Times(1)
+				mockPublisher.
+					EXPECT().
+					PublishVote(gomock.Any(), gomock.Any()).
+					Times(len(test.answers))
+			}
+			service := vote.NewVoteService(mockVoteRepository, mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), mockQuestionRepository, unweighted(ctrl), enrolled(ctrl), mocks.NewMockUserRepository(ctrl), mockPublisher, zap.NewNop())
+			ctx := voterContext()
+			err := service.CastBallot(ctx, "test-election-id", ballot)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+			for _, answer := range ballot.Answers {
+				if answer.ElectionId != "test-election-id" || answer.UserId != "test-user-id" {
+					t.Errorf("Expected answer in election %s by %s but got %s by %s", "test-election-id", "test-user-id", answer.ElectionId, answer.UserId)
+				}
+			}
+		})
+	}
+}
+
+func TestCastVoteShouldRequireBallotForElectionWithQuestions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockQuestionRepository := mocks.NewMockQuestionRepository(ctrl)
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), "test-election-id").
+		Return(&election.Election{
+			StartTime: now.Add(-1 * time.Hour),
+			EndTime: now.Add(time.Hour),
+			Status: election.Active,
+			Method: election.Plurality,
+		}, nil).
+		Times(1)
+	mockVoteRepository.
+		EXPECT().
+		HasVoted(gomock.Any(), "test-election-id", "test-user-id").
+		Return(false, nil).
+		Times(1)
+	mockQuestionRepository.
+		EXPECT().
+		GetAllByElection(gomock.Any(), "test-election-id").
+		Return([]question.Question{{ID: "motion-1"}}, nil).
+		Times(1)
+	service := vote.NewVoteService(mockVoteRepository, mockElectionRepository, mocks.NewMockCandidateRepository(ctrl), mocks.NewMockPartyListRepository(ctrl), mockQuestionRepository, unweighted(ctrl), enrolled(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockPublisher(ctrl), zap.NewNop())
+	ctx := voterContext()
+	err := service.CastVote(ctx, "test-election-id", &vote.Vote{UserId: "test-user-id", CandidateId: "for"})
+	if !errors.Is(err, vote.ErrBallotRequired) {
+		t.Errorf("Expected error: %v but got %v", vote.ErrBallotRequired, err)
+	}
+}
+
+func TestHashBallots(t *testing.T) {
+	ballots := []vote.Vote{
+		{ID: "vote-1", UserId: "user-1", CandidateId: "candidate-1"},
+		{ID: "vote-2", UserId: "user-2", Rankings: []string{"candidate-2", "candidate-1"}},
+		{ID: "vote-3", UserId: "user-3", Blank: true},
+	}
+	reordered := []vote.Vote{ballots[2], ballots[0], ballots[1]}
+	changed := []vote.Vote{ballots[0], ballots[1], {ID: "vote-3", UserId: "user-3", CandidateId: "candidate-2"}}
+
+	hash := vote.HashBallots(ballots)
+
+	if vote.HashBallots(reordered) != hash {
+		t.Error("Expected the hash not to depend on the order of the ballots")
+	}
+	if vote.HashBallots(changed) == hash {
+		t.Error("Expected a changed ballot to change the hash")
+	}
+}
+
+func TestCastVoteShouldRequireActiveVoterOnRoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	tests := []struct {
+		name string
+		voter *roll.Voter
+		expected error
+	}{
+		{"Active voter on the roll", &roll.Voter{UserId: "test-user-id", Active: true}, nil},
+		{"Deactivated voter on the roll", &roll.Voter{UserId: "test-user-id", Active: false}, vote.ErrVoterInactive},
+		{"User not on the roll", nil, vote.ErrNotOnRoll},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockRollRepository := mocks.NewMockRollRepository(ctrl)
+			mockPublisher := mocks.NewMockPublisher(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&election.Election{
+					ID: "test-election-id",
+					StartTime: now.Add(-1 * time.Hour),
+					EndTime: now.Add(time.Hour),
+					Status: election.Active,
+					Method: election.Plurality,
+				}, nil).
+				Times(1)
+			if test.voter != nil {
+				mockRollRepository.EXPECT().GetVoter(gomock.Any(), "test-election-id", "test-user-id").Return(test.voter, nil).Times(1)
+			} else {
+				mockRollRepository.EXPECT().GetVoter(gomock.Any(), "test-election-id", "test-user-id").Return(nil, sql.ErrNoRows).Times(1)
+			}
+			input := &vote.Vote{UserId: "test-user-id", CandidateId: "candidate-1"}
+			if test.expected == nil {
+				mockVoteRepository.EXPECT().HasVoted(gomock.Any(), "test-election-id", "test-user-id").Return(false, nil).Times(1)
+				mockCandidateRepository.
+					EXPECT().
+					GetAllByElection(gomock.Any(), "test-election-id").
+					Return([]candidate.Candidate{{ID: "candidate-1"}}, nil).
+					AnyTimes()
+				mockVoteRepository.EXPECT().Save(gomock.Any(), input).Return(nil).Times(1)
+				mockPublisher.EXPECT().PublishVote(gomock.Any(), input).Times(1)
+			}
+			service := vote.NewVoteService(mockVoteRepository, mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), unweighted(ctrl), mockRollRepository, mocks.NewMockUserRepository(ctrl), mockPublisher, zap.NewNop())
+			ctx := voterContext()
+			err := service.CastVote(ctx, "test-election-id", input)
+
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestCastVoteShouldAdmitUsersMatchingEligibilityRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	tests := []struct {
+		name string
+		user *user.User
+		expected error
+	}{
+		{"Member of the group", &user.User{ID: "test-user-id", Active: true, Groups: []string{"staff"}}, nil},
+		{"Deactivated member of the group", &user.User{ID: "test-user-id", Active: false, Groups: []string{"staff"}}, vote.ErrVoterInactive},
+		{"Not in the group", &user.User{ID: "test-user-id", Active: true}, vote.ErrNotEligible},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockVoteRepository := mocks.NewMockVoteRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockCandidateRepository := mocks.NewMockCandidateRepository(ctrl)
+			mockRollRepository := mocks.NewMockRollRepository(ctrl)
+			mockUserRepository := mocks.NewMockUserRepository(ctrl)
+			mockPublisher := mocks.NewMockPublisher(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&election.Election{
+					ID: "test-election-id",
+					StartTime: now.Add(-1 * time.Hour),
+					EndTime: now.Add(time.Hour),
+					Status: election.Active,
+					Method: election.Plurality,
+					Eligibility: `"staff" in groups`,
+				}, nil).
+				Times(1)
+			mockRollRepository.EXPECT().GetVoter(gomock.Any(), "test-election-id", "test-user-id").Return(nil, sql.ErrNoRows).Times(1)
+			mockUserRepository.EXPECT().GetById(gomock.Any(), "test-user-id").Return(test.user, nil).Times(1)
+			input := &vote.Vote{UserId: "test-user-id", CandidateId: "candidate-1"}
+			if test.expected == nil {
+				mockVoteRepository.EXPECT().HasVoted(gomock.Any(), "test-election-id", "test-user-id").Return(false, nil).Times(1)
+				mockCandidateRepository.
+					EXPECT().
+					GetAllByElection(gomock.Any(), "test-election-id").
+					Return([]candidate.Candidate{{ID: "candidate-1"}}, nil).
+					AnyTimes()
+				mockVoteRepository.EXPECT().Save(gomock.Any(), input).Return(nil).Times(1)
+				mockPublisher.EXPECT().PublishVote(gomock.Any(), input).Times(1)
+			}
+			service := vote.NewVoteService(mockVoteRepository, mockElectionRepository, mockCandidateRepository, mocks.NewMockPartyListRepository(ctrl), noQuestions(ctrl), unweighted(ctrl), mockRollRepository, mockUserRepository, mockPublisher, zap.NewNop())
+			ctx := voterContext()
+			err := service.CastVote(ctx, "test-election-id", input)
+
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
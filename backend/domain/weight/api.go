@@ -0,0 +1,92 @@
+package weight
+
+import (
+	"errors"
+	"net/http"
+
+	"geraldaddo.com/live-voting-system/domain/user"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// maxImportSize caps the CSV body of a weights import.
+const maxImportSize = 10 << 20
+
+type WeightAPI struct {
+	service *WeightService
+	log *zap.Logger
+}
+
+func NewWeightAPI(service *WeightService, logger *zap.Logger) *WeightAPI {
+	return &WeightAPI{service: service, log: logger}
+}
+
+func (api *WeightAPI) RegisterRoutes(server *gin.Engine) {
+	server.GET("/elections/:id/weights", user.Require(user.ManageElections), api.getWeights)
+	server.PUT("/elections/:id/weights", user.Require(user.ManageElections), api.setWeight)
+	server.POST("/elections/:id/weights/import", user.Require(user.ManageElections), api.importWeights)
+	server.DELETE("/elections/:id/weights/:userId", user.Require(user.ManageElections), api.deleteWeight)
+}
+
+func (api *WeightAPI) setWeight(ctx *gin.Context) {
+	requestId := ctx.GetString("requestId")
+	var weight VoterWeight
+	err := ctx.ShouldBindJSON(&weight)
+	if err != nil {
+		api.log.Error(err.Error())
+		api.log.Error("could not parse voter weight", zap.String("request_id", requestId))
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "could not parse voter weight"})
+		return
+	}
+	err = api.service.SetWeight(ctx, ctx.Param("id"), &weight)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "set voter weight"})
+}
+
+// importWeights reads a CSV of user id and weight pairs from the request
+// body, of at most maxImportSize bytes.
+func (api *WeightAPI) importWeights(ctx *gin.Context) {
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize)
+	imported, err := api.service.ImportWeights(ctx, ctx.Param("id"), body)
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "imported voter weights", "imported": imported})
+}
+
+func (api *WeightAPI) getWeights(ctx *gin.Context) {
+	weights, err := api.service.GetWeights(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, weights)
+}
+
+func (api *WeightAPI) deleteWeight(ctx *gin.Context) {
+	err := api.service.DeleteWeight(ctx, ctx.Param("id"), ctx.Param("userId"))
+	if err != nil {
+		ctx.JSON(statusForError(err), gin.H{"message": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "deleted voter weight"})
+}
+
+func statusForError(err error) int {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrElectionNotFound), errors.Is(err, ErrWeightNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidWeight), errors.Is(err, ErrUnknownVoter), errors.Is(err, ErrInvalidImport):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrElectionNotDraft):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,66 @@
+package weight_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/domain/weight"
+	"geraldaddo.com/live-voting-system/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func SetupServer() *gin.Engine {
+	server := gin.Default()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("requestId", uuid.New().String())
+		ctx.Set(user.ContextKey, &user.User{ID: "test-admin", Role: user.Admin, Active: true})
+	})
+	return server
+}
+
+func TestImportWeightsAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		body string
+		statusCode int
+	}{
+		{"Import weights", "user-1,7\n", 200},
+		{"Body too large", strings.Repeat("user-1,7\n", (10 << 20) / 9 + 1), 413},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockWeightRepository := mocks.NewMockWeightRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&election.Election{Status: election.Draft}, nil).
+				Times(1)
+			if test.statusCode == 200 {
+				mockWeightRepository.EXPECT().SaveAll(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			}
+			server := SetupServer()
+			service := weight.NewWeightService(mockWeightRepository, mockElectionRepository, zap.NewNop())
+			weight.NewWeightAPI(service, zap.NewNop()).RegisterRoutes(server)
+
+			recorder := httptest.NewRecorder()
+			request, _ := http.NewRequest("POST", "/elections/test-election-id/weights/import", strings.NewReader(test.body))
+			server.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.statusCode {
+				t.Errorf("Expected status code: %d but got %d", test.statusCode, recorder.Code)
+			}
+		})
+	}
+}
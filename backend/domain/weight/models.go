@@ -0,0 +1,14 @@
+package weight
+
+import "time"
+
+// VoterWeight is the number of votes a voter's ballot carries in an
+// election, such as their share count at a shareholder meeting. Voters
+// without a weight cast a single vote.
+type VoterWeight struct {
+	ElectionId string
+	UserId string `binding:"required"`
+	Weight int `binding:"required"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,114 @@
+package weight
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+//go:generate mockgen -destination=../../mocks/mock_weight_repo.go -package=mocks . WeightRepository
+type WeightRepository interface {
+	// Save sets the weight of a voter, replacing any earlier weight.
+	Save(ctx context.Context, weight *VoterWeight) error
+	// SaveAll sets every weight in one transaction, so an import either
+	// applies in full or not at all.
+	SaveAll(ctx context.Context, weights []VoterWeight) error
+	GetByVoter(ctx context.Context, electionId string, userId string) (*VoterWeight, error)
+	GetAllByElection(ctx context.Context, electionId string) ([]VoterWeight, error)
+	DeleteOne(ctx context.Context, electionId string, userId string) error
+}
+
+// foreignKeyViolation is the Postgres error code for a reference to a row
+// that does not exist.
+const foreignKeyViolation = "23503"
+
+const upsertStatement = `
+	INSERT INTO voter_weights(election_id, user_id, weight)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (election_id, user_id) DO UPDATE SET weight = EXCLUDED.weight, updated_at = CURRENT_TIMESTAMP`
+
+type WeightRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewWeightRepository(db *sql.DB) *WeightRepositoryImpl {
+	return &WeightRepositoryImpl{db: db}
+}
+
+func (repo *WeightRepositoryImpl) Save(ctx context.Context, weight *VoterWeight) error {
+	_, err := repo.db.Exec(upsertStatement, weight.ElectionId, weight.UserId, weight.Weight)
+	return unknownVoter(err)
+}
+
+func (repo *WeightRepositoryImpl) SaveAll(ctx context.Context, weights []VoterWeight) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, weight := range weights {
+		_, err := tx.Exec(upsertStatement, weight.ElectionId, weight.UserId, weight.Weight)
+		if err != nil {
+			return unknownVoter(err)
+		}
+	}
+	return tx.Commit()
+}
+
+// unknownVoter reports weights for users that do not exist as
+// ErrUnknownVoter.
+func unknownVoter(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+		return ErrUnknownVoter
+	}
+	return err
+}
+
+func (repo *WeightRepositoryImpl) GetByVoter(ctx context.Context, electionId string, userId string) (*VoterWeight, error) {
+	query := `
+	SELECT election_id, user_id, weight, created_at, updated_at
+	FROM voter_weights
+	WHERE election_id = $1 AND user_id = $2
+	`
+	row := repo.db.QueryRow(query, electionId, userId)
+
+	var w VoterWeight
+	err := row.Scan(&w.ElectionId, &w.UserId, &w.Weight, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (repo *WeightRepositoryImpl) GetAllByElection(ctx context.Context, electionId string) ([]VoterWeight, error) {
+	query := `
+	SELECT election_id, user_id, weight, created_at, updated_at
+	FROM voter_weights
+	WHERE election_id = $1
+	ORDER BY created_at ASC
+	`
+	rows, err := repo.db.Query(query, electionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var weights []VoterWeight
+	for rows.Next() {
+		var w VoterWeight
+		err := rows.Scan(&w.ElectionId, &w.UserId, &w.Weight, &w.CreatedAt, &w.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		weights = append(weights, w)
+	}
+	return weights, nil
+}
+
+func (repo *WeightRepositoryImpl) DeleteOne(ctx context.Context, electionId string, userId string) error {
+	_, err := repo.db.Exec(`DELETE FROM voter_weights WHERE election_id = $1 AND user_id = $2`, electionId, userId)
+	return err
+}
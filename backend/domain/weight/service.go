@@ -0,0 +1,185 @@
+package weight
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrElectionNotFound = errors.New("Election does not exist")
+	ErrElectionNotDraft = errors.New("Voter weights can only be changed while the election is a draft")
+	ErrWeightNotFound = errors.New("Voter has no weight in this election")
+	ErrInvalidWeight = errors.New("Voter weight must be a positive whole number")
+	ErrUnknownVoter = errors.New("Voter does not exist")
+	ErrInvalidImport = errors.New("Could not read voter weights")
+)
+
+type WeightService struct {
+	repo WeightRepository
+	elections election.ElectionRepository
+	log *zap.Logger
+}
+
+func NewWeightService(repo WeightRepository, elections election.ElectionRepository, logger *zap.Logger) *WeightService {
+	return &WeightService{repo: repo, elections: elections, log: logger}
+}
+
+func (service *WeightService) SetWeight(ctx context.Context, electionId string, weight *VoterWeight) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	_, err := service.requireDraft(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	if weight.Weight < 1 {
+		service.log.Warn(fmt.Sprintf("Invalid weight %d for voter: %s", weight.Weight, weight.UserId), zap.String("request_id", requestId))
+		return ErrInvalidWeight
+	}
+	weight.ElectionId = electionId
+	err = service.repo.Save(ctx, weight)
+	if errors.Is(err, ErrUnknownVoter) {
+		service.log.Warn("Voter does not exist: " + weight.UserId, zap.String("request_id", requestId))
+		return ErrUnknownVoter
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not set weight of voter: " + weight.UserId, zap.String("request_id", requestId))
+		return errors.New("Could not set voter weight")
+	}
+	service.log.Info("Set weight of voter: " + weight.UserId + " in election: " + electionId, zap.String("request_id", requestId))
+	return nil
+}
+
+// ImportWeights sets the weights listed in a CSV of user id and weight
+// pairs, with an optional header row. Nothing is imported unless every row
+// is valid. It returns the number of weights imported.
+func (service *WeightService) ImportWeights(ctx context.Context, electionId string, source io.Reader) (int, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	_, err := service.requireDraft(ctx, electionId)
+	if err != nil {
+		return 0, err
+	}
+	weights, err := parseWeights(electionId, source)
+	if err != nil {
+		service.log.Warn(err.Error(), zap.String("request_id", requestId))
+		return 0, err
+	}
+	err = service.repo.SaveAll(ctx, weights)
+	if errors.Is(err, ErrUnknownVoter) {
+		service.log.Warn("Voter weights name a voter that does not exist", zap.String("request_id", requestId))
+		return 0, ErrUnknownVoter
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not import voter weights for election: " + electionId, zap.String("request_id", requestId))
+		return 0, errors.New("Could not import voter weights")
+	}
+	service.log.Info(fmt.Sprintf("Imported %d voter weights for election: %s", len(weights), electionId), zap.String("request_id", requestId))
+	return len(weights), nil
+}
+
+func parseWeights(electionId string, source io.Reader) ([]VoterWeight, error) {
+	reader := csv.NewReader(source)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+	if len(records) > 0 && strings.EqualFold(records[0][0], "user_id") {
+		records = records[1:]
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: no weights", ErrInvalidImport)
+	}
+	weights := make([]VoterWeight, len(records))
+	for i, record := range records {
+		value, err := strconv.Atoi(strings.TrimSpace(record[1]))
+		if err != nil || value < 1 {
+			return nil, fmt.Errorf("%w: %q for voter %s", ErrInvalidWeight, record[1], record[0])
+		}
+		weights[i] = VoterWeight{ElectionId: electionId, UserId: strings.TrimSpace(record[0]), Weight: value}
+	}
+	return weights, nil
+}
+
+func (service *WeightService) GetWeights(ctx context.Context, electionId string) ([]VoterWeight, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	_, err := service.getElection(ctx, electionId)
+	if err != nil {
+		return nil, err
+	}
+	weights, err := service.repo.GetAllByElection(ctx, electionId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Failed to get voter weights for election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get voter weights")
+	}
+	service.log.Info(fmt.Sprintf("Got voter weights of length: %d", len(weights)), zap.String("request_id", requestId))
+	return weights, nil
+}
+
+// DeleteWeight returns the voter to a single vote.
+func (service *WeightService) DeleteWeight(ctx context.Context, electionId string, userId string) error {
+	requestId, _ := ctx.Value("requestId").(string)
+	_, err := service.requireDraft(ctx, electionId)
+	if err != nil {
+		return err
+	}
+	_, err = service.repo.GetByVoter(ctx, electionId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("Voter: " + userId + " has no weight in election: " + electionId, zap.String("request_id", requestId))
+		return ErrWeightNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get weight of voter: " + userId, zap.String("request_id", requestId))
+		return errors.New("Could not delete voter weight")
+	}
+	err = service.repo.DeleteOne(ctx, electionId, userId)
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not delete weight of voter: " + userId, zap.String("request_id", requestId))
+		return errors.New("Could not delete voter weight")
+	}
+	service.log.Info("Deleted weight of voter: " + userId + " in election: " + electionId, zap.String("request_id", requestId))
+	return nil
+}
+
+func (service *WeightService) getElection(ctx context.Context, electionId string) (*election.Election, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.elections.GetById(ctx, electionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		service.log.Warn("Election with id: " + electionId + " does not exist", zap.String("request_id", requestId))
+		return nil, ErrElectionNotFound
+	}
+	if err != nil {
+		service.log.Error(err.Error())
+		service.log.Error("Could not get election: " + electionId, zap.String("request_id", requestId))
+		return nil, errors.New("Failed to get election with ID: " + electionId)
+	}
+	return e, nil
+}
+
+// requireDraft keeps weights fixed once voting starts, since every ballot
+// records the weight of its voter when it is cast.
+func (service *WeightService) requireDraft(ctx context.Context, electionId string) (*election.Election, error) {
+	requestId, _ := ctx.Value("requestId").(string)
+	e, err := service.getElection(ctx, electionId)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status != election.Draft {
+		service.log.Warn("Cannot change voter weights of election: " + electionId, zap.String("request_id", requestId))
+		return nil, ErrElectionNotDraft
+	}
+	return e, nil
+}
@@ -0,0 +1,148 @@
+package weight_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/weight"
+	"geraldaddo.com/live-voting-system/mocks"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestSetWeight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		election *election.Election
+		weight int
+		saveErr error
+		expected error
+	}{
+		{"Draft election", &election.Election{Status: election.Draft}, 250, nil, nil},
+		{"Zero weight", &election.Election{Status: election.Draft}, 0, nil, weight.ErrInvalidWeight},
+		{"Unknown voter", &election.Election{Status: election.Draft}, 10, weight.ErrUnknownVoter, weight.ErrUnknownVoter},
+		{"Active election", &election.Election{Status: election.Active}, 250, nil, weight.ErrElectionNotDraft},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockWeightRepository := mocks.NewMockWeightRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			input := &weight.VoterWeight{UserId: "test-user-id", Weight: test.weight}
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(test.election, nil).
+				Times(1)
+			if test.election.Status == election.Draft && test.weight > 0 {
+				mockWeightRepository.
+					EXPECT().
+					Save(gomock.Any(), input).
+					Return(test.saveErr).
+					Times(1)
+			}
+			service := weight.NewWeightService(mockWeightRepository, mockElectionRepository, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			err := service.SetWeight(ctx, "test-election-id", input)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+			if test.expected == nil && input.ElectionId != "test-election-id" {
+				t.Errorf("Expected weight election id: %s but got %s", "test-election-id", input.ElectionId)
+			}
+		})
+	}
+}
+
+func TestImportWeights(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		csv string
+		imported []weight.VoterWeight
+		expected error
+	}{
+		{
+			"With header",
+			"user_id,weight\nuser-1,100\nuser-2, 40\n",
+			[]weight.VoterWeight{{ElectionId: "test-election-id", UserId: "user-1", Weight: 100}, {ElectionId: "test-election-id", UserId: "user-2", Weight: 40}},
+			nil,
+		},
+		{
+			"Without header",
+			"user-1,7\n",
+			[]weight.VoterWeight{{ElectionId: "test-election-id", UserId: "user-1", Weight: 7}},
+			nil,
+		},
+		{"Weight is not a number", "user-1,7\nuser-2,many\n", nil, weight.ErrInvalidWeight},
+		{"Negative weight", "user-1,-7\n", nil, weight.ErrInvalidWeight},
+		{"Missing weight", "user-1\n", nil, weight.ErrInvalidImport},
+		{"Only a header", "user_id,weight\n", nil, weight.ErrInvalidImport},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockWeightRepository := mocks.NewMockWeightRepository(ctrl)
+			mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+			mockElectionRepository.
+				EXPECT().
+				GetById(gomock.Any(), "test-election-id").
+				Return(&election.Election{Status: election.Draft}, nil).
+				Times(1)
+			var saved []weight.VoterWeight
+			if test.expected == nil {
+				mockWeightRepository.
+					EXPECT().
+					SaveAll(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, weights []weight.VoterWeight) error {
+						saved = weights
+						return nil
+					}).
+					Times(1)
+			}
+			service := weight.NewWeightService(mockWeightRepository, mockElectionRepository, zap.NewNop())
+			ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+			imported, err := service.ImportWeights(ctx, "test-election-id", strings.NewReader(test.csv))
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+			if imported != len(test.imported) || !slices.Equal(saved, test.imported) {
+				t.Errorf("Expected imported weights: %v but got %v", test.imported, saved)
+			}
+		})
+	}
+}
+
+func TestDeleteWeightShouldFailWithoutWeight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWeightRepository := mocks.NewMockWeightRepository(ctrl)
+	mockElectionRepository := mocks.NewMockElectionRepository(ctrl)
+	mockElectionRepository.
+		EXPECT().
+		GetById(gomock.Any(), "test-election-id").
+		Return(&election.Election{Status: election.Draft}, nil).
+		Times(1)
+	mockWeightRepository.
+		EXPECT().
+		GetByVoter(gomock.Any(), "test-election-id", "test-user-id").
+		Return(nil, sql.ErrNoRows).
+		Times(1)
+	service := weight.NewWeightService(mockWeightRepository, mockElectionRepository, zap.NewNop())
+	ctx := context.WithValue(context.Background(), "requestId", "test-request-id")
+	err := service.DeleteWeight(ctx, "test-election-id", "test-user-id")
+	if !errors.Is(err, weight.ErrWeightNotFound) {
+		t.Errorf("Expected error: %v but got %v", weight.ErrWeightNotFound, err)
+	}
+}
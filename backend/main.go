@@ -1,12 +1,30 @@
 package main
 
 import (
+	"context"
 	"os"
 	"strconv"
+	"time"
 
+	"geraldaddo.com/live-voting-system/domain/audit"
+	"geraldaddo.com/live-voting-system/domain/auth"
+	"geraldaddo.com/live-voting-system/domain/candidate"
 	"geraldaddo.com/live-voting-system/domain/election"
+	"geraldaddo.com/live-voting-system/domain/eligibility"
+	"geraldaddo.com/live-voting-system/domain/live"
+	"geraldaddo.com/live-voting-system/domain/partylist"
+	"geraldaddo.com/live-voting-system/domain/question"
+	"geraldaddo.com/live-voting-system/domain/result"
+	"geraldaddo.com/live-voting-system/domain/roll"
+	"geraldaddo.com/live-voting-system/domain/user"
+	"geraldaddo.com/live-voting-system/domain/vote"
+	"geraldaddo.com/live-voting-system/domain/weight"
 	"geraldaddo.com/live-voting-system/platform/db"
+	"geraldaddo.com/live-voting-system/platform/jwt"
+	"geraldaddo.com/live-voting-system/platform/lock"
 	"geraldaddo.com/live-voting-system/platform/log"
+	"geraldaddo.com/live-voting-system/platform/pubsub"
+	"geraldaddo.com/live-voting-system/platform/signing"
 	"github.com/gin-gonic/gin"
 	"github.com/lpernett/godotenv"
 )
@@ -42,11 +60,149 @@ func main() {
 		log.SetupRequestTracking(ctx, logger)
 	})
 
+	// Auditing and authentication run before every route, so they are
+	// registered before them. Routes declare the permission they need.
+	auditRepository := audit.NewAuditRepository(DB)
+	auditService := audit.NewAuditService(auditRepository, logger)
+	auditAPI := audit.NewAuditAPI(auditService, logger)
+	server.Use(auditAPI.Audit)
+
+	sessionTTL := auth.DefaultSessionTTL
+	if rawTTL := os.Getenv("SESSION_TTL"); rawTTL != "" {
+		sessionTTL, err = time.ParseDuration(rawTTL)
+		if err != nil {
+			logger.Error("Could not parse session TTL")
+			logger.Fatal(err.Error())
+		}
+	}
+	accessTokenTTL := auth.DefaultAccessTokenTTL
+	if rawTTL := os.Getenv("ACCESS_TOKEN_TTL"); rawTTL != "" {
+		accessTokenTTL, err = time.ParseDuration(rawTTL)
+		if err != nil {
+			logger.Error("Could not parse access token TTL")
+			logger.Fatal(err.Error())
+		}
+	}
+	keyRotation := 30 * 24 * time.Hour
+	if rawRotation := os.Getenv("JWT_KEY_ROTATION"); rawRotation != "" {
+		keyRotation, err = time.ParseDuration(rawRotation)
+		if err != nil {
+			logger.Error("Could not parse token key rotation period")
+			logger.Fatal(err.Error())
+		}
+	}
+	// Old keys must outlive every token they signed.
+	keyOverlap := time.Hour
+	if rawOverlap := os.Getenv("JWT_KEY_OVERLAP"); rawOverlap != "" {
+		keyOverlap, err = time.ParseDuration(rawOverlap)
+		if err != nil {
+			logger.Error("Could not parse token key overlap")
+			logger.Fatal(err.Error())
+		}
+	}
+	if keyOverlap < accessTokenTTL || keyOverlap < 10 * time.Minute {
+		logger.Fatal("Token key overlap must be at least the access token TTL and 10 minutes")
+	}
+	tokenKeys, err := jwt.OpenKeyring(os.Getenv("JWT_KEY_DIR"), keyRotation, keyOverlap)
+	if err != nil {
+		logger.Error("Could not load token signing keys")
+		logger.Fatal(err.Error())
+	}
+	tokenKeys.Start(context.Background(), 10 * time.Minute, logger)
+
+	userRepository := user.NewUserRepository(DB)
+	credentialRepository := auth.NewCredentialRepository(DB)
+	sessionRepository := auth.NewSessionRepository(DB)
+	authService := auth.NewAuthService(credentialRepository, sessionRepository, userRepository, tokenKeys, accessTokenTTL, sessionTTL, logger)
+	authAPI := auth.NewAuthAPI(authService, logger)
+	server.Use(authAPI.Authenticate)
+	authAPI.RegisterRoutes(server)
+	auditAPI.RegisterRoutes(server)
+
+	var events pubsub.PubSub
+	if os.Getenv("PUBSUB_DRIVER") == "memory" {
+		events = pubsub.NewMemoryPubSub()
+	} else {
+		events = pubsub.NewPostgresPubSub(DB, dbUrl, logger)
+	}
+	defer events.Close()
+
+	hub := live.NewHub(events, logger)
+	err = hub.Start(context.Background())
+	if err != nil {
+		logger.Error("Could not start live event hub")
+		logger.Fatal(err.Error())
+	}
+
 	electionRepository := election.NewElectionRepository(DB)
-	electionService := election.NewElectionService(electionRepository, logger)
+	electionService := election.NewElectionService(electionRepository, hub, logger)
 	electionAPI := election.NewElectionAPI(electionService, logger)
 	electionAPI.RegisterRoutes(server)
 
+	schedulerInterval := 30 * time.Second
+	if rawInterval := os.Getenv("SCHEDULER_INTERVAL"); rawInterval != "" {
+		schedulerInterval, err = time.ParseDuration(rawInterval)
+		if err != nil {
+			logger.Error("Could not parse scheduler interval")
+			logger.Fatal(err.Error())
+		}
+	}
+	schedulerLock := lock.NewAdvisoryLock(DB, election.SchedulerLockKey)
+	electionScheduler := election.NewElectionScheduler(electionService, electionRepository, schedulerLock, schedulerInterval, logger)
+	electionScheduler.Start(context.Background())
+
+	partyListRepository := partylist.NewPartyListRepository(DB)
+	partyListService := partylist.NewPartyListService(partyListRepository, electionRepository, logger)
+	partyListAPI := partylist.NewPartyListAPI(partyListService, logger)
+	partyListAPI.RegisterRoutes(server)
+
+	questionRepository := question.NewQuestionRepository(DB)
+	questionService := question.NewQuestionService(questionRepository, electionRepository, logger)
+	questionAPI := question.NewQuestionAPI(questionService, logger)
+	questionAPI.RegisterRoutes(server)
+
+	weightRepository := weight.NewWeightRepository(DB)
+	weightService := weight.NewWeightService(weightRepository, electionRepository, logger)
+	weightAPI := weight.NewWeightAPI(weightService, logger)
+	weightAPI.RegisterRoutes(server)
+
+	candidateRepository := candidate.NewCandidateRepository(DB)
+	candidateService := candidate.NewCandidateService(candidateRepository, electionRepository, partyListRepository, questionRepository, logger)
+	candidateAPI := candidate.NewCandidateAPI(candidateService, logger)
+	candidateAPI.RegisterRoutes(server)
+
+	rollRepository := roll.NewRollRepository(DB)
+	rollService := roll.NewRollService(rollRepository, electionRepository, logger)
+	rollAPI := roll.NewRollAPI(rollService, logger)
+	rollAPI.RegisterRoutes(server)
+
+	userService := user.NewUserService(userRepository, logger)
+	userAPI := user.NewUserAPI(userService, logger)
+	userAPI.RegisterRoutes(server)
+
+	eligibilityRepository := eligibility.NewEligibilityRepository(DB)
+	eligibilityService := eligibility.NewEligibilityService(eligibilityRepository, logger)
+	eligibilityAPI := eligibility.NewEligibilityAPI(eligibilityService, logger)
+	eligibilityAPI.RegisterRoutes(server)
+
+	voteRepository := vote.NewVoteRepository(DB)
+	voteService := vote.NewVoteService(voteRepository, electionRepository, candidateRepository, partyListRepository, questionRepository, weightRepository, rollRepository, userRepository, hub, logger)
+	voteAPI := vote.NewVoteAPI(voteService, logger)
+	voteAPI.RegisterRoutes(server)
+
+	signingKey, err := signing.LoadOrCreateKey(os.Getenv("SIGNING_KEY_FILE"))
+	if err != nil {
+		logger.Error("Could not load certificate signing key")
+		logger.Fatal(err.Error())
+	}
+	certificationRepository := result.NewCertificationRepository(DB)
+	resultService := result.NewResultService(electionRepository, candidateRepository, partyListRepository, questionRepository, voteRepository, rollRepository, eligibilityRepository, certificationRepository, signing.NewSigner(signingKey), hub, logger)
+	resultAPI := result.NewResultAPI(resultService, logger)
+	resultAPI.RegisterRoutes(server)
+
+	liveAPI := live.NewLiveAPI(hub, voteService, logger)
+	liveAPI.RegisterRoutes(server)
+
 	logger.Info("Starting server")
 	server.Run(":8080")
 }
\ No newline at end of file
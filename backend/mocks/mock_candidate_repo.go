@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: geraldaddo.com/live-voting-system/domain/candidate (interfaces: CandidateRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/mock_candidate_repo.go -package=mocks . CandidateRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	candidate "geraldaddo.com/live-voting-system/domain/candidate"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCandidateRepository is a mock of CandidateRepository interface.
+type MockCandidateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCandidateRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCandidateRepositoryMockRecorder is the mock recorder for MockCandidateRepository.
+type MockCandidateRepositoryMockRecorder struct {
+	mock *MockCandidateRepository
+}
+
+// NewMockCandidateRepository creates a new mock instance.
+func NewMockCandidateRepository(ctrl *gomock.Controller) *MockCandidateRepository {
+	mock := &MockCandidateRepository{ctrl: ctrl}
+	mock.recorder = &MockCandidateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCandidateRepository) EXPECT() *MockCandidateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteOne mocks base method.
+func (m *MockCandidateRepository) DeleteOne(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOne", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOne indicates an expected call of DeleteOne.
+func (mr *MockCandidateRepositoryMockRecorder) DeleteOne(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOne", reflect.TypeOf((*MockCandidateRepository)(nil).DeleteOne), ctx, id)
+}
+
+// GetAllByElection mocks base method.
+func (m *MockCandidateRepository) GetAllByElection(ctx context.Context, electionId string) ([]candidate.Candidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByElection", ctx, electionId)
+	ret0, _ := ret[0].([]candidate.Candidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByElection indicates an expected call of GetAllByElection.
+func (mr *MockCandidateRepositoryMockRecorder) GetAllByElection(ctx, electionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByElection", reflect.TypeOf((*MockCandidateRepository)(nil).GetAllByElection), ctx, electionId)
+}
+
+// GetById mocks base method.
+func (m *MockCandidateRepository) GetById(ctx context.Context, id string) (*candidate.Candidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*candidate.Candidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockCandidateRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockCandidateRepository)(nil).GetById), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockCandidateRepository) Save(ctx context.Context, entity *candidate.Candidate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockCandidateRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCandidateRepository)(nil).Save), ctx, entity)
+}
+
+// UpdateOne mocks base method.
+func (m *MockCandidateRepository) UpdateOne(ctx context.Context, id string, entity *candidate.Candidate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOne", ctx, id, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOne indicates an expected call of UpdateOne.
+func (mr *MockCandidateRepositoryMockRecorder) UpdateOne(ctx, id, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOne", reflect.TypeOf((*MockCandidateRepository)(nil).UpdateOne), ctx, id, entity)
+}
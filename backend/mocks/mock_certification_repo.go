@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: geraldaddo.com/live-voting-system/domain/result (interfaces: CertificationRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/mock_certification_repo.go -package=mocks . CertificationRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	result "geraldaddo.com/live-voting-system/domain/result"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCertificationRepository is a mock of CertificationRepository interface.
+type MockCertificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertificationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCertificationRepositoryMockRecorder is the mock recorder for MockCertificationRepository.
+type MockCertificationRepositoryMockRecorder struct {
+	mock *MockCertificationRepository
+}
+
+// NewMockCertificationRepository creates a new mock instance.
+func NewMockCertificationRepository(ctrl *gomock.Controller) *MockCertificationRepository {
+	mock := &MockCertificationRepository{ctrl: ctrl}
+	mock.recorder = &MockCertificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertificationRepository) EXPECT() *MockCertificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Certify mocks base method.
+func (m *MockCertificationRepository) Certify(ctx context.Context, certification *result.Certification) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Certify", ctx, certification)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Certify indicates an expected call of Certify.
+func (mr *MockCertificationRepositoryMockRecorder) Certify(ctx, certification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Certify", reflect.TypeOf((*MockCertificationRepository)(nil).Certify), ctx, certification)
+}
+
+// GetByElection mocks base method.
+func (m *MockCertificationRepository) GetByElection(ctx context.Context, electionId string) (*result.Certification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByElection", ctx, electionId)
+	ret0, _ := ret[0].(*result.Certification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByElection indicates an expected call of GetByElection.
+func (mr *MockCertificationRepositoryMockRecorder) GetByElection(ctx, electionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByElection", reflect.TypeOf((*MockCertificationRepository)(nil).GetByElection), ctx, electionId)
+}
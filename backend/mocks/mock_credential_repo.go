@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: geraldaddo.com/live-voting-system/domain/auth (interfaces: CredentialRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/mock_credential_repo.go -package=mocks . CredentialRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	auth "geraldaddo.com/live-voting-system/domain/auth"
+	user "geraldaddo.com/live-voting-system/domain/user"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCredentialRepository is a mock of CredentialRepository interface.
+type MockCredentialRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCredentialRepositoryMockRecorder is the mock recorder for MockCredentialRepository.
+type MockCredentialRepositoryMockRecorder struct {
+	mock *MockCredentialRepository
+}
+
+// NewMockCredentialRepository creates a new mock instance.
+func NewMockCredentialRepository(ctrl *gomock.Controller) *MockCredentialRepository {
+	mock := &MockCredentialRepository{ctrl: ctrl}
+	mock.recorder = &MockCredentialRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialRepository) EXPECT() *MockCredentialRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByEmail mocks base method.
+func (m *MockCredentialRepository) GetByEmail(ctx context.Context, email string) (*auth.Credentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret0, _ := ret[0].(*auth.Credentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockCredentialRepositoryMockRecorder) GetByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockCredentialRepository)(nil).GetByEmail), ctx, email)
+}
+
+// GetByUser mocks base method.
+func (m *MockCredentialRepository) GetByUser(ctx context.Context, userId string) (*auth.Credentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userId)
+	ret0, _ := ret[0].(*auth.Credentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockCredentialRepositoryMockRecorder) GetByUser(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockCredentialRepository)(nil).GetByUser), ctx, userId)
+}
+
+// Register mocks base method.
+func (m *MockCredentialRepository) Register(ctx context.Context, u *user.User, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, u, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockCredentialRepositoryMockRecorder) Register(ctx, u, passwordHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockCredentialRepository)(nil).Register), ctx, u, passwordHash)
+}
+
+// SetPassword mocks base method.
+func (m *MockCredentialRepository) SetPassword(ctx context.Context, userId, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", ctx, userId, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockCredentialRepositoryMockRecorder) SetPassword(ctx, userId, passwordHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockCredentialRepository)(nil).SetPassword), ctx, userId, passwordHash)
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: geraldaddo.com/live-voting-system/domain/election (interfaces: StatusPublisher)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/mock_election_publisher.go -package=mocks . StatusPublisher
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	election "geraldaddo.com/live-voting-system/domain/election"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatusPublisher is a mock of StatusPublisher interface.
+type MockStatusPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockStatusPublisherMockRecorder is the mock recorder for MockStatusPublisher.
+type MockStatusPublisherMockRecorder struct {
+	mock *MockStatusPublisher
+}
+
+// NewMockStatusPublisher creates a new mock instance.
+func NewMockStatusPublisher(ctrl *gomock.Controller) *MockStatusPublisher {
+	mock := &MockStatusPublisher{ctrl: ctrl}
+	mock.recorder = &MockStatusPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusPublisher) EXPECT() *MockStatusPublisherMockRecorder {
+	return m.recorder
+}
+
+// PublishStatus mocks base method.
+func (m *MockStatusPublisher) PublishStatus(ctx context.Context, electionId string, status election.ElectionStatus) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PublishStatus", ctx, electionId, status)
+}
+
+// PublishStatus indicates an expected call of PublishStatus.
+func (mr *MockStatusPublisherMockRecorder) PublishStatus(ctx, electionId, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishStatus", reflect.TypeOf((*MockStatusPublisher)(nil).PublishStatus), ctx, electionId, status)
+}
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	election "geraldaddo.com/live-voting-system/domain/election"
 	gomock "go.uber.org/mock/gomock"
@@ -71,6 +72,51 @@ func (mr *MockElectionRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockElectionRepository)(nil).GetById), ctx, id)
 }
 
+// GetDueToClose mocks base method.
+func (m *MockElectionRepository) GetDueToClose(ctx context.Context, now time.Time) ([]election.Election, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueToClose", ctx, now)
+	ret0, _ := ret[0].([]election.Election)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueToClose indicates an expected call of GetDueToClose.
+func (mr *MockElectionRepositoryMockRecorder) GetDueToClose(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueToClose", reflect.TypeOf((*MockElectionRepository)(nil).GetDueToClose), ctx, now)
+}
+
+// GetDueToOpen mocks base method.
+func (m *MockElectionRepository) GetDueToOpen(ctx context.Context, now time.Time) ([]election.Election, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueToOpen", ctx, now)
+	ret0, _ := ret[0].([]election.Election)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueToOpen indicates an expected call of GetDueToOpen.
+func (mr *MockElectionRepositoryMockRecorder) GetDueToOpen(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueToOpen", reflect.TypeOf((*MockElectionRepository)(nil).GetDueToOpen), ctx, now)
+}
+
+// IsCertified mocks base method.
+func (m *MockElectionRepository) IsCertified(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCertified", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCertified indicates an expected call of IsCertified.
+func (mr *MockElectionRepositoryMockRecorder) IsCertified(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCertified", reflect.TypeOf((*MockElectionRepository)(nil).IsCertified), ctx, id)
+}
+
 // Save mocks base method.
 func (m *MockElectionRepository) Save(ctx context.Context, entity *election.Election) error {
 	m.ctrl.T.Helper()
@@ -98,3 +144,18 @@ func (mr *MockElectionRepositoryMockRecorder) UpdateOne(ctx, id, entity any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOne", reflect.TypeOf((*MockElectionRepository)(nil).UpdateOne), ctx, id, entity)
 }
+
+// UpdateStatus mocks base method.
+func (m *MockElectionRepository) UpdateStatus(ctx context.Context, id string, from, to election.ElectionStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockElectionRepositoryMockRecorder) UpdateStatus(ctx, id, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockElectionRepository)(nil).UpdateStatus), ctx, id, from, to)
+}
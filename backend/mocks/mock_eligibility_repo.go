@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: geraldaddo.com/live-voting-system/domain/eligibility (interfaces: EligibilityRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/mock_eligibility_repo.go -package=mocks . EligibilityRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	eligibility "geraldaddo.com/live-voting-system/domain/eligibility"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEligibilityRepository is a mock of EligibilityRepository interface.
+type MockEligibilityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEligibilityRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEligibilityRepositoryMockRecorder is the mock recorder for MockEligibilityRepository.
+type MockEligibilityRepositoryMockRecorder struct {
+	mock *MockEligibilityRepository
+}
+
+// NewMockEligibilityRepository creates a new mock instance.
+func NewMockEligibilityRepository(ctrl *gomock.Controller) *MockEligibilityRepository {
+	mock := &MockEligibilityRepository{ctrl: ctrl}
+	mock.recorder = &MockEligibilityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEligibilityRepository) EXPECT() *MockEligibilityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountEligible mocks base method.
+func (m *MockEligibilityRepository) CountEligible(ctx context.Context, electionId string, rule *eligibility.Rule, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEligible", ctx, electionId, rule, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEligible indicates an expected call of CountEligible.
+func (mr *MockEligibilityRepositoryMockRecorder) CountEligible(ctx, electionId, rule, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEligible", reflect.TypeOf((*MockEligibilityRepository)(nil).CountEligible), ctx, electionId, rule, now)
+}
+
+// CountMatching mocks base method.
+func (m *MockEligibilityRepository) CountMatching(ctx context.Context, rule *eligibility.Rule, now time.Time, preview *eligibility.Preview) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMatching", ctx, rule, now, preview)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CountMatching indicates an expected call of CountMatching.
+func (mr *MockEligibilityRepositoryMockRecorder) CountMatching(ctx, rule, now, preview any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMatching", reflect.TypeOf((*MockEligibilityRepository)(nil).CountMatching), ctx, rule, now, preview)
+}
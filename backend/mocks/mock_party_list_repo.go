@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: geraldaddo.com/live-voting-system/domain/partylist (interfaces: PartyListRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/mock_party_list_repo.go -package=mocks . PartyListRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	partylist "geraldaddo.com/live-voting-system/domain/partylist"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPartyListRepository is a mock of PartyListRepository interface.
+type MockPartyListRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartyListRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPartyListRepositoryMockRecorder is the mock recorder for MockPartyListRepository.
+type MockPartyListRepositoryMockRecorder struct {
+	mock *MockPartyListRepository
+}
+
+// NewMockPartyListRepository creates a new mock instance.
+func NewMockPartyListRepository(ctrl *gomock.Controller) *MockPartyListRepository {
+	mock := &MockPartyListRepository{ctrl: ctrl}
+	mock.recorder = &MockPartyListRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartyListRepository) EXPECT() *MockPartyListRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteOne mocks base method.
+func (m *MockPartyListRepository) DeleteOne(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOne", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOne indicates an expected call of DeleteOne.
+func (mr *MockPartyListRepositoryMockRecorder) DeleteOne(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOne", reflect.TypeOf((*MockPartyListRepository)(nil).DeleteOne), ctx, id)
+}
+
+// GetAllByElection mocks base method.
+func (m *MockPartyListRepository) GetAllByElection(ctx context.Context, electionId string) ([]partylist.PartyList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByElection", ctx, electionId)
+	ret0, _ := ret[0].([]partylist.PartyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByElection indicates an expected call of GetAllByElection.
+func (mr *MockPartyListRepositoryMockRecorder) GetAllByElection(ctx, electionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByElection", reflect.TypeOf((*MockPartyListRepository)(nil).GetAllByElection), ctx, electionId)
+}
+
+// GetById mocks base method.
+func (m *MockPartyListRepository) GetById(ctx context.Context, id string) (*partylist.PartyList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*partylist.PartyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockPartyListRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPartyListRepository)(nil).GetById), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockPartyListRepository) Save(ctx context.Context, entity *partylist.PartyList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPartyListRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPartyListRepository)(nil).Save), ctx, entity)
+}
+
+// UpdateOne mocks base method.
+func (m *MockPartyListRepository) UpdateOne(ctx context.Context, id string, entity *partylist.PartyList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOne", ctx, id, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOne indicates an expected call of UpdateOne.
+func (mr *MockPartyListRepositoryMockRecorder) UpdateOne(ctx, id, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOne", reflect.TypeOf((*MockPartyListRepository)(nil).UpdateOne), ctx, id, entity)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: geraldaddo.com/live-voting-system/domain/question (interfaces: QuestionRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/mock_question_repo.go -package=mocks . QuestionRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	question "geraldaddo.com/live-voting-system/domain/question"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQuestionRepository is a mock of QuestionRepository interface.
+type MockQuestionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuestionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockQuestionRepositoryMockRecorder is the mock recorder for MockQuestionRepository.
+type MockQuestionRepositoryMockRecorder struct {
+	mock *MockQuestionRepository
+}
+
+// NewMockQuestionRepository creates a new mock instance.
+func NewMockQuestionRepository(ctrl *gomock.Controller) *MockQuestionRepository {
+	mock := &MockQuestionRepository{ctrl: ctrl}
+	mock.recorder = &MockQuestionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuestionRepository) EXPECT() *MockQuestionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteOne mocks base method.
+func (m *MockQuestionRepository) DeleteOne(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOne", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOne indicates an expected call of DeleteOne.
+func (mr *MockQuestionRepositoryMockRecorder) DeleteOne(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOne", reflect.TypeOf((*MockQuestionRepository)(nil).DeleteOne), ctx, id)
+}
+
+// GetAllByElection mocks base method.
+func (m *MockQuestionRepository) GetAllByElection(ctx context.Context, electionId string) ([]question.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByElection", ctx, electionId)
+	ret0, _ := ret[0].([]question.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByElection indicates an expected call of GetAllByElection.
+func (mr *MockQuestionRepositoryMockRecorder) GetAllByElection(ctx, electionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByElection", reflect.TypeOf((*MockQuestionRepository)(nil).GetAllByElection), ctx, electionId)
+}
+
+// GetById mocks base method.
+func (m *MockQuestionRepository) GetById(ctx context.Context, id string) (*question.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*question.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockQuestionRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockQuestionRepository)(nil).GetById), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockQuestionRepository) Save(ctx context.Context, entity *question.Question) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockQuestionRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockQuestionRepository)(nil).Save), ctx, entity)
+}
+
+// UpdateOne mocks base method.
+func (m *MockQuestionRepository) UpdateOne(ctx context.Context, id string, entity *question.Question) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOne", ctx, id, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOne indicates an expected call of UpdateOne.
+func (mr *MockQuestionRepositoryMockRecorder) UpdateOne(ctx, id, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOne", reflect.TypeOf((*MockQuestionRepository)(nil).UpdateOne), ctx, id, entity)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: geraldaddo.com/live-voting-system/domain/roll (interfaces: RollRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/mock_roll_repo.go -package=mocks . RollRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	roll "geraldaddo.com/live-voting-system/domain/roll"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRollRepository is a mock of RollRepository interface.
+type MockRollRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRollRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRollRepositoryMockRecorder is the mock recorder for MockRollRepository.
+type MockRollRepositoryMockRecorder struct {
+	mock *MockRollRepository
+}
+
+// NewMockRollRepository creates a new mock instance.
+func NewMockRollRepository(ctrl *gomock.Controller) *MockRollRepository {
+	mock := &MockRollRepository{ctrl: ctrl}
+	mock.recorder = &MockRollRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRollRepository) EXPECT() *MockRollRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddAll mocks base method.
+func (m *MockRollRepository) AddAll(ctx context.Context, electionId string, emails []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAll", ctx, electionId, emails)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAll indicates an expected call of AddAll.
+func (mr *MockRollRepositoryMockRecorder) AddAll(ctx, electionId, emails any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAll", reflect.TypeOf((*MockRollRepository)(nil).AddAll), ctx, electionId, emails)
+}
+
+// CountEligible mocks base method.
+func (m *MockRollRepository) CountEligible(ctx context.Context, electionId string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEligible", ctx, electionId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEligible indicates an expected call of CountEligible.
+func (mr *MockRollRepositoryMockRecorder) CountEligible(ctx, electionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEligible", reflect.TypeOf((*MockRollRepository)(nil).CountEligible), ctx, electionId)
+}
+
+// DeleteOne mocks base method.
+func (m *MockRollRepository) DeleteOne(ctx context.Context, electionId, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOne", ctx, electionId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOne indicates an expected call of DeleteOne.
+func (mr *MockRollRepositoryMockRecorder) DeleteOne(ctx, electionId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOne", reflect.TypeOf((*MockRollRepository)(nil).DeleteOne), ctx, electionId, userId)
+}
+
+// GetAllByElection mocks base method.
+func (m *MockRollRepository) GetAllByElection(ctx context.Context, electionId string) ([]roll.Voter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByElection", ctx, electionId)
+	ret0, _ := ret[0].([]roll.Voter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByElection indicates an expected call of GetAllByElection.
+func (mr *MockRollRepositoryMockRecorder) GetAllByElection(ctx, electionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByElection", reflect.TypeOf((*MockRollRepository)(nil).GetAllByElection), ctx, electionId)
+}
+
+// GetVoter mocks base method.
+func (m *MockRollRepository) GetVoter(ctx context.Context, electionId, userId string) (*roll.Voter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVoter", ctx, electionId, userId)
+	ret0, _ := ret[0].(*roll.Voter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoter indicates an expected call of GetVoter.
+func (mr *MockRollRepositoryMockRecorder) GetVoter(ctx, electionId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoter", reflect.TypeOf((*MockRollRepository)(nil).GetVoter), ctx, electionId, userId)
+}
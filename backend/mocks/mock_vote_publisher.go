@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: geraldaddo.com/live-voting-system/domain/vote (interfaces: Publisher)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/mock_vote_publisher.go -package=mocks . Publisher
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	vote "geraldaddo.com/live-voting-system/domain/vote"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// PublishVote mocks base method.
+func (m *MockPublisher) PublishVote(ctx context.Context, arg1 *vote.Vote) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PublishVote", ctx, arg1)
+}
+
+// PublishVote indicates an expected call of PublishVote.
+func (mr *MockPublisherMockRecorder) PublishVote(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishVote", reflect.TypeOf((*MockPublisher)(nil).PublishVote), ctx, arg1)
+}
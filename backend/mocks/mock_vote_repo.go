@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: geraldaddo.com/live-voting-system/domain/vote (interfaces: VoteRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/mock_vote_repo.go -package=mocks . VoteRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	vote "geraldaddo.com/live-voting-system/domain/vote"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVoteRepository is a mock of VoteRepository interface.
+type MockVoteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVoteRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockVoteRepositoryMockRecorder is the mock recorder for MockVoteRepository.
+type MockVoteRepositoryMockRecorder struct {
+	mock *MockVoteRepository
+}
+
+// NewMockVoteRepository creates a new mock instance.
+func NewMockVoteRepository(ctrl *gomock.Controller) *MockVoteRepository {
+	mock := &MockVoteRepository{ctrl: ctrl}
+	mock.recorder = &MockVoteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVoteRepository) EXPECT() *MockVoteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByCandidate mocks base method.
+func (m *MockVoteRepository) CountByCandidate(ctx context.Context, electionId string) ([]vote.CandidateTally, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByCandidate", ctx, electionId)
+	ret0, _ := ret[0].([]vote.CandidateTally)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByCandidate indicates an expected call of CountByCandidate.
+func (mr *MockVoteRepositoryMockRecorder) CountByCandidate(ctx, electionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByCandidate", reflect.TypeOf((*MockVoteRepository)(nil).CountByCandidate), ctx, electionId)
+}
+
+// CountVoters mocks base method.
+func (m *MockVoteRepository) CountVoters(ctx context.Context, electionId string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountVoters", ctx, electionId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountVoters indicates an expected call of CountVoters.
+func (mr *MockVoteRepositoryMockRecorder) CountVoters(ctx, electionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountVoters", reflect.TypeOf((*MockVoteRepository)(nil).CountVoters), ctx, electionId)
+}
+
+// GetAllByElection mocks base method.
+func (m *MockVoteRepository) GetAllByElection(ctx context.Context, electionId string) ([]vote.Vote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByElection", ctx, electionId)
+	ret0, _ := ret[0].([]vote.Vote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByElection indicates an expected call of GetAllByElection.
+func (mr *MockVoteRepositoryMockRecorder) GetAllByElection(ctx, electionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByElection", reflect.TypeOf((*MockVoteRepository)(nil).GetAllByElection), ctx, electionId)
+}
+
+// GetById mocks base method.
+func (m *MockVoteRepository) GetById(ctx context.Context, id string) (*vote.Vote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*vote.Vote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockVoteRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockVoteRepository)(nil).GetById), ctx, id)
+}
+
+// HasVoted mocks base method.
+func (m *MockVoteRepository) HasVoted(ctx context.Context, electionId, userId string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasVoted", ctx, electionId, userId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasVoted indicates an expected call of HasVoted.
+func (mr *MockVoteRepositoryMockRecorder) HasVoted(ctx, electionId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasVoted", reflect.TypeOf((*MockVoteRepository)(nil).HasVoted), ctx, electionId, userId)
+}
+
+// Save mocks base method.
+func (m *MockVoteRepository) Save(ctx context.Context, entity *vote.Vote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockVoteRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockVoteRepository)(nil).Save), ctx, entity)
+}
+
+// SaveBallot mocks base method.
+func (m *MockVoteRepository) SaveBallot(ctx context.Context, votes []vote.Vote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBallot", ctx, votes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBallot indicates an expected call of SaveBallot.
+func (mr *MockVoteRepositoryMockRecorder) SaveBallot(ctx, votes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBallot", reflect.TypeOf((*MockVoteRepository)(nil).SaveBallot), ctx, votes)
+}
+
+// UpdateOne mocks base method.
+func (m *MockVoteRepository) UpdateOne(ctx context.Context, id string, entity *vote.Vote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOne", ctx, id, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOne indicates an expected call of UpdateOne.
+func (mr *MockVoteRepositoryMockRecorder) UpdateOne(ctx, id, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOne", reflect.TypeOf((*MockVoteRepository)(nil).UpdateOne), ctx, id, entity)
+}
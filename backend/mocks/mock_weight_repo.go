@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: geraldaddo.com/live-voting-system/domain/weight (interfaces: WeightRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/mock_weight_repo.go -package=mocks . WeightRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	weight "geraldaddo.com/live-voting-system/domain/weight"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWeightRepository is a mock of WeightRepository interface.
+type MockWeightRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWeightRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWeightRepositoryMockRecorder is the mock recorder for MockWeightRepository.
+type MockWeightRepositoryMockRecorder struct {
+	mock *MockWeightRepository
+}
+
+// NewMockWeightRepository creates a new mock instance.
+func NewMockWeightRepository(ctrl *gomock.Controller) *MockWeightRepository {
+	mock := &MockWeightRepository{ctrl: ctrl}
+	mock.recorder = &MockWeightRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWeightRepository) EXPECT() *MockWeightRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteOne mocks base method.
+func (m *MockWeightRepository) DeleteOne(ctx context.Context, electionId, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOne", ctx, electionId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOne indicates an expected call of DeleteOne.
+func (mr *MockWeightRepositoryMockRecorder) DeleteOne(ctx, electionId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOne", reflect.TypeOf((*MockWeightRepository)(nil).DeleteOne), ctx, electionId, userId)
+}
+
+// GetAllByElection mocks base method.
+func (m *MockWeightRepository) GetAllByElection(ctx context.Context, electionId string) ([]weight.VoterWeight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByElection", ctx, electionId)
+	ret0, _ := ret[0].([]weight.VoterWeight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByElection indicates an expected call of GetAllByElection.
+func (mr *MockWeightRepositoryMockRecorder) GetAllByElection(ctx, electionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByElection", reflect.TypeOf((*MockWeightRepository)(nil).GetAllByElection), ctx, electionId)
+}
+
+// GetByVoter mocks base method.
+func (m *MockWeightRepository) GetByVoter(ctx context.Context, electionId, userId string) (*weight.VoterWeight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByVoter", ctx, electionId, userId)
+	ret0, _ := ret[0].(*weight.VoterWeight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByVoter indicates an expected call of GetByVoter.
+func (mr *MockWeightRepositoryMockRecorder) GetByVoter(ctx, electionId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByVoter", reflect.TypeOf((*MockWeightRepository)(nil).GetByVoter), ctx, electionId, userId)
+}
+
+// Save mocks base method.
+func (m *MockWeightRepository) Save(ctx context.Context, arg1 *weight.VoterWeight) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockWeightRepositoryMockRecorder) Save(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockWeightRepository)(nil).Save), ctx, arg1)
+}
+
+// SaveAll mocks base method.
+func (m *MockWeightRepository) SaveAll(ctx context.Context, weights []weight.VoterWeight) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAll", ctx, weights)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAll indicates an expected call of SaveAll.
+func (mr *MockWeightRepositoryMockRecorder) SaveAll(ctx, weights any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAll", reflect.TypeOf((*MockWeightRepository)(nil).SaveAll), ctx, weights)
+}
@@ -47,7 +47,13 @@ func createTables(DB *sql.DB, logger *zap.Logger) {
     description TEXT,
     start_time TIMESTAMP WITH TIME ZONE NOT NULL,
     end_time TIMESTAMP WITH TIME ZONE NOT NULL,
-    status VARCHAR(20) DEFAULT 'draft' CHECK (status IN ('draft', 'active', 'closed', 'archived')),
+    status VARCHAR(20) DEFAULT 'draft' CHECK (status IN ('draft', 'active', 'closed', 'certified', 'archived')),
+    method VARCHAR(20) NOT NULL DEFAULT 'plurality',
+    seats INT NOT NULL DEFAULT 1 CHECK (seats > 0),
+    surplus_transfer VARCHAR(20) NOT NULL DEFAULT '',
+    threshold DOUBLE PRECISION NOT NULL DEFAULT 0,
+    credits INT NOT NULL DEFAULT 0 CHECK (credits >= 0),
+    eligibility TEXT NOT NULL DEFAULT '',
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 	);
@@ -60,17 +66,140 @@ func createTables(DB *sql.DB, logger *zap.Logger) {
 		email VARCHAR(50) UNIQUE NOT NULL,
 		role VARCHAR(20) DEFAULT 'base' CHECK (role IN ('base', 'admin')),
 		active BOOLEAN DEFAULT true,
+		groups TEXT[] NOT NULL DEFAULT '{}',
+		password_hash TEXT,
 		created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 	);
 
+	CREATE TABLE IF NOT EXISTS party_lists (
+		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+		election_id UUID NOT NULL REFERENCES elections(id),
+		name VARCHAR(255) NOT NULL,
+		description TEXT,
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS questions (
+		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+		election_id UUID NOT NULL REFERENCES elections(id),
+		title VARCHAR(255) NOT NULL,
+		description TEXT,
+		method VARCHAR(20) NOT NULL DEFAULT 'plurality',
+		seats INT NOT NULL DEFAULT 1 CHECK (seats > 0),
+		surplus_transfer VARCHAR(20) NOT NULL DEFAULT '',
+		credits INT NOT NULL DEFAULT 0 CHECK (credits >= 0),
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS candidates (
+		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+		election_id UUID NOT NULL REFERENCES elections(id),
+		list_id UUID REFERENCES party_lists(id) ON DELETE SET NULL,
+		question_id UUID REFERENCES questions(id) ON DELETE CASCADE,
+		name VARCHAR(255) NOT NULL,
+		description TEXT,
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+	);
+
 	CREATE TABLE IF NOT EXISTS votes (
 		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 		election_id UUID REFERENCES elections(id),
 		user_id UUID REFERENCES users(id),
+		candidate_id UUID REFERENCES candidates(id),
+		list_id UUID REFERENCES party_lists(id),
+		question_id UUID REFERENCES questions(id),
+		rankings UUID[],
+		approvals UUID[],
+		scores JSONB,
+		vote_counts JSONB,
+		weight INT NOT NULL DEFAULT 1 CHECK (weight > 0),
+		blank BOOLEAN NOT NULL DEFAULT false,
 		created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 	);
+
+	CREATE TABLE IF NOT EXISTS voter_weights (
+		election_id UUID NOT NULL REFERENCES elections(id),
+		user_id UUID NOT NULL REFERENCES users(id),
+		weight INT NOT NULL CHECK (weight > 0),
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (election_id, user_id)
+	);
+
+	CREATE TABLE IF NOT EXISTS electoral_rolls (
+		election_id UUID NOT NULL REFERENCES elections(id),
+		user_id UUID NOT NULL REFERENCES users(id),
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (election_id, user_id)
+	);
+
+	-- Sessions are looked up by a hash of their token, never the token itself.
+	CREATE TABLE IF NOT EXISTS sessions (
+		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+		user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		token_hash TEXT UNIQUE NOT NULL,
+		user_agent TEXT NOT NULL DEFAULT '',
+		ip_address TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+		expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+		revoked_at TIMESTAMP WITH TIME ZONE
+	);
+	CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions(user_id);
+
+	-- Requests refused for lack of permission.
+	CREATE TABLE IF NOT EXISTS audit_events (
+		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+		user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+		role VARCHAR(20) NOT NULL DEFAULT '',
+		method VARCHAR(10) NOT NULL,
+		path TEXT NOT NULL,
+		permission VARCHAR(50) NOT NULL DEFAULT '',
+		status INT NOT NULL,
+		request_id TEXT NOT NULL DEFAULT '',
+		ip_address TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS audit_events_created_at_idx ON audit_events(created_at);
+
+	-- The results snapshot taken at certification is never changed afterwards.
+	CREATE TABLE IF NOT EXISTS certifications (
+		election_id UUID PRIMARY KEY REFERENCES elections(id),
+		certified_by UUID NOT NULL REFERENCES users(id),
+		certified_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		results JSONB NOT NULL,
+		certificate JSONB
+	);
+	CREATE OR REPLACE RULE certifications_no_update AS ON UPDATE TO certifications DO INSTEAD NOTHING;
+	CREATE OR REPLACE RULE certifications_no_delete AS ON DELETE TO certifications DO INSTEAD NOTHING;
+
+	ALTER TABLE certifications ADD COLUMN IF NOT EXISTS certificate JSONB;
+	ALTER TABLE elections ADD COLUMN IF NOT EXISTS method VARCHAR(20) NOT NULL DEFAULT 'plurality';
+	ALTER TABLE elections ADD COLUMN IF NOT EXISTS seats INT NOT NULL DEFAULT 1 CHECK (seats > 0);
+	ALTER TABLE elections ADD COLUMN IF NOT EXISTS surplus_transfer VARCHAR(20) NOT NULL DEFAULT '';
+	ALTER TABLE elections ADD COLUMN IF NOT EXISTS threshold DOUBLE PRECISION NOT NULL DEFAULT 0;
+	ALTER TABLE elections ADD COLUMN IF NOT EXISTS credits INT NOT NULL DEFAULT 0 CHECK (credits >= 0);
+	ALTER TABLE elections ADD COLUMN IF NOT EXISTS eligibility TEXT NOT NULL DEFAULT '';
+	ALTER TABLE users ADD COLUMN IF NOT EXISTS groups TEXT[] NOT NULL DEFAULT '{}';
+	ALTER TABLE users ADD COLUMN IF NOT EXISTS password_hash TEXT;
+	ALTER TABLE candidates ADD COLUMN IF NOT EXISTS list_id UUID REFERENCES party_lists(id) ON DELETE SET NULL;
+	ALTER TABLE candidates ADD COLUMN IF NOT EXISTS question_id UUID REFERENCES questions(id) ON DELETE CASCADE;
+	ALTER TABLE votes ADD COLUMN IF NOT EXISTS candidate_id UUID REFERENCES candidates(id);
+	ALTER TABLE votes ADD COLUMN IF NOT EXISTS list_id UUID REFERENCES party_lists(id);
+	ALTER TABLE votes ADD COLUMN IF NOT EXISTS rankings UUID[];
+	ALTER TABLE votes ADD COLUMN IF NOT EXISTS approvals UUID[];
+	ALTER TABLE votes ADD COLUMN IF NOT EXISTS scores JSONB;
+	ALTER TABLE votes ADD COLUMN IF NOT EXISTS vote_counts JSONB;
+	ALTER TABLE votes ADD COLUMN IF NOT EXISTS weight INT NOT NULL DEFAULT 1 CHECK (weight > 0);
+	ALTER TABLE votes ADD COLUMN IF NOT EXISTS question_id UUID REFERENCES questions(id);
+	ALTER TABLE votes ADD COLUMN IF NOT EXISTS blank BOOLEAN NOT NULL DEFAULT false;
+	ALTER TABLE elections DROP CONSTRAINT IF EXISTS elections_status_check;
+	ALTER TABLE elections ADD CONSTRAINT elections_status_check
+		CHECK (status IN ('draft', 'active', 'closed', 'certified', 'archived'));
 	`
 	_, err := DB.Exec(createSchema);
 
@@ -78,4 +207,53 @@ func createTables(DB *sql.DB, logger *zap.Logger) {
 		logger.Error("Could not create tables")
 		logger.Fatal(err.Error())
 	}
+	createVoteIndex(DB, logger)
+}
+
+// duplicateVotes groups the votes a voter cast more than once for the election
+// itself or for one of its questions.
+const duplicateVotes = `
+	SELECT election_id, user_id, question_id, COUNT(*) AS votes
+	FROM votes
+	GROUP BY election_id, user_id, question_id
+	HAVING COUNT(*) > 1`
+
+// createVoteIndex makes sure a voter answers the election itself and each of
+// its questions once. Databases holding votes cast before this was enforced
+// may already have duplicates, which would fail the index. Those are reported
+// for an admin to resolve rather than deleted, since picking which vote
+// counts is not the server's call.
+func createVoteIndex(DB *sql.DB, logger *zap.Logger) {
+	var exists bool
+	err := DB.QueryRow(`SELECT to_regclass('votes_election_user_question_key') IS NOT NULL`).Scan(&exists)
+	if err != nil {
+		logger.Error("Could not check for the unique votes index")
+		logger.Fatal(err.Error())
+	}
+	if !exists {
+		var duplicates int
+		err = DB.QueryRow(`SELECT COUNT(*) FROM (` + duplicateVotes + `) duplicates`).Scan(&duplicates)
+		if err != nil {
+			logger.Error("Could not check for duplicate votes")
+			logger.Fatal(err.Error())
+		}
+		if duplicates > 0 {
+			logger.Error(fmt.Sprintf("Found %d voters who voted more than once for the same election or question", duplicates))
+			logger.Error("Delete all but one vote of each before restarting. List them with:" + duplicateVotes)
+			logger.Fatal("Could not create unique index votes_election_user_question_key")
+		}
+		_, err = DB.Exec(`
+		CREATE UNIQUE INDEX IF NOT EXISTS votes_election_user_question_key
+			ON votes(election_id, user_id, COALESCE(question_id, '00000000-0000-0000-0000-000000000000'::UUID))`)
+		if err != nil {
+			logger.Error("Could not create unique index votes_election_user_question_key")
+			logger.Fatal(err.Error())
+		}
+	}
+	// The old index allowed one vote per election, which questions outgrew.
+	_, err = DB.Exec(`DROP INDEX IF EXISTS votes_election_user_key`)
+	if err != nil {
+		logger.Error("Could not drop index votes_election_user_key")
+		logger.Fatal(err.Error())
+	}
 }
\ No newline at end of file
@@ -0,0 +1,330 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"geraldaddo.com/live-voting-system/platform/signing"
+	"go.uber.org/zap"
+)
+
+// Algorithm is the JWS algorithm of every token.
+const Algorithm = "EdDSA"
+
+// leeway tolerates clocks of other instances running slightly ahead.
+const leeway = 30 * time.Second
+
+var (
+	ErrInvalidToken = errors.New("Token is invalid")
+	ErrExpiredToken = errors.New("Token has expired")
+	ErrUnknownKey = errors.New("Token was signed by an unknown key")
+)
+
+type Claims struct {
+	Issuer string `json:"iss"`
+	Subject string `json:"sub"`
+	IssuedAt int64 `json:"iat"`
+	ExpiresAt int64 `json:"exp"`
+	ID string `json:"jti"`
+	// SessionId is the session whose refresh token the token was issued for.
+	SessionId string `json:"sid,omitempty"`
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type string `json:"typ"`
+	KeyId string `json:"kid"`
+}
+
+// JWK is an Ed25519 public key in the JSON Web Key format.
+type JWK struct {
+	KeyType string `json:"kty"`
+	Curve string `json:"crv"`
+	X string `json:"x"`
+	KeyId string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use string `json:"use"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+type key struct {
+	id string
+	private ed25519.PrivateKey
+	createdAt time.Time
+	path string
+}
+
+// Keyring holds the token signing keys, one PEM file per key in a directory
+// named after the unix time it was created. A new key is published as soon as
+// it is created but only signs tokens once overlap has passed, so verifiers
+// that cached the key set know it first. An old key is deleted once every
+// token it signed has expired, overlap after its successor took over. overlap
+// must therefore be at least the lifetime of a token.
+type Keyring struct {
+	dir string
+	rotation time.Duration
+	overlap time.Duration
+	mu sync.RWMutex
+	keys []key
+}
+
+// OpenKeyring loads the keys in dir, creating the first one if there are none.
+func OpenKeyring(dir string, rotation time.Duration, overlap time.Duration) (*Keyring, error) {
+	keyring := &Keyring{dir: dir, rotation: rotation, overlap: overlap}
+	return keyring, keyring.Rotate(time.Now())
+}
+
+// Start rotates the keys every interval until ctx is done.
+func (keyring *Keyring) Start(ctx context.Context, interval time.Duration, logger *zap.Logger) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := keyring.Rotate(time.Now())
+				if err != nil {
+					logger.Error(err.Error())
+					logger.Error("Could not rotate token signing keys")
+				}
+			}
+		}
+	}()
+}
+
+// Rotate reloads the keys from disk, creates a key once the newest is older
+// than the rotation period and deletes keys no token can be signed by any
+// more. Several instances may share the directory.
+func (keyring *Keyring) Rotate(now time.Time) error {
+	keys, err := load(keyring.dir)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 || !now.Before(keys[len(keys) - 1].createdAt.Add(keyring.rotation)) {
+		created, err := create(keyring.dir, now)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, *created)
+	}
+	var kept []key
+	for i, k := range keys {
+		if retired(keys[i + 1:], now, keyring.overlap) {
+			err := os.Remove(k.path)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			continue
+		}
+		kept = append(kept, k)
+	}
+	keyring.mu.Lock()
+	defer keyring.mu.Unlock()
+	keyring.keys = kept
+	return nil
+}
+
+// retired reports whether a newer key has been signing for longer than
+// overlap, so every token of an older key has expired.
+func retired(newer []key, now time.Time, overlap time.Duration) bool {
+	for _, k := range newer {
+		if !now.Before(k.createdAt.Add(2 * overlap)) {
+			return true
+		}
+	}
+	return false
+}
+
+func load(dir string) ([]key, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var keys []key
+	for _, entry := range entries {
+		seconds, ok := strings.CutSuffix(entry.Name(), ".pem")
+		if !ok {
+			continue
+		}
+		created, err := strconv.ParseInt(seconds, 10, 64)
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		private, err := signing.LoadKey(path)
+		if errors.Is(err, os.ErrNotExist) {
+			// Another instance deleted the key since the directory was read.
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, path)
+		}
+		keys = append(keys, newKey(private, time.Unix(created, 0), path))
+	}
+	slices.SortFunc(keys, func(a, b key) int {
+		return a.createdAt.Compare(b.createdAt)
+	})
+	return keys, nil
+}
+
+// create writes the key for now. Instances rotating in the same second share
+// the key the first of them wrote rather than overwriting each other's.
+func create(dir string, now time.Time) (*key, error) {
+	path := filepath.Join(dir, strconv.FormatInt(now.Unix(), 10) + ".pem")
+	private, err := signing.LoadOrCreateKey(path)
+	if err != nil {
+		return nil, err
+	}
+	created := newKey(private, time.Unix(now.Unix(), 0), path)
+	return &created, nil
+}
+
+func newKey(private ed25519.PrivateKey, createdAt time.Time, path string) key {
+	return key{
+		id: signing.KeyId(private.Public().(ed25519.PublicKey)),
+		private: private,
+		createdAt: createdAt,
+		path: path,
+	}
+}
+
+// signingKey is the newest key that has been published for overlap, or the
+// oldest key while none has.
+func (keyring *Keyring) signingKey(now time.Time) key {
+	current := keyring.keys[0]
+	for _, k := range keyring.keys {
+		if !now.Before(k.createdAt.Add(keyring.overlap)) {
+			current = k
+		}
+	}
+	return current
+}
+
+// Sign issues a token for the claims, filling in when it was issued and its
+// id.
+func (keyring *Keyring) Sign(claims Claims, now time.Time) (string, error) {
+	keyring.mu.RLock()
+	defer keyring.mu.RUnlock()
+	if len(keyring.keys) == 0 {
+		return "", ErrUnknownKey
+	}
+	k := keyring.signingKey(now)
+	id := make([]byte, 16)
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", err
+	}
+	claims.ID = base64.RawURLEncoding.EncodeToString(id)
+	claims.IssuedAt = now.Unix()
+	encodedHeader, err := encode(header{Algorithm: Algorithm, Type: "JWT", KeyId: k.id})
+	if err != nil {
+		return "", err
+	}
+	encodedClaims, err := encode(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := encodedHeader + "." + encodedClaims
+	signature := ed25519.Sign(k.private, []byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func encode(value any) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+// Verify checks the signature and lifetime of a token issued by issuer and
+// returns its claims.
+func (keyring *Keyring) Verify(token string, issuer string, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+	var h header
+	err := decode(parts[0], &h)
+	if err != nil || h.Algorithm != Algorithm {
+		return nil, ErrInvalidToken
+	}
+	public, ok := keyring.publicKey(h.KeyId)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, h.KeyId)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !ed25519.Verify(public, []byte(parts[0] + "." + parts[1]), signature) {
+		return nil, ErrInvalidToken
+	}
+	var claims Claims
+	err = decode(parts[1], &claims)
+	if err != nil || claims.Issuer != issuer || claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+	if time.Unix(claims.IssuedAt, 0).After(now.Add(leeway)) {
+		return nil, ErrInvalidToken
+	}
+	if !now.Before(time.Unix(claims.ExpiresAt, 0)) {
+		return nil, ErrExpiredToken
+	}
+	return &claims, nil
+}
+
+func decode(part string, value any) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(decoded, value)
+}
+
+func (keyring *Keyring) publicKey(id string) (ed25519.PublicKey, bool) {
+	keyring.mu.RLock()
+	defer keyring.mu.RUnlock()
+	for _, k := range keyring.keys {
+		if k.id == id {
+			return k.private.Public().(ed25519.PublicKey), true
+		}
+	}
+	return nil, false
+}
+
+// JWKS returns every published key, including ones not yet or no longer
+// signing tokens.
+func (keyring *Keyring) JWKS() JWKSet {
+	keyring.mu.RLock()
+	defer keyring.mu.RUnlock()
+	set := JWKSet{Keys: []JWK{}}
+	for _, k := range keyring.keys {
+		set.Keys = append(set.Keys, JWK{
+			KeyType: "OKP",
+			Curve: "Ed25519",
+			X: base64.RawURLEncoding.EncodeToString(k.private.Public().(ed25519.PublicKey)),
+			KeyId: k.id,
+			Algorithm: Algorithm,
+			Use: "sig",
+		})
+	}
+	return set
+}
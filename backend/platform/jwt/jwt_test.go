@@ -0,0 +1,127 @@
+package jwt_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"geraldaddo.com/live-voting-system/platform/jwt"
+)
+
+const issuer = "test-issuer"
+
+func openKeyring(t *testing.T) (*jwt.Keyring, string) {
+	t.Helper()
+	dir := t.TempDir()
+	keyring, err := jwt.OpenKeyring(dir, 24 * time.Hour, time.Hour)
+	if err != nil {
+		t.Fatalf("Could not open keyring: %v", err)
+	}
+	return keyring, dir
+}
+
+func TestSignAndVerify(t *testing.T) {
+	keyring, _ := openKeyring(t)
+	now := time.Now()
+	token, err := keyring.Sign(jwt.Claims{Issuer: issuer, Subject: "test-user-id", SessionId: "test-session-id", ExpiresAt: now.Add(15 * time.Minute).Unix()}, now)
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	parts := strings.Split(token, ".")
+	tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"test-issuer","sub":"admin-id","exp":9999999999}`)) + "." + parts[2]
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + parts[1] + "."
+
+	tests := []struct {
+		name string
+		token string
+		issuer string
+		now time.Time
+		expected error
+	}{
+		{"Valid token", token, issuer, now, nil},
+		{"Expired token", token, issuer, now.Add(15 * time.Minute), jwt.ErrExpiredToken},
+		{"Other issuer", token, "other-issuer", now, jwt.ErrInvalidToken},
+		{"Tampered claims", tampered, issuer, now, jwt.ErrInvalidToken},
+		{"Unsigned token", none, issuer, now, jwt.ErrInvalidToken},
+		{"Not a token", "not-a-token", issuer, now, jwt.ErrInvalidToken},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claims, err := keyring.Verify(test.token, test.issuer, test.now)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+			if test.expected == nil && (claims.Subject != "test-user-id" || claims.SessionId != "test-session-id" || claims.ID == "") {
+				t.Errorf("Expected the signed claims but got %+v", claims)
+			}
+		})
+	}
+}
+
+func TestVerifyShouldRejectTokensOfOtherKeyrings(t *testing.T) {
+	keyring, _ := openKeyring(t)
+	other, _ := openKeyring(t)
+	now := time.Now()
+	token, _ := other.Sign(jwt.Claims{Issuer: issuer, Subject: "test-user-id", ExpiresAt: now.Add(time.Minute).Unix()}, now)
+	_, err := keyring.Verify(token, issuer, now)
+	if !errors.Is(err, jwt.ErrUnknownKey) {
+		t.Errorf("Expected error: %v but got %v", jwt.ErrUnknownKey, err)
+	}
+}
+
+func TestRotateShouldOverlapKeys(t *testing.T) {
+	keyring, dir := openKeyring(t)
+	start := time.Now()
+	sign := func(now time.Time) string {
+		token, err := keyring.Sign(jwt.Claims{Issuer: issuer, Subject: "test-user-id", ExpiresAt: now.Add(time.Hour).Unix()}, now)
+		if err != nil {
+			t.Fatalf("Could not sign token: %v", err)
+		}
+		return token
+	}
+	keyId := func(token string) string {
+		header, _ := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[0])
+		return string(header)
+	}
+	countKeys := func() int {
+		entries, _ := os.ReadDir(dir)
+		return len(entries)
+	}
+
+	oldToken := sign(start)
+	rotated := start.Add(25 * time.Hour)
+	err := keyring.Rotate(rotated)
+	if err != nil {
+		t.Fatalf("Could not rotate keys: %v", err)
+	}
+	if countKeys() != 2 || len(keyring.JWKS().Keys) != 2 {
+		t.Fatalf("Expected the new key to be published alongside the old one")
+	}
+	if keyId(sign(rotated)) != keyId(oldToken) {
+		t.Errorf("Expected the old key to keep signing until the new key has been published for the overlap")
+	}
+	newToken := sign(rotated.Add(time.Hour))
+	if keyId(newToken) == keyId(oldToken) {
+		t.Errorf("Expected the new key to sign once the overlap has passed")
+	}
+	_, err = keyring.Verify(oldToken, issuer, start.Add(30 * time.Minute))
+	if err != nil {
+		t.Errorf("Expected tokens of the old key to verify during the overlap but got %v", err)
+	}
+
+	err = keyring.Rotate(rotated.Add(2 * time.Hour))
+	if err != nil {
+		t.Fatalf("Could not rotate keys: %v", err)
+	}
+	if countKeys() != 1 || len(keyring.JWKS().Keys) != 1 {
+		t.Errorf("Expected the old key to be deleted once its tokens have expired")
+	}
+	_, err = keyring.Verify(newToken, issuer, rotated.Add(90 * time.Minute))
+	if err != nil {
+		t.Errorf("Expected tokens of the new key to verify but got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+)
+
+// AdvisoryLock elects a single leader among instances sharing a database. The
+// lock is a session level Postgres advisory lock held on a dedicated
+// connection, so it is released automatically if the holder crashes or loses
+// its connection and another instance can take over.
+type AdvisoryLock struct {
+	db *sql.DB
+	key int64
+	conn *sql.Conn
+}
+
+func NewAdvisoryLock(db *sql.DB, key int64) *AdvisoryLock {
+	return &AdvisoryLock{db: db, key: key}
+}
+
+// TryAcquire reports whether this instance holds the lock, taking it if it is
+// free. It is not safe for concurrent use.
+func (lock *AdvisoryLock) TryAcquire(ctx context.Context) (bool, error) {
+	if lock.conn != nil {
+		err := lock.conn.PingContext(ctx)
+		if err == nil {
+			return true, nil
+		}
+		discard(lock.conn)
+		lock.conn = nil
+	}
+	conn, err := lock.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	var acquired bool
+	err = conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, lock.key).Scan(&acquired)
+	if err != nil {
+		// The lock may have been taken even though the answer was lost.
+		discard(conn)
+		return false, err
+	}
+	if !acquired {
+		_ = conn.Close()
+		return false, nil
+	}
+	lock.conn = conn
+	return true, nil
+}
+
+func (lock *AdvisoryLock) Release(ctx context.Context) error {
+	if lock.conn == nil {
+		return nil
+	}
+	_, err := lock.conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, lock.key)
+	if err != nil {
+		discard(lock.conn)
+		lock.conn = nil
+		return err
+	}
+	err = lock.conn.Close()
+	lock.conn = nil
+	return err
+}
+
+// discard closes a connection that may still hold the lock instead of handing
+// it back to the pool, where the lock would outlive this instance's claim to
+// it and no instance could take it again. Closing the session releases the
+// lock.
+func discard(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error {
+		return driver.ErrBadConn
+	})
+	_ = conn.Close()
+}
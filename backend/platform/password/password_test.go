@@ -0,0 +1,69 @@
+package password_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/platform/password"
+)
+
+// testParams keep the tests fast; the format is the same as DefaultParams.
+var testParams = password.Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestHashAndVerify(t *testing.T) {
+	hash, err := password.HashWithParams("correct horse battery staple", testParams)
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("Expected an argon2id PHC string but got %s", hash)
+	}
+	other, _ := password.HashWithParams("correct horse battery staple", testParams)
+	if hash == other {
+		t.Errorf("Expected hashes of the same password to use different salts")
+	}
+
+	tests := []struct {
+		name string
+		password string
+		expected bool
+	}{
+		{"Same password", "correct horse battery staple", true},
+		{"Different password", "correct horse battery stapler", false},
+		{"Empty password", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ok, err := password.Verify(test.password, hash)
+			if err != nil {
+				t.Fatalf("Expected no error but got %v", err)
+			}
+			if ok != test.expected {
+				t.Errorf("Expected match: %t but got %t", test.expected, ok)
+			}
+		})
+	}
+}
+
+func TestVerifyShouldRejectMalformedHashes(t *testing.T) {
+	tests := []string{
+		"",
+		"plaintext",
+		"$2a$10$abcdefghijklmnopqrstuv",
+		"$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=0,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$not base64$a2V5",
+	}
+
+	for _, hash := range tests {
+		t.Run(hash, func(t *testing.T) {
+			_, err := password.Verify("password", hash)
+			if !errors.Is(err, password.ErrInvalidHash) {
+				t.Errorf("Expected error: %v but got %v", password.ErrInvalidHash, err)
+			}
+		})
+	}
+}
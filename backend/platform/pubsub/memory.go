@@ -0,0 +1,81 @@
+package pubsub
+
+import (
+	"context"
+	"net"
+	"sync"
+)
+
+const subscriberBuffer = 64
+
+// MemoryPubSub only reaches subscribers in the same process. It is meant for
+// tests and single instance deployments.
+type MemoryPubSub struct {
+	mu sync.RWMutex
+	closed bool
+	subscribers map[string]map[chan Message]struct{}
+}
+
+func NewMemoryPubSub() *MemoryPubSub {
+	return &MemoryPubSub{subscribers: make(map[string]map[chan Message]struct{})}
+}
+
+func (ps *MemoryPubSub) Publish(ctx context.Context, topic string, payload []byte) error {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+	if ps.closed {
+		return net.ErrClosed
+	}
+	message := Message{Topic: topic, Payload: payload}
+	for subscriber := range ps.subscribers[topic] {
+		select {
+		case subscriber <- message:
+		default:
+		}
+	}
+	return nil
+}
+
+func (ps *MemoryPubSub) Subscribe(ctx context.Context, topic string) (<-chan Message, error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.closed {
+		return nil, net.ErrClosed
+	}
+	subscriber := make(chan Message, subscriberBuffer)
+	if ps.subscribers[topic] == nil {
+		ps.subscribers[topic] = make(map[chan Message]struct{})
+	}
+	ps.subscribers[topic][subscriber] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		ps.mu.Lock()
+		defer ps.mu.Unlock()
+		if _, exists := ps.subscribers[topic][subscriber]; !exists {
+			return
+		}
+		delete(ps.subscribers[topic], subscriber)
+		if len(ps.subscribers[topic]) == 0 {
+			delete(ps.subscribers, topic)
+		}
+		close(subscriber)
+	}()
+	return subscriber, nil
+}
+
+func (ps *MemoryPubSub) Close() error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.closed {
+		return nil
+	}
+	ps.closed = true
+	for topic, subscribers := range ps.subscribers {
+		for subscriber := range subscribers {
+			close(subscriber)
+		}
+		delete(ps.subscribers, topic)
+	}
+	return nil
+}
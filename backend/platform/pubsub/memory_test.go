@@ -0,0 +1,64 @@
+package pubsub_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"geraldaddo.com/live-voting-system/platform/pubsub"
+)
+
+func receive(t *testing.T, messages <-chan pubsub.Message) (pubsub.Message, bool) {
+	t.Helper()
+	select {
+	case message, ok := <-messages:
+		return message, ok
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for message")
+		return pubsub.Message{}, false
+	}
+}
+
+func TestMemoryPubSubDeliversToEverySubscriber(t *testing.T) {
+	ps := pubsub.NewMemoryPubSub()
+	defer ps.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, _ := ps.Subscribe(ctx, "votes")
+	second, _ := ps.Subscribe(ctx, "votes")
+	other, _ := ps.Subscribe(ctx, "status")
+
+	err := ps.Publish(ctx, "votes", []byte("payload"))
+	if err != nil {
+		t.Fatal("Could not publish", err.Error())
+	}
+	for _, messages := range []<-chan pubsub.Message{first, second} {
+		message, _ := receive(t, messages)
+		if message.Topic != "votes" || string(message.Payload) != "payload" {
+			t.Errorf("Expected payload on votes topic but got %s on %s", message.Payload, message.Topic)
+		}
+	}
+	select {
+	case message := <-other:
+		t.Errorf("Subscriber on another topic received %s", message.Payload)
+	default:
+	}
+}
+
+func TestMemoryPubSubClosesSubscriptionWhenContextIsDone(t *testing.T) {
+	ps := pubsub.NewMemoryPubSub()
+	defer ps.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	messages, _ := ps.Subscribe(ctx, "votes")
+	cancel()
+
+	if _, ok := receive(t, messages); ok {
+		t.Error("Subscription was not closed")
+	}
+	err := ps.Publish(context.Background(), "votes", []byte("payload"))
+	if err != nil {
+		t.Error("Publishing without subscribers returned an error", err.Error())
+	}
+}
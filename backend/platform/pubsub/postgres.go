@@ -0,0 +1,106 @@
+package pubsub
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+const (
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+	listenerPingInterval = 90 * time.Second
+)
+
+// PostgresPubSub fans messages out to every instance connected to the same
+// database using LISTEN/NOTIFY. Messages are published through the shared
+// connection pool and received on one dedicated listener connection per
+// instance, which then hands them to local subscribers. Postgres limits a
+// payload to just under 8000 bytes and does not replay notifications sent
+// while the listener was reconnecting.
+type PostgresPubSub struct {
+	db *sql.DB
+	listener *pq.Listener
+	local *MemoryPubSub
+	mu sync.Mutex
+	topics map[string]struct{}
+	done chan struct{}
+	log *zap.Logger
+}
+
+func NewPostgresPubSub(db *sql.DB, dbUrl string, logger *zap.Logger) *PostgresPubSub {
+	onEvent := func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventDisconnected, pq.ListenerEventConnectionAttemptFailed:
+			logger.Error("Pub/sub listener lost its database connection", zap.Error(err))
+		case pq.ListenerEventReconnected:
+			logger.Warn("Pub/sub listener reconnected, notifications sent while disconnected were missed")
+		}
+	}
+	ps := &PostgresPubSub{
+		db: db,
+		listener: pq.NewListener(dbUrl, minReconnectInterval, maxReconnectInterval, onEvent),
+		local: NewMemoryPubSub(),
+		topics: make(map[string]struct{}),
+		done: make(chan struct{}),
+		log: logger,
+	}
+	go ps.dispatch()
+	return ps
+}
+
+func (ps *PostgresPubSub) Publish(ctx context.Context, topic string, payload []byte) error {
+	_, err := ps.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, topic, string(payload))
+	return err
+}
+
+func (ps *PostgresPubSub) Subscribe(ctx context.Context, topic string) (<-chan Message, error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if _, listening := ps.topics[topic]; !listening {
+		err := ps.listener.Listen(topic)
+		if err != nil && err != pq.ErrChannelAlreadyOpen {
+			return nil, err
+		}
+		ps.topics[topic] = struct{}{}
+	}
+	return ps.local.Subscribe(ctx, topic)
+}
+
+func (ps *PostgresPubSub) Close() error {
+	close(ps.done)
+	err := ps.listener.Close()
+	_ = ps.local.Close()
+	return err
+}
+
+func (ps *PostgresPubSub) dispatch() {
+	ping := time.NewTicker(listenerPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ps.done:
+			return
+		case <-ping.C:
+			go func() {
+				err := ps.listener.Ping()
+				if err != nil {
+					ps.log.Warn("Pub/sub listener ping failed", zap.Error(err))
+				}
+			}()
+		case notification, ok := <-ps.listener.Notify:
+			if !ok {
+				return
+			}
+			// A nil notification marks a reconnect and carries no message.
+			if notification == nil {
+				continue
+			}
+			_ = ps.local.Publish(context.Background(), notification.Channel, []byte(notification.Extra))
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package pubsub
+
+import "context"
+
+type Message struct {
+	Topic string
+	Payload []byte
+}
+
+// PubSub delivers every message published on a topic to all of its current
+// subscribers. Subscription channels are closed once the subscribing context
+// is done. Delivery is best effort: a subscriber that stops reading loses
+// messages instead of stalling publishers.
+type PubSub interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	Subscribe(ctx context.Context, topic string) (<-chan Message, error)
+	Close() error
+}
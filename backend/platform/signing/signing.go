@@ -0,0 +1,193 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Algorithm is the signature scheme of every certificate.
+const Algorithm = "Ed25519"
+
+var (
+	ErrInvalidSignature = errors.New("Certificate signature is invalid")
+	ErrUnknownKey = errors.New("Certificate was signed by a different key")
+	ErrInvalidKey = errors.New("Key is not an Ed25519 key")
+)
+
+// Certificate is a signed JSON document. Document holds the encoded JSON as
+// a string, so the signed bytes survive the certificate itself being stored
+// or pretty-printed.
+type Certificate struct {
+	Document string
+	Algorithm string
+	KeyId string
+	Signature []byte
+}
+
+// PublicKey is the key certificates are checked against, as published by the
+// server.
+type PublicKey struct {
+	Algorithm string
+	KeyId string
+	Key []byte
+}
+
+type Signer struct {
+	key ed25519.PrivateKey
+	keyId string
+}
+
+func NewSigner(key ed25519.PrivateKey) *Signer {
+	return &Signer{key: key, keyId: KeyId(key.Public().(ed25519.PublicKey))}
+}
+
+// KeyId names a public key by the start of its SHA-256 fingerprint.
+func KeyId(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+func (signer *Signer) PublicKey() PublicKey {
+	return PublicKey{
+		Algorithm: Algorithm,
+		KeyId: signer.keyId,
+		Key: signer.key.Public().(ed25519.PublicKey),
+	}
+}
+
+// Sign encodes the document as JSON and signs it.
+func (signer *Signer) Sign(document any) (*Certificate, error) {
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	return &Certificate{
+		Document: string(encoded),
+		Algorithm: Algorithm,
+		KeyId: signer.keyId,
+		Signature: ed25519.Sign(signer.key, encoded),
+	}, nil
+}
+
+// Verify checks that the certificate was signed by the key and that its
+// document has not been changed since.
+func Verify(certificate *Certificate, key ed25519.PublicKey) error {
+	if certificate.Algorithm != Algorithm {
+		return fmt.Errorf("%w: unsupported algorithm %s", ErrInvalidSignature, certificate.Algorithm)
+	}
+	if certificate.KeyId != KeyId(key) {
+		return fmt.Errorf("%w: %s", ErrUnknownKey, certificate.KeyId)
+	}
+	if !ed25519.Verify(key, []byte(certificate.Document), certificate.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ParsePublicKey reads a public key either as published by the server or as
+// the base64 encoding of the raw key.
+func ParsePublicKey(data []byte) (ed25519.PublicKey, error) {
+	var published PublicKey
+	raw := []byte(strings.TrimSpace(string(data)))
+	if json.Unmarshal(raw, &published) == nil {
+		raw = published.Key
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(string(raw))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidKey, err.Error())
+		}
+		raw = decoded
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: %d bytes", ErrInvalidKey, len(raw))
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// LoadOrCreateKey reads a PKCS #8 PEM private key from path. The first time
+// the server starts the file does not exist yet, so a new key is generated
+// and written there. When several instances start at once only one key is
+// written and every instance loads it.
+func LoadOrCreateKey(path string) (ed25519.PrivateKey, error) {
+	key, err := LoadKey(path)
+	if !errors.Is(err, os.ErrNotExist) {
+		return key, err
+	}
+	key, err = createKey(path)
+	if errors.Is(err, os.ErrExist) {
+		return LoadKey(path)
+	}
+	return key, err
+}
+
+// LoadKey reads a PKCS #8 PEM private key from path.
+func LoadKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: %s is not PEM encoded", ErrInvalidKey, path)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidKey, path)
+	}
+	return key, nil
+}
+
+// createKey writes a new key to path, failing with os.ErrExist if another
+// process got there first. The key is written to a temporary file and linked
+// into place, which like O_EXCL never replaces an existing file but also
+// never exposes a partly written one.
+func createKey(path string) (ed25519.PrivateKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), ".key-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: encoded}))
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = os.Link(file.Name(), path)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,153 @@
+package signing_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"geraldaddo.com/live-voting-system/platform/signing"
+)
+
+func newSigner(t *testing.T) *signing.Signer {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal("Could not generate key", err.Error())
+	}
+	return signing.NewSigner(key)
+}
+
+func TestVerify(t *testing.T) {
+	signer := newSigner(t)
+	other := newSigner(t)
+	document := map[string]any{"ElectionId": "test-election-id", "Winners": []string{"a"}}
+
+	tests := []struct {
+		name string
+		tamper func(certificate *signing.Certificate)
+		key []byte
+		expected error
+	}{
+		{"Untouched certificate", func(certificate *signing.Certificate) {}, signer.PublicKey().Key, nil},
+		{
+			"Changed document",
+			func(certificate *signing.Certificate) {
+				certificate.Document = `{"ElectionId":"test-election-id","Winners":["b"]}`
+			},
+			signer.PublicKey().Key,
+			signing.ErrInvalidSignature,
+		},
+		{
+			"Changed signature",
+			func(certificate *signing.Certificate) { certificate.Signature[0] ^= 1 },
+			signer.PublicKey().Key,
+			signing.ErrInvalidSignature,
+		},
+		{"Other key", func(certificate *signing.Certificate) {}, other.PublicKey().Key, signing.ErrUnknownKey},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			certificate, err := signer.Sign(document)
+			if err != nil {
+				t.Fatal("Could not sign document", err.Error())
+			}
+			test.tamper(certificate)
+			err = signing.Verify(certificate, test.key)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected error: %v but got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestVerifyShouldAcceptCertificateReadBackFromJSON(t *testing.T) {
+	signer := newSigner(t)
+	certificate, err := signer.Sign(map[string]int{"b": 2, "a": 1})
+	if err != nil {
+		t.Fatal("Could not sign document", err.Error())
+	}
+	encoded, _ := json.MarshalIndent(certificate, "", "  ")
+	var decoded signing.Certificate
+	err = json.Unmarshal(encoded, &decoded)
+	if err != nil {
+		t.Fatal("Could not decode certificate", err.Error())
+	}
+
+	err = signing.Verify(&decoded, signer.PublicKey().Key)
+
+	if err != nil {
+		t.Error("Expected certificate to verify but got", err.Error())
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	signer := newSigner(t)
+	published, _ := json.Marshal(signer.PublicKey())
+	encoded := base64.StdEncoding.EncodeToString(signer.PublicKey().Key)
+
+	for _, input := range [][]byte{published, []byte(encoded + "\n")} {
+		key, err := signing.ParsePublicKey(input)
+		if err != nil {
+			t.Fatal("Could not parse public key", err.Error())
+		}
+		if signing.KeyId(key) != signer.PublicKey().KeyId {
+			t.Errorf("Expected key %s but got %s", signer.PublicKey().KeyId, signing.KeyId(key))
+		}
+	}
+	_, err := signing.ParsePublicKey([]byte("bm90IGEga2V5"))
+	if !errors.Is(err, signing.ErrInvalidKey) {
+		t.Errorf("Expected error: %v but got %v", signing.ErrInvalidKey, err)
+	}
+}
+
+func TestLoadOrCreateKeyShouldReuseKeyOnDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "signing.pem")
+
+	created, err := signing.LoadOrCreateKey(path)
+	if err != nil {
+		t.Fatal("Could not create key", err.Error())
+	}
+	loaded, err := signing.LoadOrCreateKey(path)
+	if err != nil {
+		t.Fatal("Could not load key", err.Error())
+	}
+
+	if !created.Equal(loaded) {
+		t.Error("Expected the key written on first start to be loaded again")
+	}
+}
+
+func TestLoadOrCreateKeyShouldAgreeOnOneKeyWhenStartedTogether(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "signing.pem")
+
+	keys := make([]ed25519.PrivateKey, 8)
+	errs := make([]error, len(keys))
+	var wg sync.WaitGroup
+	for i := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			keys[i], errs[i] = signing.LoadOrCreateKey(path)
+		}()
+	}
+	wg.Wait()
+
+	loaded, err := signing.LoadKey(path)
+	if err != nil {
+		t.Fatal("Could not load key", err.Error())
+	}
+	for i, key := range keys {
+		if errs[i] != nil {
+			t.Fatal("Could not load or create key", errs[i].Error())
+		}
+		if !key.Equal(loaded) {
+			t.Error("Expected every instance to use the key on disk")
+		}
+	}
+}